@@ -6,16 +6,23 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/azsharkawy5/SRBCS/config"
+	"github.com/azsharkawy5/SRBCS/internal/domain"
 	"github.com/azsharkawy5/SRBCS/internal/handler"
+	"github.com/azsharkawy5/SRBCS/internal/notification"
 	"github.com/azsharkawy5/SRBCS/internal/repository"
 	"github.com/azsharkawy5/SRBCS/internal/routes"
 	"github.com/azsharkawy5/SRBCS/internal/service"
 	"github.com/azsharkawy5/SRBCS/pkg/httpserver"
+	"github.com/azsharkawy5/SRBCS/pkg/mailer"
+	"github.com/azsharkawy5/SRBCS/pkg/otp"
 	"github.com/azsharkawy5/SRBCS/pkg/postgres"
+	"github.com/azsharkawy5/SRBCS/pkg/scheduler"
+	"github.com/azsharkawy5/SRBCS/pkg/token"
 	"github.com/gin-gonic/gin"
 )
 
@@ -54,12 +61,125 @@ func main() {
 
 	// Initialize repositories
 	userRepo := repository.NewPostgresUserRepository(dbConn.DB)
+	refreshTokenRepo := repository.NewPostgresRefreshTokenRepository(dbConn.DB)
+	apiKeyRepo := repository.NewPostgresAPIKeyRepository(dbConn.DB)
+	emailChangeRepo := repository.NewPostgresEmailChangeRepository(dbConn.DB)
+	ledgerRepo := repository.NewPostgresLedgerRepository(dbConn.DB)
+	earnRuleRepo := repository.NewPostgresEarnRuleRepository(dbConn.DB)
+	rewardRepo := repository.NewPostgresRewardRepository(dbConn.DB)
+	redemptionRepo := repository.NewPostgresRedemptionRepository(dbConn.DB)
+	transferRepo := repository.NewPostgresTransferRepository(dbConn.DB)
+	idempotencyRepo := repository.NewPostgresIdempotencyRepository(dbConn.DB)
+	tierRepo := repository.NewPostgresTierRepository(dbConn.DB)
+	referralRepo := repository.NewPostgresReferralRepository(dbConn.DB)
+	campaignRepo := repository.NewPostgresCampaignRepository(dbConn.DB)
+	adjustmentRepo := repository.NewPostgresAdjustmentRepository(dbConn.DB)
+	reversalRepo := repository.NewPostgresReversalRepository(dbConn.DB)
+
+	// Initialize outbound email
+	mail, err := newMailer(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+	mailDispatcher := mailer.NewDispatcher(mail, mailer.DispatcherConfig{
+		Workers:      cfg.Mail.Workers,
+		QueueSize:    cfg.Mail.QueueSize,
+		MaxRetries:   cfg.Mail.MaxRetries,
+		RetryBackoff: cfg.Mail.RetryBackoff,
+	})
+
+	// Initialize notifiers
+	notifier, err := notification.NewEmailNotifier(mailDispatcher, notification.EmailNotifierConfig{
+		From:           cfg.Mail.From,
+		OTPTTL:         cfg.OTP.TTL,
+		EmailChangeTTL: cfg.EmailChange.CodeTTL,
+		EmailRevertTTL: cfg.EmailChange.RevertTTL,
+		EmailRevertURL: strings.TrimRight(cfg.Server.PublicURL, "/") + "/api/v1/users/email/revert",
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize email notifier: %v", err)
+	}
 
 	// Initialize services
-	userService := service.NewUserService(userRepo)
+	pointTypes := domain.PointTypePolicies{
+		domain.PointTypeCredits: {
+			Transferable: cfg.Credits.Transferable,
+			Redeemable:   cfg.Credits.Redeemable,
+			Lifetime:     cfg.Credits.Lifetime,
+		},
+		domain.PointTypeTierPoints: {
+			Transferable: cfg.TierPoints.Transferable,
+			Redeemable:   cfg.TierPoints.Redeemable,
+			Lifetime:     cfg.TierPoints.Lifetime,
+		},
+	}
+	referralService := service.NewReferralService(referralRepo, userRepo, ledgerRepo, service.ReferralConfig{
+		ReferrerReward:    int64(cfg.Referral.ReferrerReward),
+		RefereeReward:     int64(cfg.Referral.RefereeReward),
+		MaxPerEmailDomain: cfg.Referral.MaxPerEmailDomain,
+		PointTypes:        pointTypes,
+	})
+	otpHasher := otp.NewHasher(cfg.OTP.HashSecret)
+	userService := service.NewUserService(userRepo, refreshTokenRepo, emailChangeRepo, referralService, notifier, otpHasher, service.OTPConfig{
+		TTL:             cfg.OTP.TTL,
+		MaxAttempts:     cfg.OTP.MaxAttempts,
+		LockoutDuration: cfg.OTP.LockoutDuration,
+		ResendCooldown:  cfg.OTP.ResendCooldown,
+	}, service.EmailChangeConfig{
+		CodeTTL:   cfg.EmailChange.CodeTTL,
+		RevertTTL: cfg.EmailChange.RevertTTL,
+	})
+	tokenSigner := token.NewSigner(cfg.Auth.JWTSecret, cfg.Auth.JWTIssuer)
+	tokenService := service.NewTokenService(userRepo, refreshTokenRepo, tokenSigner, service.TokenConfig{
+		AccessTokenTTL:  cfg.Auth.AccessTokenTTL,
+		RefreshTokenTTL: cfg.Auth.RefreshTokenTTL,
+	})
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo)
+	ledgerService := service.NewLedgerService(ledgerRepo, service.LedgerConfig{
+		PointTypes: pointTypes,
+	})
+	tierService := service.NewTierService(tierRepo, service.TierConfig{
+		QualifyingWindow: cfg.Tiers.QualifyingWindow,
+	})
+	earnService := service.NewEarnService(earnRuleRepo, campaignRepo, userRepo, ledgerRepo, tierService, service.EarnConfig{
+		PointTypes: pointTypes,
+	})
+	campaignService := service.NewCampaignService(campaignRepo)
+	adjustmentService := service.NewAdjustmentService(adjustmentRepo, userRepo, ledgerRepo, service.AdjustmentConfig{
+		PointTypes: pointTypes,
+	})
+	reversalService := service.NewReversalService(reversalRepo, ledgerRepo, tierService, service.ReversalConfig{
+		PointTypes: pointTypes,
+	})
+	rewardService := service.NewRewardService(rewardRepo)
+	redemptionService := service.NewRedemptionService(redemptionRepo, rewardRepo, ledgerRepo, service.RedemptionConfig{
+		HoldTTL:    cfg.Redemption.HoldTTL,
+		PointTypes: pointTypes,
+	})
+	transferService := service.NewTransferService(transferRepo, userRepo, ledgerRepo, service.TransferConfig{
+		MaxAmount:  int64(cfg.Transfer.MaxAmount),
+		DailyLimit: int64(cfg.Transfer.DailyLimit),
+		PointTypes: pointTypes,
+	})
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, service.IdempotencyConfig{
+		KeyTTL:          cfg.Idempotency.KeyTTL,
+		InProgressLease: cfg.Idempotency.InProgressLease,
+	})
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService)
+	authHandler := handler.NewAuthHandler(userService, tokenService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	walletHandler := handler.NewWalletHandler(ledgerService)
+	earnRuleHandler := handler.NewEarnRuleHandler(earnService)
+	rewardHandler := handler.NewRewardHandler(rewardService)
+	redemptionHandler := handler.NewRedemptionHandler(redemptionService)
+	transferHandler := handler.NewTransferHandler(transferService)
+	tierHandler := handler.NewTierHandler(tierService)
+	referralHandler := handler.NewReferralHandler(referralService)
+	campaignHandler := handler.NewCampaignHandler(campaignService)
+	adjustmentHandler := handler.NewAdjustmentHandler(adjustmentService)
+	reversalHandler := handler.NewReversalHandler(reversalService)
 
 	// Initialize HTTP server
 	serverConfig := httpserver.Config{
@@ -74,7 +194,94 @@ func main() {
 	engine := server.Engine()
 
 	// Register routes
-	routes.RegisterRoutes(engine, userHandler)
+	routes.RegisterRoutes(engine, routes.Handlers{
+		User:       userHandler,
+		Auth:       authHandler,
+		APIKey:     apiKeyHandler,
+		Wallet:     walletHandler,
+		EarnRule:   earnRuleHandler,
+		Reward:     rewardHandler,
+		Redemption: redemptionHandler,
+		Transfer:   transferHandler,
+		Tier:       tierHandler,
+		Referral:   referralHandler,
+		Campaign:   campaignHandler,
+		Adjustment: adjustmentHandler,
+		Reversal:   reversalHandler,
+	}, routes.Authenticators{
+		AccessToken: tokenService,
+		APIKey:      apiKeyService,
+	}, idempotencyService)
+
+	// Start background jobs
+	jobs := scheduler.New()
+	jobs.Add(scheduler.Job{
+		Name:     "purge-deleted-users",
+		Interval: cfg.Jobs.UserPurgeInterval,
+		Run: func(ctx context.Context) error {
+			purged, err := userService.PurgeDeletedUsers(ctx, cfg.Jobs.UserRetentionPeriod)
+			if err == nil && purged > 0 {
+				log.Printf("Purged %d deleted users", purged)
+			}
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "release-expired-redemption-holds",
+		Interval: cfg.Jobs.RedemptionReleaseInterval,
+		Run: func(ctx context.Context) error {
+			released, err := redemptionService.ReleaseExpiredHolds(ctx)
+			if released > 0 {
+				log.Printf("Released %d expired redemption holds", released)
+			}
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "expire-credits",
+		Interval: cfg.Jobs.CreditExpiryInterval,
+		Run: func(ctx context.Context) error {
+			expired, err := ledgerService.ExpireCredits(ctx)
+			if expired > 0 {
+				log.Printf("Expired credits from %d lots", expired)
+			}
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "purge-expired-idempotency-keys",
+		Interval: cfg.Jobs.IdempotencyPurgeInterval,
+		Run: func(ctx context.Context) error {
+			purged, err := idempotencyService.PurgeExpiredKeys(ctx)
+			if err == nil && purged > 0 {
+				log.Printf("Purged %d expired idempotency keys", purged)
+			}
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "recalculate-tiers",
+		Interval: cfg.Jobs.TierRecalculationInterval,
+		Run: func(ctx context.Context) error {
+			changed, err := tierService.RecalculateAllTiers(ctx)
+			if changed > 0 {
+				log.Printf("Moved %d users to a new tier", changed)
+			}
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "reward-verified-referrals",
+		Interval: cfg.Jobs.ReferralRewardInterval,
+		Run: func(ctx context.Context) error {
+			rewarded, err := referralService.RewardVerifiedReferrals(ctx)
+			if rewarded > 0 {
+				log.Printf("Rewarded %d referrals whose payout failed at verification", rewarded)
+			}
+			return err
+		},
+	})
+	jobs.Start()
 
 	// Start server in a goroutine
 	go func() {
@@ -98,9 +305,34 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop background jobs
+	jobs.Stop()
+
+	// Flush queued emails
+	if err := mailDispatcher.Close(ctx); err != nil {
+		log.Printf("Email queue not fully flushed: %v", err)
+	}
+
 	log.Println("Server exited")
 }
 
+// newMailer creates the mailer selected by the mail driver configuration
+func newMailer(cfg config.MailConfig) (mailer.Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+		}), nil
+	case "spool":
+		return mailer.NewFileSpoolMailer(cfg.SpoolDir)
+	default:
+		return mailer.NewStreamSpoolMailer(os.Stdout), nil
+	}
+}
+
 // ginWrapHTTPMiddleware adapts a net/http middleware (func(http.Handler) http.Handler) to gin.HandlerFunc
 func ginWrapHTTPMiddleware(mw func(http.Handler) http.Handler) func(*gin.Context) {
 	return func(c *gin.Context) {
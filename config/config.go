@@ -9,8 +9,20 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	OTP         OTPConfig
+	Auth        AuthConfig
+	Mail        MailConfig
+	EmailChange EmailChangeConfig
+	Credits     PointTypeConfig
+	TierPoints  PointTypeConfig
+	Redemption  RedemptionConfig
+	Transfer    TransferConfig
+	Tiers       TierConfig
+	Referral    ReferralConfig
+	Idempotency IdempotencyConfig
+	Jobs        JobsConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -20,6 +32,7 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	PublicURL    string // externally reachable base URL used in links sent to users
 }
 
 // DatabaseConfig holds database configuration
@@ -32,6 +45,91 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// OTPConfig holds one-time password configuration
+type OTPConfig struct {
+	TTL             time.Duration
+	HashSecret      string
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	ResendCooldown  time.Duration
+}
+
+// AuthConfig holds token signing configuration
+type AuthConfig struct {
+	JWTSecret       string
+	JWTIssuer       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// MailConfig holds outbound email configuration
+type MailConfig struct {
+	Driver       string // "smtp", "spool" (files in SpoolDir) or "stdout"
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SpoolDir     string
+	Workers      int
+	QueueSize    int
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+// EmailChangeConfig holds email change confirmation configuration
+type EmailChangeConfig struct {
+	CodeTTL   time.Duration
+	RevertTTL time.Duration
+}
+
+// PointTypeConfig holds the configuration of one point type
+type PointTypeConfig struct {
+	Lifetime     time.Duration // how long earned points stay usable; 0 disables expiry
+	Transferable bool
+	Redeemable   bool
+}
+
+// RedemptionConfig holds reward redemption configuration
+type RedemptionConfig struct {
+	HoldTTL time.Duration
+}
+
+// TransferConfig holds peer-to-peer credit transfer configuration
+type TransferConfig struct {
+	MaxAmount  int // per transfer
+	DailyLimit int // per sender over the last 24 hours
+}
+
+// TierConfig holds loyalty tier configuration
+type TierConfig struct {
+	QualifyingWindow time.Duration // how far back earned tier points count towards a tier
+}
+
+// ReferralConfig holds referral program configuration
+type ReferralConfig struct {
+	ReferrerReward    int // credits paid to the user who shared the code
+	RefereeReward     int // credits paid to the user who signed up with it
+	MaxPerEmailDomain int // most referees one referrer may bring from a single email domain
+}
+
+// IdempotencyConfig holds Idempotency-Key configuration
+type IdempotencyConfig struct {
+	KeyTTL          time.Duration
+	InProgressLease time.Duration
+}
+
+// JobsConfig holds background job configuration
+type JobsConfig struct {
+	UserPurgeInterval         time.Duration
+	UserRetentionPeriod       time.Duration
+	RedemptionReleaseInterval time.Duration
+	CreditExpiryInterval      time.Duration
+	IdempotencyPurgeInterval  time.Duration
+	TierRecalculationInterval time.Duration
+	ReferralRewardInterval    time.Duration
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	config := &Config{
@@ -41,6 +139,7 @@ func Load() (*Config, error) {
 			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
 			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
 			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			PublicURL:    getEnv("PUBLIC_URL", "http://localhost:8000"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -50,6 +149,74 @@ func Load() (*Config, error) {
 			DBName:   getEnv("DB_NAME", ""),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
+		OTP: OTPConfig{
+			TTL:             getDurationEnv("OTP_TTL", 10*time.Minute),
+			HashSecret:      getEnv("OTP_HASH_SECRET", ""),
+			MaxAttempts:     getIntEnv("OTP_MAX_ATTEMPTS", 5),
+			LockoutDuration: getDurationEnv("OTP_LOCKOUT_DURATION", 15*time.Minute),
+			ResendCooldown:  getDurationEnv("OTP_RESEND_COOLDOWN", time.Minute),
+		},
+		Auth: AuthConfig{
+			JWTSecret:       getEnv("JWT_SECRET", ""),
+			JWTIssuer:       getEnv("JWT_ISSUER", "srbcs"),
+			AccessTokenTTL:  getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "stdout"),
+			From:         getEnv("MAIL_FROM", "no-reply@srbcs.local"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			SpoolDir:     getEnv("MAIL_SPOOL_DIR", "./tmp/mail"),
+			Workers:      getIntEnv("MAIL_WORKERS", 2),
+			QueueSize:    getIntEnv("MAIL_QUEUE_SIZE", 100),
+			MaxRetries:   getIntEnv("MAIL_MAX_RETRIES", 3),
+			RetryBackoff: getDurationEnv("MAIL_RETRY_BACKOFF", 2*time.Second),
+		},
+		EmailChange: EmailChangeConfig{
+			CodeTTL:   getDurationEnv("EMAIL_CHANGE_CODE_TTL", 15*time.Minute),
+			RevertTTL: getDurationEnv("EMAIL_CHANGE_REVERT_TTL", 7*24*time.Hour),
+		},
+		Credits: PointTypeConfig{
+			Lifetime:     getDurationEnv("CREDIT_LIFETIME", 365*24*time.Hour),
+			Transferable: getBoolEnv("CREDITS_TRANSFERABLE", true),
+			Redeemable:   getBoolEnv("CREDITS_REDEEMABLE", true),
+		},
+		TierPoints: PointTypeConfig{
+			Lifetime:     getDurationEnv("TIER_POINT_LIFETIME", 0),
+			Transferable: getBoolEnv("TIER_POINTS_TRANSFERABLE", false),
+			Redeemable:   getBoolEnv("TIER_POINTS_REDEEMABLE", false),
+		},
+		Redemption: RedemptionConfig{
+			HoldTTL: getDurationEnv("REDEMPTION_HOLD_TTL", 15*time.Minute),
+		},
+		Transfer: TransferConfig{
+			MaxAmount:  getIntEnv("TRANSFER_MAX_AMOUNT", 10000),
+			DailyLimit: getIntEnv("TRANSFER_DAILY_LIMIT", 50000),
+		},
+		Tiers: TierConfig{
+			QualifyingWindow: getDurationEnv("TIER_QUALIFYING_WINDOW", 365*24*time.Hour),
+		},
+		Referral: ReferralConfig{
+			ReferrerReward:    getIntEnv("REFERRAL_REFERRER_REWARD", 500),
+			RefereeReward:     getIntEnv("REFERRAL_REFEREE_REWARD", 250),
+			MaxPerEmailDomain: getIntEnv("REFERRAL_MAX_PER_EMAIL_DOMAIN", 5),
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL:          getDurationEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+			InProgressLease: getDurationEnv("IDEMPOTENCY_IN_PROGRESS_LEASE", time.Minute),
+		},
+		Jobs: JobsConfig{
+			UserPurgeInterval:         getDurationEnv("USER_PURGE_INTERVAL", time.Hour),
+			UserRetentionPeriod:       getDurationEnv("USER_RETENTION_PERIOD", 30*24*time.Hour),
+			RedemptionReleaseInterval: getDurationEnv("REDEMPTION_RELEASE_INTERVAL", time.Minute),
+			CreditExpiryInterval:      getDurationEnv("CREDIT_EXPIRY_INTERVAL", time.Hour),
+			IdempotencyPurgeInterval:  getDurationEnv("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+			TierRecalculationInterval: getDurationEnv("TIER_RECALCULATION_INTERVAL", 24*time.Hour),
+			ReferralRewardInterval:    getDurationEnv("REFERRAL_REWARD_INTERVAL", 10*time.Minute),
+		},
 	}
 
 	// Validate required configuration
@@ -57,6 +224,21 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("DB_PASSWORD environment variable is required")
 	}
 
+	if config.Auth.JWTSecret == "" {
+		return nil, fmt.Errorf("JWT_SECRET environment variable is required")
+	}
+
+	// Fall back to the JWT secret so OTP hashes are always keyed
+	if config.OTP.HashSecret == "" {
+		config.OTP.HashSecret = config.Auth.JWTSecret
+	}
+
+	switch config.Mail.Driver {
+	case "smtp", "spool", "stdout":
+	default:
+		return nil, fmt.Errorf("MAIL_DRIVER must be one of smtp, spool or stdout, got %q", config.Mail.Driver)
+	}
+
 	return config, nil
 }
 
@@ -87,3 +269,13 @@ func getIntEnv(key string, fallback int) int {
 	}
 	return fallback
 }
+
+// getBoolEnv gets a boolean environment variable with a fallback value
+func getBoolEnv(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return fallback
+}
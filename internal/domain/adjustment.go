@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAdjustmentNoteLength is the maximum number of characters in an adjustment or review note
+const MaxAdjustmentNoteLength = 500
+
+// AdjustmentDirection tells whether an adjustment grants or deducts points
+type AdjustmentDirection string
+
+// Adjustment directions
+const (
+	AdjustmentGrant  AdjustmentDirection = "grant"
+	AdjustmentDeduct AdjustmentDirection = "deduct"
+)
+
+// AdjustmentReason classifies why support staff adjusted a user's balance
+type AdjustmentReason string
+
+// Adjustment reason codes
+const (
+	AdjustmentReasonGoodwill        AdjustmentReason = "goodwill"
+	AdjustmentReasonErrorCorrection AdjustmentReason = "error_correction"
+	AdjustmentReasonMissingAward    AdjustmentReason = "missing_award"
+	AdjustmentReasonFraud           AdjustmentReason = "fraud"
+	AdjustmentReasonOther           AdjustmentReason = "other"
+)
+
+// IsValid reports whether the reason code is known
+func (r AdjustmentReason) IsValid() bool {
+	switch r {
+	case AdjustmentReasonGoodwill, AdjustmentReasonErrorCorrection, AdjustmentReasonMissingAward, AdjustmentReasonFraud, AdjustmentReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdjustmentStatus tracks an adjustment through review
+type AdjustmentStatus string
+
+// Adjustment statuses
+const (
+	AdjustmentPending  AdjustmentStatus = "pending"  // waiting for a second admin
+	AdjustmentApproved AdjustmentStatus = "approved" // approved and posted to the ledger
+	AdjustmentRejected AdjustmentStatus = "rejected" // rejected; nothing was posted
+)
+
+// IsValid reports whether the status is known
+func (s AdjustmentStatus) IsValid() bool {
+	return s == AdjustmentPending || s == AdjustmentApproved || s == AdjustmentRejected
+}
+
+// Adjustment is a manual grant or deduction of a user's points requested by one admin,
+// which is only posted once a different admin approved it
+type Adjustment struct {
+	ID          string
+	UserID      string
+	PointType   PointType
+	Direction   AdjustmentDirection
+	Amount      int64
+	Reason      AdjustmentReason
+	Note        string
+	Status      AdjustmentStatus
+	RequestedBy string
+	ReviewedBy  *string
+	ReviewNote  string
+	EntryID     *string
+	CreatedAt   time.Time
+	ReviewedAt  *time.Time
+}
+
+// NewAdjustment creates a pending adjustment with validation (ID will be generated by database)
+func NewAdjustment(userID string, pointType PointType, direction AdjustmentDirection, amount int64, reason AdjustmentReason, note, requestedBy string) (*Adjustment, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	if requestedBy == "" {
+		return nil, ErrInvalidAdjustmentActor
+	}
+
+	if pointType == "" {
+		pointType = PointTypeCredits
+	}
+	if !pointType.IsValid() {
+		return nil, ErrInvalidPointType
+	}
+
+	if direction != AdjustmentGrant && direction != AdjustmentDeduct {
+		return nil, ErrInvalidAdjustmentDirection
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if !reason.IsValid() {
+		return nil, ErrInvalidAdjustmentReason
+	}
+
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxAdjustmentNoteLength {
+		return nil, ErrInvalidAdjustmentNote
+	}
+
+	return &Adjustment{
+		UserID:      userID,
+		PointType:   pointType,
+		Direction:   direction,
+		Amount:      amount,
+		Reason:      reason,
+		Note:        note,
+		Status:      AdjustmentPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// Approve records the second admin's approval; the requester cannot approve their own adjustment
+func (a *Adjustment) Approve(reviewerID, note string, now time.Time) error {
+	return a.review(AdjustmentApproved, reviewerID, note, now)
+}
+
+// Reject records the second admin's rejection; the requester cannot reject their own adjustment
+func (a *Adjustment) Reject(reviewerID, note string, now time.Time) error {
+	return a.review(AdjustmentRejected, reviewerID, note, now)
+}
+
+// review moves a pending adjustment to the given status on behalf of a reviewer other than the requester
+func (a *Adjustment) review(status AdjustmentStatus, reviewerID, note string, now time.Time) error {
+	if a.Status != AdjustmentPending {
+		return ErrAdjustmentNotPending
+	}
+
+	if reviewerID == "" {
+		return ErrInvalidAdjustmentActor
+	}
+
+	if reviewerID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxAdjustmentNoteLength {
+		return ErrInvalidAdjustmentNote
+	}
+
+	a.Status = status
+	a.ReviewedBy = &reviewerID
+	a.ReviewNote = note
+	a.ReviewedAt = &now
+	return nil
+}
+
+// EntryReference returns the journal entry reference that makes posting the adjustment idempotent
+func (a *Adjustment) EntryReference() string {
+	return fmt.Sprintf("adjustment:%s", a.ID)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAdjustment(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		pointType   PointType
+		direction   AdjustmentDirection
+		amount      int64
+		reason      AdjustmentReason
+		note        string
+		requestedBy string
+		errType     error
+	}{
+		{"goodwill grant", "user-1", "", AdjustmentGrant, 100, AdjustmentReasonGoodwill, "Late delivery", "admin-1", nil},
+		{"fraud deduction", "user-1", PointTypeCredits, AdjustmentDeduct, 50, AdjustmentReasonFraud, "", "admin-1", nil},
+		{"missing user", "", "", AdjustmentGrant, 100, AdjustmentReasonGoodwill, "", "admin-1", ErrInvalidUserID},
+		{"missing requester", "user-1", "", AdjustmentGrant, 100, AdjustmentReasonGoodwill, "", "", ErrInvalidAdjustmentActor},
+		{"unknown direction", "user-1", "", "refund", 100, AdjustmentReasonGoodwill, "", "admin-1", ErrInvalidAdjustmentDirection},
+		{"zero amount", "user-1", "", AdjustmentGrant, 0, AdjustmentReasonGoodwill, "", "admin-1", ErrInvalidAmount},
+		{"unknown reason", "user-1", "", AdjustmentGrant, 100, "because", "", "admin-1", ErrInvalidAdjustmentReason},
+		{"note too long", "user-1", "", AdjustmentGrant, 100, AdjustmentReasonOther, strings.Repeat("a", MaxAdjustmentNoteLength+1), "admin-1", ErrInvalidAdjustmentNote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adjustment, err := NewAdjustment(tt.userID, tt.pointType, tt.direction, tt.amount, tt.reason, tt.note, tt.requestedBy)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewAdjustment() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewAdjustment() unexpected error: %v", err)
+			}
+			if adjustment.Status != AdjustmentPending || adjustment.PointType != PointTypeCredits {
+				t.Errorf("NewAdjustment() = %+v, want a pending credits adjustment", adjustment)
+			}
+		})
+	}
+}
+
+func TestAdjustment_Review(t *testing.T) {
+	adjustment, err := NewAdjustment("user-1", "", AdjustmentGrant, 100, AdjustmentReasonGoodwill, "", "admin-1")
+	if err != nil {
+		t.Fatalf("NewAdjustment() unexpected error: %v", err)
+	}
+	now := time.Now()
+
+	if err := adjustment.Approve("admin-1", "", now); !containsTargetError(err, ErrSelfApproval) {
+		t.Errorf("Approve() by requester expected error %v, got %v", ErrSelfApproval, err)
+	}
+	if err := adjustment.Reject("admin-1", "", now); !containsTargetError(err, ErrSelfApproval) {
+		t.Errorf("Reject() by requester expected error %v, got %v", ErrSelfApproval, err)
+	}
+	if adjustment.Status != AdjustmentPending {
+		t.Fatalf("Status = %s, want %s", adjustment.Status, AdjustmentPending)
+	}
+
+	if err := adjustment.Reject("admin-2", " Duplicate request ", now); err != nil {
+		t.Fatalf("Reject() unexpected error: %v", err)
+	}
+	if adjustment.Status != AdjustmentRejected || adjustment.ReviewNote != "Duplicate request" || adjustment.ReviewedAt == nil {
+		t.Errorf("Reject() = %+v, want rejected with a trimmed note", adjustment)
+	}
+
+	if err := adjustment.Approve("admin-3", "", now); !containsTargetError(err, ErrAdjustmentNotPending) {
+		t.Errorf("Approve() after rejection expected error %v, got %v", ErrAdjustmentNotPending, err)
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix marks bearer credentials that are API keys rather than access tokens
+const APIKeyPrefix = "srbcs_"
+
+// API key scopes
+const (
+	ScopeUsersRead    = "users:read"
+	ScopeUsersWrite   = "users:write"
+	ScopeCreditsRead  = "credits:read"
+	ScopeCreditsWrite = "credits:write"
+)
+
+// validScopes lists every scope that can be granted to an API key
+var validScopes = []string{
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeCreditsRead,
+	ScopeCreditsWrite,
+}
+
+// IsValidScope reports whether scope can be granted to an API key
+func IsValidScope(scope string) bool {
+	return slices.Contains(validScopes, scope)
+}
+
+// APIKey represents a credential for partner and service-to-service clients (only its hash is persisted)
+type APIKey struct {
+	ID          string
+	Name        string
+	Prefix      string
+	KeyHash     string
+	Scopes      []string
+	OwnerUserID string
+	ExpiresAt   *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// NewAPIKey creates a new API key with validation (ID will be generated by database)
+func NewAPIKey(name, prefix, keyHash, ownerUserID string, scopes []string, expiresAt *time.Time) (*APIKey, error) {
+	key := &APIKey{
+		Name:        strings.TrimSpace(name),
+		Prefix:      prefix,
+		KeyHash:     keyHash,
+		Scopes:      scopes,
+		OwnerUserID: ownerUserID,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Validate performs domain validation on the API key
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return ErrInvalidAPIKeyName
+	}
+
+	if k.OwnerUserID == "" {
+		return ErrInvalidUserID
+	}
+
+	if k.KeyHash == "" {
+		return ErrInvalidAPIKey
+	}
+
+	if len(k.Scopes) == 0 {
+		return ErrInvalidAPIKeyScope
+	}
+
+	for _, scope := range k.Scopes {
+		if !IsValidScope(scope) {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(k.CreatedAt) {
+		return ErrInvalidAPIKeyExpiry
+	}
+
+	return nil
+}
+
+// EnsureUsable checks that the key is neither revoked nor expired
+func (k *APIKey) EnsureUsable(now time.Time) error {
+	if k.RevokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ErrAPIKeyExpired
+	}
+
+	return nil
+}
+
+// Revoke marks the key as revoked
+func (k *APIKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+// Principal returns the principal a request authenticated with this key acts as
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		APIKeyID: k.ID,
+		Scopes:   k.Scopes,
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Principal represents the authenticated caller of a request: either a user
+// authenticated with an access token or a client authenticated with an API key
+type Principal struct {
+	UserID   string
+	Role     Role
+	APIKeyID string
+	Scopes   []string
+}
+
+// IsAdmin reports whether the principal is a user with the admin role
+func (p *Principal) IsAdmin() bool {
+	return !p.IsAPIKey() && p.Role == RoleAdmin
+}
+
+// IsAPIKey reports whether the principal authenticated with an API key
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyID != ""
+}
+
+// HasScope reports whether the principal's API key grants the given scope
+func (p *Principal) HasScope(scope string) bool {
+	return p.IsAPIKey() && slices.Contains(p.Scopes, scope)
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// TokenPair holds the tokens issued to a user after login
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// RefreshToken represents a stored refresh token (only its hash is persisted)
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken creates a new refresh token record (ID will be generated by database)
+func NewRefreshToken(userID, tokenHash string, expiresAt time.Time) (*RefreshToken, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	if tokenHash == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &RefreshToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRevoked reports whether the refresh token has been revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired reports whether the refresh token has expired at the given time
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// CanAccessUser reports whether the principal may access the given user's data.
+// API keys need the given scope; users must be admins or the user themselves.
+func (p *Principal) CanAccessUser(userID, scope string) bool {
+	if p.IsAPIKey() {
+		return scope != "" && p.HasScope(scope)
+	}
+	return p.IsAdmin() || p.UserID == userID
+}
+
+// CanAdminister reports whether the principal may perform an admin operation.
+// API keys need the given scope; an empty scope means the operation is reserved for admin users.
+func (p *Principal) CanAdminister(scope string) bool {
+	if p.IsAPIKey() {
+		return scope != "" && p.HasScope(scope)
+	}
+	return p.IsAdmin()
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// CampaignAudience narrows the awards a campaign boosts; empty lists match every event type or user
+type CampaignAudience struct {
+	EventTypes []string `json:"event_types,omitempty"`
+	TierIDs    []string `json:"tier_ids,omitempty"`
+}
+
+// Validate checks that the audience only targets valid event types
+func (a CampaignAudience) Validate() error {
+	for _, eventType := range a.EventTypes {
+		if !IsValidEventType(eventType) {
+			return ErrInvalidEventType
+		}
+	}
+
+	for _, tierID := range a.TierIDs {
+		if strings.TrimSpace(tierID) == "" {
+			return ErrInvalidCampaignAudience
+		}
+	}
+
+	return nil
+}
+
+// Includes reports whether an event of the given type for a user holding the given tier is targeted
+func (a CampaignAudience) Includes(eventType string, tierID *string) bool {
+	if len(a.EventTypes) > 0 && !slices.Contains(a.EventTypes, eventType) {
+		return false
+	}
+
+	if len(a.TierIDs) > 0 && (tierID == nil || !slices.Contains(a.TierIDs, *tierID)) {
+		return false
+	}
+
+	return true
+}
+
+// CampaignSpec holds the admin-editable settings of a promotional campaign
+type CampaignSpec struct {
+	Name        string
+	Description string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Audience    CampaignAudience
+	Multiplier  int64 // basis points of the base award the boosted award adds up to (20000 doubles it); 0 for none
+	Bonus       int64 // fixed credits added to each boosted award
+	Budget      int64 // most credits the campaign may add across all awards
+	IsActive    bool
+}
+
+// Campaign boosts the credits earn rules award during a time window until its budget is spent
+type Campaign struct {
+	ID string
+	CampaignSpec
+	Spent     int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCampaign creates a new campaign with validation (ID will be generated by database)
+func NewCampaign(spec CampaignSpec) (*Campaign, error) {
+	now := time.Now()
+	campaign := &Campaign{
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := campaign.Update(spec); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// Update replaces the campaign's settings with validation; the budget cannot drop below what was spent
+func (c *Campaign) Update(spec CampaignSpec) error {
+	spec.Name = strings.TrimSpace(spec.Name)
+	spec.Description = strings.TrimSpace(spec.Description)
+
+	updated := *c
+	updated.CampaignSpec = spec
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	c.CampaignSpec = spec
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Validate performs domain validation on the campaign
+func (c *Campaign) Validate() error {
+	if c.Name == "" {
+		return ErrInvalidCampaignName
+	}
+
+	if c.StartsAt.IsZero() || !c.EndsAt.After(c.StartsAt) {
+		return ErrInvalidCampaignWindow
+	}
+
+	if err := c.Audience.Validate(); err != nil {
+		return err
+	}
+
+	if c.Multiplier != 0 && c.Multiplier <= basisPoints {
+		return ErrInvalidCampaignBoost
+	}
+
+	if c.Bonus < 0 || (c.Multiplier == 0 && c.Bonus == 0) {
+		return ErrInvalidCampaignBoost
+	}
+
+	if c.Budget <= 0 || c.Budget < c.Spent {
+		return ErrInvalidCampaignBudget
+	}
+
+	return nil
+}
+
+// IsActiveAt reports whether the campaign is enabled and inside its time window at the given time
+func (c *Campaign) IsActiveAt(at time.Time) bool {
+	return c.IsActive && !at.Before(c.StartsAt) && at.Before(c.EndsAt)
+}
+
+// RemainingBudget returns the credits the campaign may still add
+func (c *Campaign) RemainingBudget() int64 {
+	return max(c.Budget-c.Spent, 0)
+}
+
+// ComputeBonus returns the credits the campaign adds on top of a base credit award for the event,
+// limited to its remaining budget, or zero when it does not apply
+func (c *Campaign) ComputeBonus(event *EarnEvent, tierID *string, base int64) int64 {
+	if base <= 0 || !c.IsActiveAt(event.OccurredAt) || !c.Audience.Includes(event.Type, tierID) {
+		return 0
+	}
+
+	bonus := c.Bonus
+	if c.Multiplier > 0 {
+		bonus += base * (c.Multiplier - basisPoints) / basisPoints
+	}
+
+	return min(bonus, c.RemainingBudget())
+}
+
+// BestCampaign returns the campaign adding the most credits to a base credit award and its bonus;
+// campaigns do not stack, so at most one boosts each award
+func BestCampaign(campaigns []*Campaign, event *EarnEvent, tierID *string, base int64) (*Campaign, int64) {
+	var best *Campaign
+	var bestBonus int64
+	for _, campaign := range campaigns {
+		if bonus := campaign.ComputeBonus(event, tierID, base); bonus > bestBonus {
+			best, bestBonus = campaign, bonus
+		}
+	}
+	return best, bestBonus
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCampaign(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+
+	tests := []struct {
+		name    string
+		spec    CampaignSpec
+		errType error
+	}{
+		{
+			name: "double credits",
+			spec: CampaignSpec{Name: "Double weekend", StartsAt: start, EndsAt: end, Multiplier: 20000, Budget: 10000},
+		},
+		{
+			name: "fixed bonus for purchases",
+			spec: CampaignSpec{Name: "Bonus", StartsAt: start, EndsAt: end, Audience: CampaignAudience{EventTypes: []string{EarnEventPurchase}}, Bonus: 50, Budget: 10000},
+		},
+		{
+			name:    "missing name",
+			spec:    CampaignSpec{StartsAt: start, EndsAt: end, Multiplier: 20000, Budget: 10000},
+			errType: ErrInvalidCampaignName,
+		},
+		{
+			name:    "ends before it starts",
+			spec:    CampaignSpec{Name: "Backwards", StartsAt: end, EndsAt: start, Multiplier: 20000, Budget: 10000},
+			errType: ErrInvalidCampaignWindow,
+		},
+		{
+			name:    "missing start",
+			spec:    CampaignSpec{Name: "Open", EndsAt: end, Multiplier: 20000, Budget: 10000},
+			errType: ErrInvalidCampaignWindow,
+		},
+		{
+			name:    "invalid event type",
+			spec:    CampaignSpec{Name: "Bad audience", StartsAt: start, EndsAt: end, Audience: CampaignAudience{EventTypes: []string{"Not Valid"}}, Multiplier: 20000, Budget: 10000},
+			errType: ErrInvalidEventType,
+		},
+		{
+			name:    "multiplier that does not increase awards",
+			spec:    CampaignSpec{Name: "Same", StartsAt: start, EndsAt: end, Multiplier: 10000, Budget: 10000},
+			errType: ErrInvalidCampaignBoost,
+		},
+		{
+			name:    "neither multiplier nor bonus",
+			spec:    CampaignSpec{Name: "Nothing", StartsAt: start, EndsAt: end, Budget: 10000},
+			errType: ErrInvalidCampaignBoost,
+		},
+		{
+			name:    "missing budget",
+			spec:    CampaignSpec{Name: "Unlimited", StartsAt: start, EndsAt: end, Multiplier: 20000},
+			errType: ErrInvalidCampaignBudget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCampaign(tt.spec)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewCampaign() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewCampaign() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCampaign_UpdateBelowSpent(t *testing.T) {
+	start := time.Now()
+	campaign, err := NewCampaign(CampaignSpec{Name: "Double", StartsAt: start, EndsAt: start.Add(time.Hour), Multiplier: 20000, Budget: 1000, IsActive: true})
+	if err != nil {
+		t.Fatalf("NewCampaign() unexpected error: %v", err)
+	}
+	campaign.Spent = 600
+
+	spec := campaign.CampaignSpec
+	spec.Budget = 500
+	if err := campaign.Update(spec); !containsTargetError(err, ErrInvalidCampaignBudget) {
+		t.Errorf("Update() expected error %v, got %v", ErrInvalidCampaignBudget, err)
+	}
+	if campaign.Budget != 1000 {
+		t.Errorf("Update() Budget = %d, want unchanged 1000", campaign.Budget)
+	}
+}
+
+func TestBestCampaign(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	gold := "gold"
+
+	double := &Campaign{ID: "double", CampaignSpec: CampaignSpec{Name: "Double", StartsAt: start, EndsAt: end, Multiplier: 20000, Budget: 1000, IsActive: true}}
+	purchases := &Campaign{ID: "purchases", CampaignSpec: CampaignSpec{Name: "Purchase bonus", StartsAt: start, EndsAt: end, Audience: CampaignAudience{EventTypes: []string{EarnEventPurchase}}, Bonus: 30, Budget: 1000, IsActive: true}}
+	goldOnly := &Campaign{ID: "gold", CampaignSpec: CampaignSpec{Name: "Gold triple", StartsAt: start, EndsAt: end, Audience: CampaignAudience{TierIDs: []string{gold}}, Multiplier: 30000, Budget: 1000, IsActive: true}, Spent: 950}
+	campaigns := []*Campaign{double, purchases, goldOnly}
+
+	tests := []struct {
+		name      string
+		eventType string
+		at        time.Time
+		tierID    *string
+		base      int64
+		want      *Campaign
+		wantBonus int64
+	}{
+		{"multiplier beats smaller bonus", EarnEventPurchase, start, nil, 100, double, 100},
+		{"bonus beats smaller multiplier", EarnEventPurchase, start, nil, 20, purchases, 30},
+		{"audience excludes other events", EarnEventSignup, start, nil, 20, double, 20},
+		{"tier campaign limited to its remaining budget", EarnEventSignup, start, &gold, 100, double, 100},
+		{"tier campaign when it still pays the most", EarnEventSignup, start, &gold, 40, goldOnly, 50},
+		{"outside the window", EarnEventPurchase, end, nil, 100, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &EarnEvent{Type: tt.eventType, UserID: "user-1", OccurredAt: tt.at}
+
+			campaign, bonus := BestCampaign(campaigns, event, tt.tierID, tt.base)
+			if campaign != tt.want || bonus != tt.wantBonus {
+				t.Errorf("BestCampaign() = %v, %d; want %v, %d", campaign, bonus, tt.want, tt.wantBonus)
+			}
+		})
+	}
+}
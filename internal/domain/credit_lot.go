@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// CreditLot is a batch of points a user received in one journal entry. The remaining points of
+// a user's lots of a type always add up to their available and held balances of that type.
+type CreditLot struct {
+	ID        string
+	UserID    string
+	PointType PointType
+	EntryID   *string // nil for balances that predate lot tracking
+	Amount    int64
+	Remaining int64
+	ExpiresAt *time.Time // nil for credits that never expire
+	CreatedAt time.Time
+}
+
+// IsLotTracked reports whether credits in accounts of this type are tracked in the owner's credit lots
+func (t AccountType) IsLotTracked() bool {
+	return t == AccountUserAvailable || t == AccountUserHeld
+}
+
+// NewCreditLot creates a lot for credits added to a user's wallet by a posted entry (ID will be generated by database)
+func NewCreditLot(userID string, entry *JournalEntry, amount int64) (*CreditLot, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	return &CreditLot{
+		UserID:    userID,
+		PointType: entry.PointType,
+		EntryID:   &entry.ID,
+		Amount:    amount,
+		Remaining: amount,
+		ExpiresAt: entry.ExpiresAt,
+		CreatedAt: entry.CreatedAt,
+	}, nil
+}
+
+// IsExpiredAt reports whether the lot's credits have expired at the given time
+func (l *CreditLot) IsExpiredAt(t time.Time) bool {
+	return l.ExpiresAt != nil && !t.Before(*l.ExpiresAt)
+}
+
+// LotDraw is the part of a lot's credits taken out of a wallet by one entry
+type LotDraw struct {
+	Lot    *CreditLot
+	Amount int64
+}
+
+// ConsumeLots spends amount from the lots oldest first, returning what it drew from each lot.
+// Lots are ordered by expiry, then by when they were received; lots that never expire go last.
+func ConsumeLots(lots []*CreditLot, amount int64) []LotDraw {
+	SortLotsForConsumption(lots)
+
+	var draws []LotDraw
+	for _, lot := range lots {
+		if amount <= 0 {
+			break
+		}
+		if lot.Remaining <= 0 {
+			continue
+		}
+
+		spent := min(lot.Remaining, amount)
+		lot.Remaining -= spent
+		amount -= spent
+		draws = append(draws, LotDraw{Lot: lot, Amount: spent})
+	}
+
+	return draws
+}
+
+// NewCreditLots creates the lots for credits an entry adds to a user's wallet. Credits the entry drew
+// from another wallet keep the expiry of the lots they came from, so moving credits between users
+// never extends their life; the rest expire as the entry says. The draws not passed on are returned.
+func NewCreditLots(userID string, entry *JournalEntry, amount int64, draws []LotDraw) ([]*CreditLot, []LotDraw, error) {
+	var lots []*CreditLot
+	for amount > 0 && len(draws) > 0 {
+		moved := min(draws[0].Amount, amount)
+
+		lot, err := NewCreditLot(userID, entry, moved)
+		if err != nil {
+			return nil, nil, err
+		}
+		lot.ExpiresAt = draws[0].Lot.ExpiresAt
+		lots = append(lots, lot)
+
+		amount -= moved
+		if draws[0].Amount -= moved; draws[0].Amount == 0 {
+			draws = draws[1:]
+		}
+	}
+
+	if amount > 0 {
+		lot, err := NewCreditLot(userID, entry, amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		lots = append(lots, lot)
+	}
+
+	return lots, draws, nil
+}
+
+// SortLotsForConsumption orders lots the way ConsumeLots spends them
+func SortLotsForConsumption(lots []*CreditLot) {
+	sort.SliceStable(lots, func(a, b int) bool {
+		expiresA, expiresB := lots[a].ExpiresAt, lots[b].ExpiresAt
+		switch {
+		case expiresA != nil && expiresB != nil && !expiresA.Equal(*expiresB):
+			return expiresA.Before(*expiresB)
+		case (expiresA == nil) != (expiresB == nil):
+			return expiresB == nil
+		default:
+			return lots[a].CreatedAt.Before(lots[b].CreatedAt)
+		}
+	})
+}
+
+// ExpiringCredits summarizes a user's credits that expire before a given time
+type ExpiringCredits struct {
+	UserID string
+	Before time.Time
+	Total  int64
+	Lots   []*CreditLot
+}
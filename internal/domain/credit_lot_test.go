@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsumeLots(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name          string
+		amount        int64
+		wantRemaining []int64 // in the order the lots are declared below
+		wantChanged   int
+	}{
+		{name: "soonest expiry first", amount: 30, wantRemaining: []int64{50, 20, 100, 40}, wantChanged: 1},
+		{name: "spills into the next lot", amount: 70, wantRemaining: []int64{50, 0, 80, 40}, wantChanged: 2},
+		{name: "non-expiring lots last, oldest first", amount: 180, wantRemaining: []int64{50, 0, 0, 10}, wantChanged: 3},
+		{name: "more than the lots hold", amount: 500, wantRemaining: []int64{0, 0, 0, 0}, wantChanged: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lots := []*CreditLot{
+				{ID: "newer-forever", Remaining: 50, CreatedAt: now},
+				{ID: "soon", Remaining: 50, ExpiresAt: &soon, CreatedAt: now},
+				{ID: "later", Remaining: 100, ExpiresAt: &later, CreatedAt: now.Add(-time.Hour)},
+				{ID: "older-forever", Remaining: 40, CreatedAt: now.Add(-time.Hour)},
+			}
+			declared := append([]*CreditLot(nil), lots...)
+
+			changed := ConsumeLots(lots, tt.amount)
+
+			if len(changed) != tt.wantChanged {
+				t.Errorf("ConsumeLots() changed %d lots, want %d", len(changed), tt.wantChanged)
+			}
+			for i, lot := range declared {
+				if lot.Remaining != tt.wantRemaining[i] {
+					t.Errorf("ConsumeLots() lot %s remaining = %d, want %d", lot.ID, lot.Remaining, tt.wantRemaining[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJournalEntry_ExpireCreditsAfter(t *testing.T) {
+	entry := &JournalEntry{CreatedAt: time.Now()}
+
+	entry.ExpireCreditsAfter(time.Hour)
+	if entry.ExpiresAt == nil || !entry.ExpiresAt.Equal(entry.CreatedAt.Add(time.Hour)) {
+		t.Errorf("ExpireCreditsAfter(1h) ExpiresAt = %v, want an hour after creation", entry.ExpiresAt)
+	}
+
+	entry.ExpireCreditsAfter(0)
+	if entry.ExpiresAt != nil {
+		t.Errorf("ExpireCreditsAfter(0) ExpiresAt = %v, want nil", entry.ExpiresAt)
+	}
+}
+
+func TestNewCreditLots(t *testing.T) {
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(2 * time.Hour)
+	entry := &JournalEntry{ID: "entry-1", ExpiresAt: &later, CreatedAt: time.Now()}
+	draws := []LotDraw{
+		{Lot: &CreditLot{ExpiresAt: &soon}, Amount: 30},
+		{Lot: &CreditLot{}, Amount: 20},
+	}
+
+	lots, rest, err := NewCreditLots("user-1", entry, 40, draws)
+	if err != nil {
+		t.Fatalf("NewCreditLots() unexpected error: %v", err)
+	}
+
+	if len(lots) != 2 || lots[0].Amount != 30 || lots[0].ExpiresAt != &soon || lots[1].Amount != 10 || lots[1].ExpiresAt != nil {
+		t.Errorf("NewCreditLots() = %+v, want 30 expiring soon and 10 never expiring", lots)
+	}
+
+	if len(rest) != 1 || rest[0].Amount != 10 {
+		t.Errorf("NewCreditLots() rest = %+v, want 10 left of the second draw", rest)
+	}
+
+	lots, _, err = NewCreditLots("user-1", entry, 25, nil)
+	if err != nil {
+		t.Fatalf("NewCreditLots() unexpected error: %v", err)
+	}
+	if len(lots) != 1 || lots[0].ExpiresAt != &later {
+		t.Errorf("NewCreditLots() without draws = %+v, want one lot expiring with the entry", lots)
+	}
+}
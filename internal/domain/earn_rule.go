@@ -0,0 +1,387 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Well-known earn event types; rules may also target any other event type reported by clients
+const (
+	EarnEventSignup        = "signup"
+	EarnEventEmailVerified = "email_verified"
+	EarnEventPurchase      = "purchase"
+	EarnEventReferral      = "referral"
+)
+
+// eventTypePattern restricts event types to lowercase identifiers such as "order.completed"
+var eventTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,63}$`)
+
+// IsValidEventType reports whether eventType can be reported and targeted by rules
+func IsValidEventType(eventType string) bool {
+	return eventTypePattern.MatchString(eventType)
+}
+
+// AwardType decides how a rule computes the credits it awards
+type AwardType string
+
+// Award types
+const (
+	AwardFixed      AwardType = "fixed"      // Amount credits per event
+	AwardPercentage AwardType = "percentage" // Amount basis points of a numeric event attribute
+)
+
+// basisPoints is the denominator of percentage award amounts (100 = 1%)
+const basisPoints = 10000
+
+// ConditionOperator compares an event attribute with a condition value
+type ConditionOperator string
+
+// Condition operators
+const (
+	OpEquals         ConditionOperator = "eq"
+	OpNotEquals      ConditionOperator = "neq"
+	OpGreaterThan    ConditionOperator = "gt"
+	OpGreaterOrEqual ConditionOperator = "gte"
+	OpLessThan       ConditionOperator = "lt"
+	OpLessOrEqual    ConditionOperator = "lte"
+	OpIn             ConditionOperator = "in"
+)
+
+// RuleCondition requires an event attribute to compare with a value; numeric operators need numbers
+type RuleCondition struct {
+	Attribute string            `json:"attribute"`
+	Operator  ConditionOperator `json:"operator"`
+	Value     any               `json:"value"`
+}
+
+// Validate checks that the condition can be evaluated
+func (c RuleCondition) Validate() error {
+	if strings.TrimSpace(c.Attribute) == "" {
+		return ErrInvalidRuleCondition
+	}
+
+	switch c.Operator {
+	case OpEquals, OpNotEquals:
+		if c.Value == nil {
+			return ErrInvalidRuleCondition
+		}
+	case OpGreaterThan, OpGreaterOrEqual, OpLessThan, OpLessOrEqual:
+		if _, ok := toNumber(c.Value); !ok {
+			return ErrInvalidRuleCondition
+		}
+	case OpIn:
+		if values, ok := c.Value.([]any); !ok || len(values) == 0 {
+			return ErrInvalidRuleCondition
+		}
+	default:
+		return ErrInvalidRuleCondition
+	}
+
+	return nil
+}
+
+// Matches reports whether the event attributes satisfy the condition; missing attributes never match
+func (c RuleCondition) Matches(attributes map[string]any) bool {
+	actual, ok := attributes[c.Attribute]
+	if !ok || actual == nil {
+		return false
+	}
+
+	switch c.Operator {
+	case OpEquals:
+		return valuesEqual(actual, c.Value)
+	case OpNotEquals:
+		return !valuesEqual(actual, c.Value)
+	case OpIn:
+		values, _ := c.Value.([]any)
+		return slices.ContainsFunc(values, func(v any) bool { return valuesEqual(actual, v) })
+	}
+
+	got, ok := toNumber(actual)
+	if !ok {
+		return false
+	}
+	want, _ := toNumber(c.Value)
+
+	switch c.Operator {
+	case OpGreaterThan:
+		return got > want
+	case OpGreaterOrEqual:
+		return got >= want
+	case OpLessThan:
+		return got < want
+	case OpLessOrEqual:
+		return got <= want
+	default:
+		return false
+	}
+}
+
+// EarnRuleSpec holds the admin-editable settings of an earn rule
+type EarnRuleSpec struct {
+	Name             string
+	EventType        string
+	Conditions       []RuleCondition
+	AwardType        AwardType
+	Amount           int64
+	PointType        PointType // what the award is paid in; defaults to credits
+	BaseAttribute    string
+	MaxAward         *int64
+	MaxAwardsPerUser *int
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+	IsActive         bool
+}
+
+// EarnRule awards credits to users when a matching event is reported
+type EarnRule struct {
+	ID string
+	EarnRuleSpec
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewEarnRule creates a new earn rule with validation (ID will be generated by database)
+func NewEarnRule(spec EarnRuleSpec) (*EarnRule, error) {
+	now := time.Now()
+	rule := &EarnRule{
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := rule.Update(spec); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Update replaces the rule's settings with validation
+func (r *EarnRule) Update(spec EarnRuleSpec) error {
+	spec.Name = strings.TrimSpace(spec.Name)
+	spec.EventType = strings.TrimSpace(spec.EventType)
+	spec.BaseAttribute = strings.TrimSpace(spec.BaseAttribute)
+	if spec.PointType == "" {
+		spec.PointType = PointTypeCredits
+	}
+	if spec.Conditions == nil {
+		spec.Conditions = []RuleCondition{}
+	}
+
+	updated := *r
+	updated.EarnRuleSpec = spec
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	r.EarnRuleSpec = spec
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Validate performs domain validation on the earn rule
+func (r *EarnRule) Validate() error {
+	if r.Name == "" {
+		return ErrInvalidEarnRuleName
+	}
+
+	if !IsValidEventType(r.EventType) {
+		return ErrInvalidEventType
+	}
+
+	if !r.PointType.IsValid() {
+		return ErrInvalidPointType
+	}
+
+	for _, condition := range r.Conditions {
+		if err := condition.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if r.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	switch r.AwardType {
+	case AwardFixed:
+	case AwardPercentage:
+		if r.BaseAttribute == "" {
+			return ErrInvalidEarnRuleAward
+		}
+	default:
+		return ErrInvalidEarnRuleAward
+	}
+
+	if r.MaxAward != nil && *r.MaxAward <= 0 {
+		return ErrInvalidEarnRuleCap
+	}
+
+	if r.MaxAwardsPerUser != nil && *r.MaxAwardsPerUser <= 0 {
+		return ErrInvalidEarnRuleCap
+	}
+
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return ErrInvalidEarnRuleWindow
+	}
+
+	return nil
+}
+
+// IsActiveAt reports whether the rule is enabled and inside its date window at the given time
+func (r *EarnRule) IsActiveAt(at time.Time) bool {
+	if !r.IsActive {
+		return false
+	}
+
+	if r.StartsAt != nil && at.Before(*r.StartsAt) {
+		return false
+	}
+
+	return r.EndsAt == nil || at.Before(*r.EndsAt)
+}
+
+// ComputeAward returns the credits the rule awards for the event, or zero when it does not apply
+func (r *EarnRule) ComputeAward(event *EarnEvent) int64 {
+	if r.EventType != event.Type || !r.IsActiveAt(event.OccurredAt) {
+		return 0
+	}
+
+	for _, condition := range r.Conditions {
+		if !condition.Matches(event.Attributes) {
+			return 0
+		}
+	}
+
+	amount := r.Amount
+	if r.AwardType == AwardPercentage {
+		base, ok := toNumber(event.Attributes[r.BaseAttribute])
+		if !ok || base <= 0 {
+			return 0
+		}
+		amount = int64(math.Floor(base * float64(r.Amount) / basisPoints))
+	}
+
+	if r.MaxAward != nil && amount > *r.MaxAward {
+		amount = *r.MaxAward
+	}
+
+	return amount
+}
+
+// EarnEvent is a user action reported for evaluation against the earn rules
+type EarnEvent struct {
+	Type       string
+	UserID     string
+	Reference  string // optional event ID; repeated events with the same reference are awarded once
+	Attributes map[string]any
+	OccurredAt time.Time
+}
+
+// NewEarnEvent creates an earn event with validation; a zero occurredAt means now
+func NewEarnEvent(eventType, userID, reference string, attributes map[string]any, occurredAt time.Time) (*EarnEvent, error) {
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+
+	event := &EarnEvent{
+		Type:       strings.TrimSpace(eventType),
+		UserID:     userID,
+		Reference:  strings.TrimSpace(reference),
+		Attributes: attributes,
+		OccurredAt: occurredAt,
+	}
+
+	if !IsValidEventType(event.Type) {
+		return nil, ErrInvalidEventType
+	}
+
+	if event.UserID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	return event, nil
+}
+
+// EntryReference returns the journal entry reference that makes the rule's award for this event idempotent
+func (e *EarnEvent) EntryReference(ruleID string) string {
+	if e.Reference == "" {
+		return ""
+	}
+	return fmt.Sprintf("earn_rule:%s:%s", ruleID, e.Reference)
+}
+
+// EarnAward records credits a rule awarded to a user
+type EarnAward struct {
+	ID         string
+	RuleID     string
+	UserID     string
+	EntryID    string
+	PointType  PointType
+	Amount     int64   // what the rule awards on its own
+	CampaignID *string // campaign that boosted the award, if any
+	Bonus      int64   // credits the campaign added on top of Amount
+	CreatedAt  time.Time
+}
+
+// Boost adds a campaign's bonus to the award
+func (a *EarnAward) Boost(campaign *Campaign, bonus int64) {
+	a.CampaignID = &campaign.ID
+	a.Bonus = bonus
+}
+
+// ClearBoost removes the campaign's bonus from the award
+func (a *EarnAward) ClearBoost() {
+	a.CampaignID = nil
+	a.Bonus = 0
+}
+
+// Total returns the points paid for the award, including any campaign bonus
+func (a *EarnAward) Total() int64 {
+	return a.Amount + a.Bonus
+}
+
+// toNumber converts JSON and Go numeric values to float64
+func toNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// valuesEqual compares numbers numerically and strings and booleans by value
+func valuesEqual(a, b any) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	default:
+		return false
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEarnRule(t *testing.T) {
+	start := time.Now()
+	end := start.Add(-time.Hour)
+	zero := int64(0)
+
+	tests := []struct {
+		name    string
+		spec    EarnRuleSpec
+		errType error
+	}{
+		{
+			name: "valid fixed rule",
+			spec: EarnRuleSpec{Name: "Signup bonus", EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100},
+		},
+		{
+			name: "valid percentage rule",
+			spec: EarnRuleSpec{Name: "Purchase cashback", EventType: EarnEventPurchase, AwardType: AwardPercentage, Amount: 500, BaseAttribute: "amount"},
+		},
+		{
+			name:    "missing name",
+			spec:    EarnRuleSpec{EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100},
+			errType: ErrInvalidEarnRuleName,
+		},
+		{
+			name:    "invalid event type",
+			spec:    EarnRuleSpec{Name: "Bonus", EventType: "Sign Up", AwardType: AwardFixed, Amount: 100},
+			errType: ErrInvalidEventType,
+		},
+		{
+			name:    "percentage without base attribute",
+			spec:    EarnRuleSpec{Name: "Cashback", EventType: EarnEventPurchase, AwardType: AwardPercentage, Amount: 500},
+			errType: ErrInvalidEarnRuleAward,
+		},
+		{
+			name:    "non-positive amount",
+			spec:    EarnRuleSpec{Name: "Bonus", EventType: EarnEventSignup, AwardType: AwardFixed},
+			errType: ErrInvalidAmount,
+		},
+		{
+			name:    "non-positive cap",
+			spec:    EarnRuleSpec{Name: "Bonus", EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100, MaxAward: &zero},
+			errType: ErrInvalidEarnRuleCap,
+		},
+		{
+			name:    "window ends before it starts",
+			spec:    EarnRuleSpec{Name: "Bonus", EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100, StartsAt: &start, EndsAt: &end},
+			errType: ErrInvalidEarnRuleWindow,
+		},
+		{
+			name: "unknown condition operator",
+			spec: EarnRuleSpec{Name: "Bonus", EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100,
+				Conditions: []RuleCondition{{Attribute: "country", Operator: "like", Value: "E%"}}},
+			errType: ErrInvalidRuleCondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewEarnRule(tt.spec)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewEarnRule() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewEarnRule() unexpected error: %v", err)
+			}
+
+			if rule.Conditions == nil {
+				t.Errorf("NewEarnRule() Conditions should not be nil")
+			}
+		})
+	}
+}
+
+func TestEarnRule_ComputeAward(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	maxAward := int64(200)
+
+	cashback := &EarnRule{EarnRuleSpec: EarnRuleSpec{
+		Name:          "Cashback",
+		EventType:     EarnEventPurchase,
+		AwardType:     AwardPercentage,
+		Amount:        500, // 5%
+		BaseAttribute: "amount",
+		MaxAward:      &maxAward,
+		IsActive:      true,
+		Conditions: []RuleCondition{
+			{Attribute: "amount", Operator: OpGreaterOrEqual, Value: float64(10)},
+			{Attribute: "channel", Operator: OpIn, Value: []any{"web", "app"}},
+		},
+	}}
+
+	tests := []struct {
+		name  string
+		rule  *EarnRule
+		event *EarnEvent
+		want  int64
+	}{
+		{
+			name:  "percentage of base attribute",
+			rule:  cashback,
+			event: &EarnEvent{Type: EarnEventPurchase, Attributes: map[string]any{"amount": float64(1234), "channel": "web"}, OccurredAt: now},
+			want:  61,
+		},
+		{
+			name:  "capped award",
+			rule:  cashback,
+			event: &EarnEvent{Type: EarnEventPurchase, Attributes: map[string]any{"amount": float64(100000), "channel": "app"}, OccurredAt: now},
+			want:  200,
+		},
+		{
+			name:  "condition not met",
+			rule:  cashback,
+			event: &EarnEvent{Type: EarnEventPurchase, Attributes: map[string]any{"amount": float64(1234), "channel": "store"}, OccurredAt: now},
+			want:  0,
+		},
+		{
+			name:  "missing attribute",
+			rule:  cashback,
+			event: &EarnEvent{Type: EarnEventPurchase, Attributes: map[string]any{"channel": "web"}, OccurredAt: now},
+			want:  0,
+		},
+		{
+			name:  "other event type",
+			rule:  cashback,
+			event: &EarnEvent{Type: EarnEventSignup, Attributes: map[string]any{"amount": float64(1234), "channel": "web"}, OccurredAt: now},
+			want:  0,
+		},
+		{
+			name:  "fixed award",
+			rule:  &EarnRule{EarnRuleSpec: EarnRuleSpec{EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100, IsActive: true}},
+			event: &EarnEvent{Type: EarnEventSignup, OccurredAt: now},
+			want:  100,
+		},
+		{
+			name:  "outside date window",
+			rule:  &EarnRule{EarnRuleSpec: EarnRuleSpec{EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100, IsActive: true, StartsAt: &later}},
+			event: &EarnEvent{Type: EarnEventSignup, OccurredAt: now},
+			want:  0,
+		},
+		{
+			name:  "inactive rule",
+			rule:  &EarnRule{EarnRuleSpec: EarnRuleSpec{EventType: EarnEventSignup, AwardType: AwardFixed, Amount: 100}},
+			event: &EarnEvent{Type: EarnEventSignup, OccurredAt: now},
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.ComputeAward(tt.event); got != tt.want {
+				t.Errorf("EarnRule.ComputeAward() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// EmailChangeRequest is a change of a user's email address that must be confirmed from the new address
+// (only the hashes of the confirmation code and revert token are persisted)
+type EmailChangeRequest struct {
+	ID              string
+	UserID          string
+	OldEmail        string
+	NewEmail        string
+	CodeHash        string
+	RevertTokenHash string
+	Attempts        int
+	ExpiresAt       time.Time
+	RevertExpiresAt time.Time
+	ConfirmedAt     *time.Time
+	RevertedAt      *time.Time
+	CancelledAt     *time.Time
+	CreatedAt       time.Time
+}
+
+// NewEmailChangeRequest creates a new pending email change with validation (ID will be generated by database)
+func NewEmailChangeRequest(userID, oldEmail, newEmail, codeHash, revertTokenHash string, expiresAt, revertExpiresAt, now time.Time) (*EmailChangeRequest, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	if !isValidEmail(newEmail) {
+		return nil, ErrInvalidUserEmail
+	}
+
+	if strings.EqualFold(oldEmail, newEmail) {
+		return nil, ErrEmailUnchanged
+	}
+
+	if codeHash == "" {
+		return nil, ErrInvalidEmailChangeCode
+	}
+
+	if revertTokenHash == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if !expiresAt.After(now) || revertExpiresAt.Before(expiresAt) {
+		return nil, ErrInvalidInput
+	}
+
+	return &EmailChangeRequest{
+		UserID:          userID,
+		OldEmail:        oldEmail,
+		NewEmail:        newEmail,
+		CodeHash:        codeHash,
+		RevertTokenHash: revertTokenHash,
+		ExpiresAt:       expiresAt,
+		RevertExpiresAt: revertExpiresAt,
+		CreatedAt:       now,
+	}, nil
+}
+
+// IsPending reports whether the request is still waiting for confirmation
+func (r *EmailChangeRequest) IsPending() bool {
+	return r.ConfirmedAt == nil && r.RevertedAt == nil && r.CancelledAt == nil
+}
+
+// EnsureConfirmable checks that the request is pending and its code has not expired
+func (r *EmailChangeRequest) EnsureConfirmable(now time.Time) error {
+	if !r.IsPending() {
+		return ErrEmailChangeNotFound
+	}
+
+	if !now.Before(r.ExpiresAt) {
+		return ErrEmailChangeExpired
+	}
+
+	return nil
+}
+
+// Confirm marks the request as confirmed from the new address
+func (r *EmailChangeRequest) Confirm(now time.Time) {
+	r.ConfirmedAt = &now
+}
+
+// Cancel discards a pending request
+func (r *EmailChangeRequest) Cancel(now time.Time) {
+	r.CancelledAt = &now
+}
+
+// EnsureRevertable checks that the revert link has not been used or expired
+func (r *EmailChangeRequest) EnsureRevertable(now time.Time) error {
+	if r.RevertedAt != nil || r.CancelledAt != nil {
+		return ErrInvalidToken
+	}
+
+	if !now.Before(r.RevertExpiresAt) {
+		return ErrTokenExpired
+	}
+
+	return nil
+}
+
+// Revert marks a confirmed request as undone by the owner of the old address
+func (r *EmailChangeRequest) Revert(now time.Time) {
+	r.RevertedAt = &now
+}
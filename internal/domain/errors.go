@@ -4,16 +4,176 @@ import "errors"
 
 // User-related errors
 var (
-	ErrUserNotFound             = errors.New("user not found")
-	ErrUserAlreadyExists        = errors.New("user already exists")
-	ErrInvalidUserID            = errors.New("invalid user ID")
-	ErrInvalidUserEmail         = errors.New("invalid user email")
-	ErrInvalidUserName          = errors.New("invalid user name")
-	ErrInvalidUserRole          = errors.New("invalid user role")
-	ErrInvalidUserEmailVerified = errors.New("invalid user email verified")
-	ErrInvalidUserActive        = errors.New("invalid user active")
-	ErrInvalidOTP               = errors.New("invalid OTP")
-	ErrInvalidOTPExpiresAt      = errors.New("OTP expires at is in the past")
+	ErrUserNotFound              = errors.New("user not found")
+	ErrUserAlreadyExists         = errors.New("user already exists")
+	ErrInvalidUserID             = errors.New("invalid user ID")
+	ErrInvalidUserEmail          = errors.New("invalid user email")
+	ErrInvalidUserName           = errors.New("invalid user name")
+	ErrInvalidUserRole           = errors.New("invalid user role")
+	ErrInvalidUserEmailVerified  = errors.New("invalid user email verified")
+	ErrInvalidUserActive         = errors.New("invalid user active")
+	ErrInvalidOTP                = errors.New("invalid OTP")
+	ErrInvalidOTPExpiresAt       = errors.New("OTP expires at is in the past")
+	ErrOTPLocked                 = errors.New("too many failed OTP attempts, try again later")
+	ErrOTPResendCooldown         = errors.New("OTP was sent recently, try again later")
+	ErrUserInactive              = errors.New("user account is deactivated")
+	ErrUserAlreadyActive         = errors.New("user account is already active")
+	ErrUserAlreadyInactive       = errors.New("user account is already deactivated")
+	ErrInvalidDeactivationReason = errors.New("deactivation reason is required")
+	ErrRoleUnchanged             = errors.New("user already has this role")
+	ErrLastAdmin                 = errors.New("cannot remove the last active admin")
+)
+
+// Email change-related errors
+var (
+	ErrEmailChangeNotFound         = errors.New("no pending email change")
+	ErrEmailUnchanged              = errors.New("new email must differ from the current email")
+	ErrInvalidEmailChangeCode      = errors.New("invalid email change code")
+	ErrEmailChangeExpired          = errors.New("email change code has expired")
+	ErrEmailChangeAttemptsExceeded = errors.New("too many failed email change attempts, request a new change")
+)
+
+// Auth-related errors
+var (
+	ErrInvalidToken        = errors.New("invalid token")
+	ErrTokenExpired        = errors.New("token has expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+	ErrRefreshTokenRevoked = errors.New("refresh token has been revoked")
+)
+
+// API key-related errors
+var (
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	ErrInvalidAPIKey       = errors.New("invalid API key")
+	ErrInvalidAPIKeyName   = errors.New("invalid API key name")
+	ErrInvalidAPIKeyScope  = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyExpiry = errors.New("API key expiry must be in the future")
+	ErrAPIKeyRevoked       = errors.New("API key has been revoked")
+	ErrAPIKeyExpired       = errors.New("API key has expired")
+)
+
+// Ledger-related errors
+var (
+	ErrLedgerAccountNotFound    = errors.New("ledger account not found")
+	ErrWalletNotFound           = errors.New("wallet not found")
+	ErrJournalEntryNotFound     = errors.New("journal entry not found")
+	ErrInvalidAccountType       = errors.New("invalid ledger account type")
+	ErrInvalidEntryKind         = errors.New("invalid journal entry kind")
+	ErrInvalidPosting           = errors.New("invalid ledger posting")
+	ErrUnbalancedEntry          = errors.New("journal entry postings must balance to zero")
+	ErrInvalidAmount            = errors.New("amount must be positive")
+	ErrInsufficientCredits      = errors.New("insufficient credits")
+	ErrDuplicateEntry           = errors.New("journal entry with this reference already exists")
+	ErrCreditLotChanged         = errors.New("credit lot changed while it was being expired")
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+	ErrInvalidCursor            = errors.New("invalid pagination cursor")
+)
+
+// Reversal-related errors
+var (
+	ErrEntryNotReversible    = errors.New("journal entries of this kind cannot be reversed")
+	ErrEntryAlreadyReversed  = errors.New("journal entry has already been fully reversed")
+	ErrReversalExceedsEntry  = errors.New("reversal amount exceeds what is left of the journal entry")
+	ErrInvalidReversalReason = errors.New("invalid reversal reason")
+	ErrReversalConflict      = errors.New("journal entry was reversed concurrently, try again")
+)
+
+// Point type-related errors
+var (
+	ErrInvalidPointType         = errors.New("invalid point type")
+	ErrPointTypeMismatch        = errors.New("journal entry postings must use accounts of the entry's point type")
+	ErrPointTypeNotTransferable = errors.New("points of this type cannot be transferred")
+	ErrPointTypeNotRedeemable   = errors.New("points of this type cannot be redeemed")
+)
+
+// Earn rule-related errors
+var (
+	ErrEarnRuleNotFound      = errors.New("earn rule not found")
+	ErrInvalidEarnRuleName   = errors.New("invalid earn rule name")
+	ErrInvalidEventType      = errors.New("invalid earn event type")
+	ErrInvalidRuleCondition  = errors.New("invalid earn rule condition")
+	ErrInvalidEarnRuleAward  = errors.New("invalid earn rule award")
+	ErrInvalidEarnRuleCap    = errors.New("earn rule caps must be positive")
+	ErrInvalidEarnRuleWindow = errors.New("earn rule must end after it starts")
+	ErrEarnRuleCapReached    = errors.New("user has reached the earn rule's award limit")
+)
+
+// Campaign-related errors
+var (
+	ErrCampaignNotFound        = errors.New("campaign not found")
+	ErrInvalidCampaignName     = errors.New("invalid campaign name")
+	ErrInvalidCampaignWindow   = errors.New("campaign must have a start time and end after it starts")
+	ErrInvalidCampaignAudience = errors.New("invalid campaign audience")
+	ErrInvalidCampaignBoost    = errors.New("campaign needs a multiplier above 10000 basis points or a positive bonus")
+	ErrInvalidCampaignBudget   = errors.New("campaign budget must be positive and cover the credits already spent")
+	ErrCampaignBudgetExhausted = errors.New("campaign budget is exhausted")
+)
+
+// Reward-related errors
+var (
+	ErrRewardNotFound      = errors.New("reward not found")
+	ErrInvalidRewardName   = errors.New("invalid reward name")
+	ErrInvalidRewardCost   = errors.New("reward cost must be positive")
+	ErrInvalidRewardStock  = errors.New("reward stock cannot be negative")
+	ErrInvalidRewardStatus = errors.New("invalid reward status")
+	ErrInvalidRewardWindow = errors.New("reward must end after it starts")
+	ErrRewardUnavailable   = errors.New("reward is not available")
+)
+
+// Redemption-related errors
+var (
+	ErrRedemptionNotFound = errors.New("redemption not found")
+	ErrRedemptionNotHeld  = errors.New("redemption is no longer on hold")
+	ErrRedemptionExpired  = errors.New("redemption hold has expired")
+)
+
+// Transfer-related errors
+var (
+	ErrSelfTransfer                = errors.New("cannot transfer credits to yourself")
+	ErrInvalidTransferNote         = errors.New("transfer note is too long")
+	ErrTransferLimitExceeded       = errors.New("amount exceeds the per-transfer limit")
+	ErrDailyTransferLimitExceeded  = errors.New("amount exceeds the daily transfer limit")
+	ErrTransferSenderIneligible    = errors.New("sender account must be active and email-verified to transfer credits")
+	ErrTransferRecipientIneligible = errors.New("recipient account must be active and email-verified to receive credits")
+)
+
+// Adjustment-related errors
+var (
+	ErrAdjustmentNotFound         = errors.New("adjustment not found")
+	ErrInvalidAdjustmentDirection = errors.New("invalid adjustment direction")
+	ErrInvalidAdjustmentReason    = errors.New("invalid adjustment reason code")
+	ErrInvalidAdjustmentNote      = errors.New("adjustment note is too long")
+	ErrInvalidAdjustmentStatus    = errors.New("invalid adjustment status")
+	ErrInvalidAdjustmentActor     = errors.New("adjustments must be requested and reviewed by admin users")
+	ErrSelfApproval               = errors.New("an adjustment must be reviewed by an admin other than its requester")
+	ErrAdjustmentNotPending       = errors.New("adjustment has already been reviewed")
+)
+
+// Tier-related errors
+var (
+	ErrTierNotFound         = errors.New("tier not found")
+	ErrInvalidTierName      = errors.New("invalid tier name")
+	ErrInvalidTierThreshold = errors.New("tier threshold cannot be negative")
+	ErrTierAlreadyExists    = errors.New("a tier with this name or threshold already exists")
+	ErrTierChanged          = errors.New("user's tier changed while it was being recalculated")
+)
+
+// Referral-related errors
+var (
+	ErrReferralNotFound     = errors.New("referral not found")
+	ErrInvalidReferralCode  = errors.New("invalid referral code")
+	ErrSelfReferral         = errors.New("cannot use your own referral code")
+	ErrReferralLimitReached = errors.New("referral code has been used by too many accounts on this email domain")
+	ErrReferralNotPending   = errors.New("referral has already been rewarded")
+	ErrReferralCodeTaken    = errors.New("referral code is already taken")
+)
+
+// Idempotency-related errors
+var (
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyExists     = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
 )
 
 var (
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the maximum length of a client-supplied Idempotency-Key
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord remembers the response sent for a mutating request so retries
+// carrying the same Idempotency-Key get that response instead of repeating the change
+type IdempotencyRecord struct {
+	Scope        string // whose key it is: keys of different callers never collide
+	Key          string
+	Fingerprint  string // hash of the request the key was first used with
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CompletedAt  *time.Time // nil while the first request is still being processed
+	ExpiresAt    time.Time  // end of the in-progress lease, then of the replay window once completed
+	CreatedAt    time.Time
+}
+
+// NewIdempotencyRecord reserves a key for a request (method, path and body). The reservation is a
+// lease: if the request never completes or releases the key, it can be taken over once the lease ends.
+func NewIdempotencyRecord(scope, key, method, path string, body []byte, lease time.Duration) (*IdempotencyRecord, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now()
+	return &IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: RequestFingerprint(method, path, body),
+		ExpiresAt:   now.Add(lease),
+		CreatedAt:   now,
+	}, nil
+}
+
+// RequestFingerprint hashes what identifies a request for idempotency purposes
+func RequestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// IsCompleted reports whether the response to the first request has been stored
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.CompletedAt != nil
+}
+
+// IsExpiredAt reports whether the key can be reused for a new request at the given time
+func (r *IdempotencyRecord) IsExpiredAt(t time.Time) bool {
+	return !t.Before(r.ExpiresAt)
+}
+
+// Complete stores the response sent for the key's first request and keeps it for ttl
+func (r *IdempotencyRecord) Complete(statusCode int, contentType string, body []byte, ttl time.Duration) {
+	now := time.Now()
+	r.StatusCode = statusCode
+	r.ContentType = contentType
+	r.ResponseBody = body
+	r.CompletedAt = &now
+	r.ExpiresAt = now.Add(ttl)
+}
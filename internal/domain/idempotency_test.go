@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		errType error
+	}{
+		{name: "valid key", key: "3f1c2b7e-retry"},
+		{name: "empty key", key: "", errType: ErrInvalidIdempotencyKey},
+		{name: "key too long", key: strings.Repeat("k", MaxIdempotencyKeyLength+1), errType: ErrInvalidIdempotencyKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := NewIdempotencyRecord("user:1", tt.key, "POST", "/api/v1/transfers", []byte(`{"amount":10}`), time.Hour)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewIdempotencyRecord() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewIdempotencyRecord() unexpected error: %v", err)
+			}
+
+			if record.IsCompleted() || record.IsExpiredAt(time.Now()) || !record.IsExpiredAt(time.Now().Add(time.Hour)) {
+				t.Errorf("NewIdempotencyRecord() = %+v, want a pending key expiring in an hour", record)
+			}
+		})
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	fingerprint := RequestFingerprint("POST", "/api/v1/transfers", []byte(`{"amount":10}`))
+
+	if fingerprint != RequestFingerprint("POST", "/api/v1/transfers", []byte(`{"amount":10}`)) {
+		t.Error("RequestFingerprint() differs for identical requests")
+	}
+	if fingerprint == RequestFingerprint("POST", "/api/v1/transfers", []byte(`{"amount":20}`)) {
+		t.Error("RequestFingerprint() matches for a different body")
+	}
+	if fingerprint == RequestFingerprint("POST", "/api/v1/redemptions", []byte(`{"amount":10}`)) {
+		t.Error("RequestFingerprint() matches for a different path")
+	}
+}
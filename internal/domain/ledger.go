@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AccountType identifies what a ledger account holds
+type AccountType string
+
+// Ledger account types
+const (
+	AccountUserAvailable AccountType = "user_available" // credits a user can spend
+	AccountUserHeld      AccountType = "user_held"      // credits reserved by a user's open redemptions
+	AccountIssuance      AccountType = "issuance"       // system source of every credit awarded to users
+	AccountRedemption    AccountType = "redemption"     // system sink for credits users spend
+	AccountExpiration    AccountType = "expiration"     // system sink for credits that expired unspent
+)
+
+// WalletAccountTypes lists the accounts opened for every user together with the user row
+var WalletAccountTypes = []AccountType{AccountUserAvailable, AccountUserHeld}
+
+// IsUserAccount reports whether accounts of this type belong to a user rather than the system
+func (t AccountType) IsUserAccount() bool {
+	return t == AccountUserAvailable || t == AccountUserHeld
+}
+
+// EntryKind classifies the business event recorded by a journal entry
+type EntryKind string
+
+// Journal entry kinds
+const (
+	EntryKindEarn       EntryKind = "earn"
+	EntryKindRedeem     EntryKind = "redeem"
+	EntryKindHold       EntryKind = "hold"
+	EntryKindRelease    EntryKind = "release"
+	EntryKindAdjustment EntryKind = "adjustment"
+	EntryKindExpire     EntryKind = "expire"
+	EntryKindTransfer   EntryKind = "transfer"
+	EntryKindReversal   EntryKind = "reversal"
+)
+
+// IsValid reports whether the entry kind is known
+func (k EntryKind) IsValid() bool {
+	switch k {
+	case EntryKindEarn, EntryKindRedeem, EntryKindHold, EntryKindRelease, EntryKindAdjustment, EntryKindExpire, EntryKindTransfer, EntryKindReversal:
+		return true
+	default:
+		return false
+	}
+}
+
+// LedgerAccount holds a balance of points of one type; user accounts can never go negative
+type LedgerAccount struct {
+	ID            string
+	Type          AccountType
+	PointType     PointType
+	UserID        *string // nil for system accounts
+	Balance       int64
+	AllowNegative bool
+	CreatedAt     time.Time
+}
+
+// NewUserAccount creates a ledger account owned by a user (ID will be generated by database)
+func NewUserAccount(userID string, pointType PointType, accountType AccountType) (*LedgerAccount, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	if !pointType.IsValid() {
+		return nil, ErrInvalidPointType
+	}
+
+	if !accountType.IsUserAccount() {
+		return nil, ErrInvalidAccountType
+	}
+
+	return &LedgerAccount{
+		Type:      accountType,
+		PointType: pointType,
+		UserID:    &userID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Wallet summarizes a user's points, derived from their ledger accounts and postings.
+// Available, Held and LifetimeEarned count credits; Balances holds the available
+// points of every type the user has an account for.
+type Wallet struct {
+	UserID         string
+	Available      int64
+	Held           int64
+	LifetimeEarned int64
+	Balances       map[PointType]int64
+}
+
+// Pending returns the credits awaiting settlement: held by open redemptions, they are spent when
+// the redemption is confirmed or return to the available balance when it is cancelled or times out
+func (w *Wallet) Pending() int64 {
+	return w.Held
+}
+
+// Posting moves an amount into (positive) or out of (negative) a single account
+type Posting struct {
+	ID           int64
+	EntryID      string
+	AccountID    string
+	Amount       int64
+	BalanceAfter int64 // set by the repository when the entry is posted
+	CreatedAt    time.Time
+}
+
+// JournalEntry is an immutable, balanced record of one movement of points of a single type
+type JournalEntry struct {
+	ID          string
+	Kind        EntryKind
+	PointType   PointType // every posting must be to an account of this type
+	UserID      string
+	Reference   *string // optional external reference, unique per kind
+	Description string
+	Postings    []Posting
+	ExpiresAt   *time.Time // when credits the entry adds to a user's wallet expire; nil means never
+	CreatedAt   time.Time
+}
+
+// NewJournalEntry creates a balanced journal entry with validation (ID will be generated by database)
+func NewJournalEntry(kind EntryKind, pointType PointType, userID, reference, description string, postings []Posting) (*JournalEntry, error) {
+	entry := &JournalEntry{
+		Kind:        kind,
+		PointType:   pointType,
+		UserID:      userID,
+		Description: strings.TrimSpace(description),
+		Postings:    postings,
+		CreatedAt:   time.Now(),
+	}
+
+	if reference = strings.TrimSpace(reference); reference != "" {
+		entry.Reference = &reference
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Validate checks that the entry is tied to a user and its postings balance to zero
+func (e *JournalEntry) Validate() error {
+	if !e.Kind.IsValid() {
+		return ErrInvalidEntryKind
+	}
+
+	if !e.PointType.IsValid() {
+		return ErrInvalidPointType
+	}
+
+	if e.UserID == "" {
+		return ErrInvalidUserID
+	}
+
+	if len(e.Postings) < 2 {
+		return ErrUnbalancedEntry
+	}
+
+	var sum int64
+	for _, posting := range e.Postings {
+		if posting.AccountID == "" || posting.Amount == 0 {
+			return ErrInvalidPosting
+		}
+		sum += posting.Amount
+	}
+
+	if sum != 0 {
+		return ErrUnbalancedEntry
+	}
+
+	return nil
+}
+
+// ExpireCreditsAfter makes the credits the entry adds to a user's wallet expire lifetime after it is created.
+// A non-positive lifetime keeps them forever.
+func (e *JournalEntry) ExpireCreditsAfter(lifetime time.Duration) {
+	if lifetime <= 0 {
+		e.ExpiresAt = nil
+		return
+	}
+
+	expiresAt := e.CreatedAt.Add(lifetime)
+	e.ExpiresAt = &expiresAt
+}
+
+// NewTransferEntry creates an entry moving a positive amount from one account to another
+func NewTransferEntry(kind EntryKind, pointType PointType, userID, fromAccountID, toAccountID string, amount int64, reference, description string) (*JournalEntry, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if fromAccountID == toAccountID {
+		return nil, ErrInvalidPosting
+	}
+
+	return NewJournalEntry(kind, pointType, userID, reference, description, []Posting{
+		{AccountID: fromAccountID, Amount: -amount},
+		{AccountID: toAccountID, Amount: amount},
+	})
+}
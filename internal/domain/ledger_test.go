@@ -0,0 +1,120 @@
+package domain
+
+import "testing"
+
+func TestNewJournalEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     EntryKind
+		userID   string
+		postings []Posting
+		errType  error
+	}{
+		{
+			name:   "balanced entry",
+			kind:   EntryKindEarn,
+			userID: "user-1",
+			postings: []Posting{
+				{AccountID: "issuance", Amount: -100},
+				{AccountID: "user-account", Amount: 100},
+			},
+		},
+		{
+			name:   "unbalanced entry",
+			kind:   EntryKindEarn,
+			userID: "user-1",
+			postings: []Posting{
+				{AccountID: "issuance", Amount: -100},
+				{AccountID: "user-account", Amount: 90},
+			},
+			errType: ErrUnbalancedEntry,
+		},
+		{
+			name:   "single posting",
+			kind:   EntryKindEarn,
+			userID: "user-1",
+			postings: []Posting{
+				{AccountID: "user-account", Amount: 0},
+			},
+			errType: ErrUnbalancedEntry,
+		},
+		{
+			name:   "zero amount posting",
+			kind:   EntryKindAdjustment,
+			userID: "user-1",
+			postings: []Posting{
+				{AccountID: "issuance", Amount: 0},
+				{AccountID: "user-account", Amount: 0},
+			},
+			errType: ErrInvalidPosting,
+		},
+		{
+			name:   "missing user",
+			kind:   EntryKindEarn,
+			userID: "",
+			postings: []Posting{
+				{AccountID: "issuance", Amount: -100},
+				{AccountID: "user-account", Amount: 100},
+			},
+			errType: ErrInvalidUserID,
+		},
+		{
+			name:   "unknown kind",
+			kind:   EntryKind("gift"),
+			userID: "user-1",
+			postings: []Posting{
+				{AccountID: "issuance", Amount: -100},
+				{AccountID: "user-account", Amount: 100},
+			},
+			errType: ErrInvalidEntryKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewJournalEntry(tt.kind, PointTypeCredits, tt.userID, " order-1 ", " signup bonus ", tt.postings)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewJournalEntry() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewJournalEntry() unexpected error: %v", err)
+			}
+
+			if entry.Reference == nil || *entry.Reference != "order-1" {
+				t.Errorf("NewJournalEntry() Reference = %v, want order-1", entry.Reference)
+			}
+
+			if entry.Description != "signup bonus" {
+				t.Errorf("NewJournalEntry() Description = %q, want %q", entry.Description, "signup bonus")
+			}
+		})
+	}
+}
+
+func TestNewTransferEntry(t *testing.T) {
+	entry, err := NewTransferEntry(EntryKindRedeem, PointTypeCredits, "user-1", "user-account", "redemption", 40, "", "")
+	if err != nil {
+		t.Fatalf("NewTransferEntry() unexpected error: %v", err)
+	}
+
+	if entry.Reference != nil {
+		t.Errorf("NewTransferEntry() Reference = %v, want nil", *entry.Reference)
+	}
+
+	if len(entry.Postings) != 2 || entry.Postings[0].Amount != -40 || entry.Postings[1].Amount != 40 {
+		t.Errorf("NewTransferEntry() Postings = %+v, want -40/+40", entry.Postings)
+	}
+
+	if _, err := NewTransferEntry(EntryKindRedeem, PointTypeCredits, "user-1", "user-account", "redemption", 0, "", ""); !containsTargetError(err, ErrInvalidAmount) {
+		t.Errorf("NewTransferEntry() zero amount expected %v, got %v", ErrInvalidAmount, err)
+	}
+
+	if _, err := NewTransferEntry(EntryKindRedeem, PointTypeCredits, "user-1", "user-account", "user-account", 10, "", ""); !containsTargetError(err, ErrInvalidPosting) {
+		t.Errorf("NewTransferEntry() same account expected %v, got %v", ErrInvalidPosting, err)
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// PointType identifies a currency of points kept in the ledger; balances of different types never mix
+type PointType string
+
+// Point types
+const (
+	PointTypeCredits    PointType = "credits"     // spendable credits
+	PointTypeTierPoints PointType = "tier_points" // status points that count towards loyalty tiers
+)
+
+// PointTypes lists every point type the ledger keeps
+var PointTypes = []PointType{PointTypeCredits, PointTypeTierPoints}
+
+// IsValid reports whether the point type is known
+func (t PointType) IsValid() bool {
+	switch t {
+	case PointTypeCredits, PointTypeTierPoints:
+		return true
+	default:
+		return false
+	}
+}
+
+// PointTypePolicy configures what users can do with points of one type
+type PointTypePolicy struct {
+	Transferable bool          // users can send the points to each other
+	Redeemable   bool          // rewards can be priced in the points
+	Lifetime     time.Duration // how long issued points stay usable; zero keeps them forever
+}
+
+// PointTypePolicies holds the policy of each point type
+type PointTypePolicies map[PointType]PointTypePolicy
+
+// DefaultPointTypePolicies returns spendable, transferable credits and status-only tier points, none expiring
+func DefaultPointTypePolicies() PointTypePolicies {
+	return PointTypePolicies{
+		PointTypeCredits:    {Transferable: true, Redeemable: true},
+		PointTypeTierPoints: {},
+	}
+}
+
+// Policy returns the policy of a point type
+func (p PointTypePolicies) Policy(pointType PointType) (PointTypePolicy, error) {
+	if !pointType.IsValid() {
+		return PointTypePolicy{}, ErrInvalidPointType
+	}
+
+	return p[pointType], nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestPointTypePolicies_Policy(t *testing.T) {
+	policies := DefaultPointTypePolicies()
+
+	credits, err := policies.Policy(PointTypeCredits)
+	if err != nil {
+		t.Fatalf("Policy(credits) unexpected error: %v", err)
+	}
+	if !credits.Transferable || !credits.Redeemable {
+		t.Errorf("Policy(credits) = %+v, want transferable and redeemable", credits)
+	}
+
+	tierPoints, err := policies.Policy(PointTypeTierPoints)
+	if err != nil {
+		t.Fatalf("Policy(tier_points) unexpected error: %v", err)
+	}
+	if tierPoints.Transferable || tierPoints.Redeemable {
+		t.Errorf("Policy(tier_points) = %+v, want neither transferable nor redeemable", tierPoints)
+	}
+
+	if _, err := policies.Policy(PointType("miles")); !containsTargetError(err, ErrInvalidPointType) {
+		t.Errorf("Policy(miles) expected error %v, got %v", ErrInvalidPointType, err)
+	}
+}
+
+func TestNewJournalEntry_PointType(t *testing.T) {
+	postings := []Posting{
+		{AccountID: "issuance", Amount: -100},
+		{AccountID: "user-account", Amount: 100},
+	}
+
+	entry, err := NewJournalEntry(EntryKindEarn, PointTypeTierPoints, "user-1", "", "", postings)
+	if err != nil {
+		t.Fatalf("NewJournalEntry() unexpected error: %v", err)
+	}
+	if entry.PointType != PointTypeTierPoints {
+		t.Errorf("NewJournalEntry() PointType = %q, want %q", entry.PointType, PointTypeTierPoints)
+	}
+
+	if _, err := NewJournalEntry(EntryKindEarn, PointType(""), "user-1", "", "", postings); !containsTargetError(err, ErrInvalidPointType) {
+		t.Errorf("NewJournalEntry() without point type expected error %v, got %v", ErrInvalidPointType, err)
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// RedemptionStatus tracks a redemption from hold to settlement
+type RedemptionStatus string
+
+// Redemption statuses
+const (
+	RedemptionHeld      RedemptionStatus = "held"      // credits and stock are reserved
+	RedemptionConfirmed RedemptionStatus = "confirmed" // credits were spent on the reward
+	RedemptionCancelled RedemptionStatus = "cancelled" // the user or an admin released the hold
+	RedemptionExpired   RedemptionStatus = "expired"   // the hold timed out and was released
+)
+
+// Redemption reserves a user's credits and one unit of reward stock until it is confirmed or released
+type Redemption struct {
+	ID            string
+	UserID        string
+	RewardID      string
+	Cost          int64
+	PointType     PointType
+	Status        RedemptionStatus
+	HoldEntryID   string
+	SettleEntryID *string
+	ExpiresAt     time.Time
+	SettledAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// NewRedemption creates a held redemption for a reward that is available now (ID will be generated by database)
+func NewRedemption(userID string, reward *Reward, holdTTL time.Duration) (*Redemption, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+
+	now := time.Now()
+	if !reward.IsAvailableAt(now) {
+		return nil, ErrRewardUnavailable
+	}
+
+	return &Redemption{
+		UserID:    userID,
+		RewardID:  reward.ID,
+		Cost:      reward.Cost,
+		PointType: reward.PointType,
+		Status:    RedemptionHeld,
+		ExpiresAt: now.Add(holdTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+// IsHeld reports whether the redemption still reserves credits and stock
+func (r *Redemption) IsHeld() bool {
+	return r.Status == RedemptionHeld
+}
+
+// IsExpiredAt reports whether the hold has timed out at the given time
+func (r *Redemption) IsExpiredAt(at time.Time) bool {
+	return r.IsHeld() && !at.Before(r.ExpiresAt)
+}
+
+// Confirm marks the held redemption as spent
+func (r *Redemption) Confirm(now time.Time) error {
+	if !r.IsHeld() {
+		return ErrRedemptionNotHeld
+	}
+
+	if r.IsExpiredAt(now) {
+		return ErrRedemptionExpired
+	}
+
+	r.Status = RedemptionConfirmed
+	r.SettledAt = &now
+	return nil
+}
+
+// Cancel releases the held redemption; expired reports whether the hold timed out rather than being cancelled
+func (r *Redemption) Cancel(now time.Time, expired bool) error {
+	if !r.IsHeld() {
+		return ErrRedemptionNotHeld
+	}
+
+	r.Status = RedemptionCancelled
+	if expired {
+		r.Status = RedemptionExpired
+	}
+	r.SettledAt = &now
+	return nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRedemption(t *testing.T) {
+	available := &Reward{ID: "reward-1", RewardSpec: RewardSpec{Name: "Mug", Cost: 300, Stock: 1, Status: RewardActive}}
+	soldOut := &Reward{ID: "reward-2", RewardSpec: RewardSpec{Name: "Mug", Cost: 300, Status: RewardActive}}
+
+	redemption, err := NewRedemption("user-1", available, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("NewRedemption() unexpected error: %v", err)
+	}
+
+	if redemption.Status != RedemptionHeld || redemption.Cost != 300 || redemption.RewardID != "reward-1" {
+		t.Errorf("NewRedemption() = %+v, want held redemption of reward-1 costing 300", redemption)
+	}
+
+	if got := redemption.ExpiresAt.Sub(redemption.CreatedAt); got != 10*time.Minute {
+		t.Errorf("NewRedemption() hold lasts %v, want 10m", got)
+	}
+
+	if _, err := NewRedemption("user-1", soldOut, 10*time.Minute); !containsTargetError(err, ErrRewardUnavailable) {
+		t.Errorf("NewRedemption() sold out expected %v, got %v", ErrRewardUnavailable, err)
+	}
+
+	if _, err := NewRedemption("", available, 10*time.Minute); !containsTargetError(err, ErrInvalidUserID) {
+		t.Errorf("NewRedemption() without user expected %v, got %v", ErrInvalidUserID, err)
+	}
+}
+
+func TestRedemption_Settle(t *testing.T) {
+	now := time.Now()
+
+	held := func() *Redemption {
+		return &Redemption{Status: RedemptionHeld, ExpiresAt: now.Add(time.Minute)}
+	}
+
+	redemption := held()
+	if err := redemption.Confirm(now); err != nil {
+		t.Fatalf("Confirm() unexpected error: %v", err)
+	}
+	if redemption.Status != RedemptionConfirmed || redemption.SettledAt == nil {
+		t.Errorf("Confirm() = %+v, want confirmed and settled", redemption)
+	}
+	if err := redemption.Cancel(now, false); !containsTargetError(err, ErrRedemptionNotHeld) {
+		t.Errorf("Cancel() after confirm expected %v, got %v", ErrRedemptionNotHeld, err)
+	}
+
+	if err := held().Confirm(now.Add(time.Hour)); !containsTargetError(err, ErrRedemptionExpired) {
+		t.Errorf("Confirm() after expiry expected %v, got %v", ErrRedemptionExpired, err)
+	}
+
+	redemption = held()
+	if err := redemption.Cancel(now, true); err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+	if redemption.Status != RedemptionExpired {
+		t.Errorf("Cancel(expired) Status = %v, want %v", redemption.Status, RedemptionExpired)
+	}
+}
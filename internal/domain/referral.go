@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ReferralCodeLength is the number of characters in a referral code
+const ReferralCodeLength = 8
+
+// ReferralStatus tracks whether a referral has paid out
+type ReferralStatus string
+
+// Referral statuses
+const (
+	ReferralPending  ReferralStatus = "pending"  // waiting for the referee to verify their email
+	ReferralRewarded ReferralStatus = "rewarded" // both users received their credits
+)
+
+// Referral links a user who signed up with a referral code to the user who owns the code
+type Referral struct {
+	ID                 string
+	ReferrerID         string
+	RefereeID          string
+	RefereeName        string
+	RefereeEmailDomain string // domain the referee signed up with, capped per referrer against farming
+	Status             ReferralStatus
+	ReferrerEntryID    *string
+	RefereeEntryID     *string
+	CreatedAt          time.Time
+	RewardedAt         *time.Time
+}
+
+// NormalizeReferralCode makes referral codes case-insensitive
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// EmailDomain returns the lower-cased domain of an email address
+func EmailDomain(email string) string {
+	_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	return domain
+}
+
+// mailbox returns the lower-cased email address without any "+tag" in its local part,
+// so aliases delivered to the same inbox compare equal
+func mailbox(email string) string {
+	local, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + domain
+}
+
+// ReferBy links a user who is signing up to the owner of the referral code they used
+func (u *User) ReferBy(referrer *User) error {
+	if !referrer.IsActive {
+		return ErrInvalidReferralCode
+	}
+
+	if referrer.ID == u.ID || mailbox(referrer.Email) == mailbox(u.Email) {
+		return ErrSelfReferral
+	}
+
+	u.ReferredBy = &referrer.ID
+	return nil
+}
+
+// MarkRewarded records that both users of the referral are being paid their credits
+func (r *Referral) MarkRewarded(now time.Time) error {
+	if r.Status != ReferralPending {
+		return ErrReferralNotPending
+	}
+
+	r.Status = ReferralRewarded
+	r.RewardedAt = &now
+	return nil
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxReversalReasonLength is the maximum number of characters in a reversal reason
+const MaxReversalReasonLength = 500
+
+// IsReversible reports whether entries of this kind can be reversed: earn entries when the order
+// behind them is refunded, and redeem entries when the reward could not be fulfilled
+func (k EntryKind) IsReversible() bool {
+	return k == EntryKindEarn || k == EntryKindRedeem
+}
+
+// Amount returns the points the entry moved, the sum of its postings into accounts
+func (e *JournalEntry) Amount() int64 {
+	var amount int64
+	for _, posting := range e.Postings {
+		if posting.Amount > 0 {
+			amount += posting.Amount
+		}
+	}
+	return amount
+}
+
+// Reversal undoes all or part of a journal entry with a compensating entry linked to it.
+// Reversing an earn entry claws the points back from the user's available balance; points the user
+// already spent or lost to expiry cannot be taken back and are written off as the shortfall.
+// Reversing a redeem entry returns the points to the user's available balance.
+type Reversal struct {
+	ID              string
+	EntryID         string // the reversed entry
+	EntryKind       EntryKind
+	UserID          string
+	PointType       PointType
+	Amount          int64   // how much of the reversed entry this reversal undoes
+	Posted          int64   // how much the compensating entry moved; less than Amount when there is a shortfall
+	ReversalEntryID *string // the compensating entry; nil when nothing could be moved
+	Reason          string
+	CreatedAt       time.Time
+}
+
+// NewReversal creates a reversal of amount points of an entry, of which reversed points were reversed before;
+// a zero amount reverses whatever is left (ID will be generated by database)
+func NewReversal(entry *JournalEntry, reversed, amount int64, reason string) (*Reversal, error) {
+	if !entry.Kind.IsReversible() {
+		return nil, ErrEntryNotReversible
+	}
+
+	remaining := entry.Amount() - reversed
+	if remaining <= 0 {
+		return nil, ErrEntryAlreadyReversed
+	}
+
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+	if amount > remaining {
+		return nil, ErrReversalExceedsEntry
+	}
+
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > MaxReversalReasonLength {
+		return nil, ErrInvalidReversalReason
+	}
+
+	return &Reversal{
+		EntryID:   entry.ID,
+		EntryKind: entry.Kind,
+		UserID:    entry.UserID,
+		PointType: entry.PointType,
+		Amount:    amount,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Shortfall returns the points the reversal undid on paper but could not move back
+func (r *Reversal) Shortfall() int64 {
+	return r.Amount - r.Posted
+}
+
+// EntryReference returns the reference of the compensating entry, given the points of the reversed entry
+// that were reversed before; it identifies each reversal of an entry
+func (r *Reversal) EntryReference(reversed int64) string {
+	return fmt.Sprintf("reversal:%s:%d", r.EntryID, reversed)
+}
+
+// Description returns the description of the compensating entry
+func (r *Reversal) Description() string {
+	if r.Reason == "" {
+		return "Reversal"
+	}
+	return "Reversal: " + r.Reason
+}
+
+// TotalReversed returns the points reversed by the given reversals
+func TotalReversed(reversals []*Reversal) int64 {
+	var total int64
+	for _, reversal := range reversals {
+		total += reversal.Amount
+	}
+	return total
+}
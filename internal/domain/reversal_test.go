@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewReversal(t *testing.T) {
+	earn, err := NewTransferEntry(EntryKindEarn, PointTypeCredits, "user-1", "issuance", "account-1", 100, "order-1", "Cashback")
+	if err != nil {
+		t.Fatalf("NewTransferEntry() unexpected error: %v", err)
+	}
+	transfer, err := NewTransferEntry(EntryKindTransfer, PointTypeCredits, "user-1", "account-1", "account-2", 100, "", "Gift")
+	if err != nil {
+		t.Fatalf("NewTransferEntry() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		entry    *JournalEntry
+		reversed int64
+		amount   int64
+		reason   string
+		want     int64
+		errType  error
+	}{
+		{"full reversal", earn, 0, 0, "Order refunded", 100, nil},
+		{"partial reversal", earn, 0, 40, "", 40, nil},
+		{"rest after a partial reversal", earn, 40, 0, "", 60, nil},
+		{"more than is left", earn, 40, 61, "", 0, ErrReversalExceedsEntry},
+		{"already fully reversed", earn, 100, 0, "", 0, ErrEntryAlreadyReversed},
+		{"negative amount", earn, 0, -5, "", 0, ErrInvalidAmount},
+		{"reason too long", earn, 0, 0, strings.Repeat("a", MaxReversalReasonLength+1), 0, ErrInvalidReversalReason},
+		{"transfers are not reversible", transfer, 0, 0, "", 0, ErrEntryNotReversible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reversal, err := NewReversal(tt.entry, tt.reversed, tt.amount, tt.reason)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewReversal() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewReversal() unexpected error: %v", err)
+			}
+			if reversal.Amount != tt.want || reversal.UserID != "user-1" || reversal.EntryKind != tt.entry.Kind {
+				t.Errorf("NewReversal() = %+v, want %d reversed", reversal, tt.want)
+			}
+		})
+	}
+}
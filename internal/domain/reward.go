@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// RewardStatus controls whether a reward is offered in the catalog
+type RewardStatus string
+
+// Reward statuses
+const (
+	RewardDraft    RewardStatus = "draft"
+	RewardActive   RewardStatus = "active"
+	RewardArchived RewardStatus = "archived"
+)
+
+// IsValid reports whether the reward status is known
+func (s RewardStatus) IsValid() bool {
+	switch s {
+	case RewardDraft, RewardActive, RewardArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+// RewardSpec holds the admin-editable settings of a reward
+type RewardSpec struct {
+	Name        string
+	Description string
+	Cost        int64
+	PointType   PointType // what the cost is paid in; defaults to credits
+	Stock       int
+	Status      RewardStatus
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+}
+
+// Reward is a catalog item users can spend credits on
+type Reward struct {
+	ID string
+	RewardSpec
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewReward creates a new reward with validation (ID will be generated by database)
+func NewReward(spec RewardSpec) (*Reward, error) {
+	now := time.Now()
+	reward := &Reward{
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if spec.Status == "" {
+		spec.Status = RewardDraft
+	}
+
+	if err := reward.Update(spec); err != nil {
+		return nil, err
+	}
+
+	return reward, nil
+}
+
+// Update replaces the reward's settings with validation
+func (r *Reward) Update(spec RewardSpec) error {
+	spec.Name = strings.TrimSpace(spec.Name)
+	spec.Description = strings.TrimSpace(spec.Description)
+	if spec.PointType == "" {
+		spec.PointType = PointTypeCredits
+	}
+
+	updated := *r
+	updated.RewardSpec = spec
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	r.RewardSpec = spec
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Validate performs domain validation on the reward
+func (r *Reward) Validate() error {
+	if r.Name == "" {
+		return ErrInvalidRewardName
+	}
+
+	if r.Cost <= 0 {
+		return ErrInvalidRewardCost
+	}
+
+	if !r.PointType.IsValid() {
+		return ErrInvalidPointType
+	}
+
+	if r.Stock < 0 {
+		return ErrInvalidRewardStock
+	}
+
+	if !r.Status.IsValid() {
+		return ErrInvalidRewardStatus
+	}
+
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return ErrInvalidRewardWindow
+	}
+
+	return nil
+}
+
+// IsAvailableAt reports whether users can browse and redeem the reward at the given time
+func (r *Reward) IsAvailableAt(at time.Time) bool {
+	if r.Status != RewardActive || r.Stock <= 0 {
+		return false
+	}
+
+	if r.StartsAt != nil && at.Before(*r.StartsAt) {
+		return false
+	}
+
+	return r.EndsAt == nil || at.Before(*r.EndsAt)
+}
+
+// Archive withdraws the reward from the catalog
+func (r *Reward) Archive() {
+	r.Status = RewardArchived
+	r.UpdatedAt = time.Now()
+}
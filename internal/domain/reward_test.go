@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReward(t *testing.T) {
+	start := time.Now()
+	end := start.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		spec    RewardSpec
+		errType error
+	}{
+		{
+			name: "valid reward defaults to draft",
+			spec: RewardSpec{Name: " Coffee voucher ", Cost: 500, Stock: 10},
+		},
+		{
+			name:    "missing name",
+			spec:    RewardSpec{Cost: 500, Stock: 10},
+			errType: ErrInvalidRewardName,
+		},
+		{
+			name:    "non-positive cost",
+			spec:    RewardSpec{Name: "Coffee voucher", Stock: 10},
+			errType: ErrInvalidRewardCost,
+		},
+		{
+			name:    "negative stock",
+			spec:    RewardSpec{Name: "Coffee voucher", Cost: 500, Stock: -1},
+			errType: ErrInvalidRewardStock,
+		},
+		{
+			name:    "unknown status",
+			spec:    RewardSpec{Name: "Coffee voucher", Cost: 500, Status: "sold_out"},
+			errType: ErrInvalidRewardStatus,
+		},
+		{
+			name:    "window ends before it starts",
+			spec:    RewardSpec{Name: "Coffee voucher", Cost: 500, StartsAt: &start, EndsAt: &end},
+			errType: ErrInvalidRewardWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reward, err := NewReward(tt.spec)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewReward() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewReward() unexpected error: %v", err)
+			}
+
+			if reward.Name != "Coffee voucher" {
+				t.Errorf("NewReward() Name = %q, want %q", reward.Name, "Coffee voucher")
+			}
+
+			if reward.Status != RewardDraft {
+				t.Errorf("NewReward() Status = %v, want %v", reward.Status, RewardDraft)
+			}
+		})
+	}
+}
+
+func TestReward_IsAvailableAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		reward Reward
+		want   bool
+	}{
+		{
+			name:   "active and in stock",
+			reward: Reward{RewardSpec: RewardSpec{Status: RewardActive, Stock: 1}},
+			want:   true,
+		},
+		{
+			name:   "out of stock",
+			reward: Reward{RewardSpec: RewardSpec{Status: RewardActive}},
+			want:   false,
+		},
+		{
+			name:   "draft",
+			reward: Reward{RewardSpec: RewardSpec{Status: RewardDraft, Stock: 1}},
+			want:   false,
+		},
+		{
+			name:   "not started",
+			reward: Reward{RewardSpec: RewardSpec{Status: RewardActive, Stock: 1, StartsAt: &future}},
+			want:   false,
+		},
+		{
+			name:   "ended",
+			reward: Reward{RewardSpec: RewardSpec{Status: RewardActive, Stock: 1, EndsAt: &past}},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reward.IsAvailableAt(now); got != tt.want {
+				t.Errorf("Reward.IsAvailableAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
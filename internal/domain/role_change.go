@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// RoleChange is an audit record of a user's role being changed
+type RoleChange struct {
+	ID        string
+	UserID    string
+	OldRole   Role
+	NewRole   Role
+	ChangedBy *string // nil when changed by an internal caller
+	CreatedAt time.Time
+}
+
+// NewRoleChange records a role change (ID will be generated by database)
+func NewRoleChange(userID string, oldRole, newRole Role, changedBy string, now time.Time) *RoleChange {
+	change := &RoleChange{
+		UserID:    userID,
+		OldRole:   oldRole,
+		NewRole:   newRole,
+		CreatedAt: now,
+	}
+
+	if changedBy != "" {
+		change.ChangedBy = &changedBy
+	}
+
+	return change
+}
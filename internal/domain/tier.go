@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// QualifyingPointType is the point type whose earnings decide a user's loyalty tier
+const QualifyingPointType = PointTypeTierPoints
+
+// TierSpec holds the admin-editable settings of a loyalty tier
+type TierSpec struct {
+	Name        string
+	Description string
+	Threshold   int64 // qualifying points a user must earn within the qualifying window
+}
+
+// Tier is a loyalty level users reach by earning qualifying points
+type Tier struct {
+	ID string
+	TierSpec
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TierRef identifies the tier a user holds or held
+type TierRef struct {
+	ID   string // empty when the tier has since been deleted
+	Name string
+}
+
+// NewTier creates a new tier with validation (ID will be generated by database)
+func NewTier(spec TierSpec) (*Tier, error) {
+	now := time.Now()
+	tier := &Tier{
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := tier.Update(spec); err != nil {
+		return nil, err
+	}
+
+	return tier, nil
+}
+
+// Update replaces the tier's settings with validation
+func (t *Tier) Update(spec TierSpec) error {
+	spec.Name = strings.TrimSpace(spec.Name)
+	spec.Description = strings.TrimSpace(spec.Description)
+
+	updated := *t
+	updated.TierSpec = spec
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	t.TierSpec = spec
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Validate performs domain validation on the tier
+func (t *Tier) Validate() error {
+	if t.Name == "" {
+		return ErrInvalidTierName
+	}
+
+	if t.Threshold < 0 {
+		return ErrInvalidTierThreshold
+	}
+
+	return nil
+}
+
+// Ref returns a reference to the tier
+func (t *Tier) Ref() *TierRef {
+	return &TierRef{ID: t.ID, Name: t.Name}
+}
+
+// QualifyingTier returns the highest tier whose threshold points reaches, or nil if none does
+func QualifyingTier(tiers []*Tier, points int64) *Tier {
+	var qualified *Tier
+	for _, tier := range tiers {
+		if tier.Threshold <= points && (qualified == nil || tier.Threshold > qualified.Threshold) {
+			qualified = tier
+		}
+	}
+	return qualified
+}
+
+// TierStanding is a user's current tier together with the qualifying points they earned in the window
+type TierStanding struct {
+	UserID           string
+	TierID           *string // nil when the user holds no tier
+	QualifyingPoints int64
+}
+
+// TierChangeReason records what triggered a tier recalculation
+type TierChangeReason string
+
+// Tier change reasons
+const (
+	TierChangeActivity  TierChangeReason = "activity"  // the user earned qualifying points
+	TierChangeScheduled TierChangeReason = "scheduled" // the periodic recalculation of every user
+)
+
+// TierChange is a history record of a user moving between tiers
+type TierChange struct {
+	ID               string
+	UserID           string
+	FromTier         *TierRef // nil when the user held no tier
+	ToTier           *TierRef // nil when the user no longer qualifies for any tier
+	QualifyingPoints int64
+	Reason           TierChangeReason
+	CreatedAt        time.Time
+}
+
+// NextTierChange computes the move to the tier the standing qualifies for among tiers,
+// returning nil if the user already holds that tier
+func NextTierChange(standing *TierStanding, tiers []*Tier, reason TierChangeReason, now time.Time) *TierChange {
+	next := QualifyingTier(tiers, standing.QualifyingPoints)
+
+	var currentID, nextID string
+	if standing.TierID != nil {
+		currentID = *standing.TierID
+	}
+	if next != nil {
+		nextID = next.ID
+	}
+	if currentID == nextID {
+		return nil
+	}
+
+	change := &TierChange{
+		UserID:           standing.UserID,
+		QualifyingPoints: standing.QualifyingPoints,
+		Reason:           reason,
+		CreatedAt:        now,
+	}
+
+	if standing.TierID != nil {
+		change.FromTier = &TierRef{ID: currentID}
+		for _, tier := range tiers {
+			if tier.ID == currentID {
+				change.FromTier = tier.Ref()
+			}
+		}
+	}
+
+	if next != nil {
+		change.ToTier = next.Ref()
+	}
+
+	return change
+}
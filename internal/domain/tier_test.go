@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTier(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    TierSpec
+		errType error
+	}{
+		{
+			name: "valid tier",
+			spec: TierSpec{Name: " Gold ", Threshold: 5000},
+		},
+		{
+			name: "base tier without threshold",
+			spec: TierSpec{Name: "Bronze"},
+		},
+		{
+			name:    "missing name",
+			spec:    TierSpec{Threshold: 5000},
+			errType: ErrInvalidTierName,
+		},
+		{
+			name:    "negative threshold",
+			spec:    TierSpec{Name: "Gold", Threshold: -1},
+			errType: ErrInvalidTierThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier, err := NewTier(tt.spec)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewTier() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewTier() unexpected error: %v", err)
+			}
+			if tier.Name != strings.TrimSpace(tt.spec.Name) {
+				t.Errorf("NewTier() Name = %q, want it trimmed", tier.Name)
+			}
+		})
+	}
+}
+
+func TestNextTierChange(t *testing.T) {
+	bronze := &Tier{ID: "bronze", TierSpec: TierSpec{Name: "Bronze", Threshold: 0}}
+	silver := &Tier{ID: "silver", TierSpec: TierSpec{Name: "Silver", Threshold: 1000}}
+	gold := &Tier{ID: "gold", TierSpec: TierSpec{Name: "Gold", Threshold: 5000}}
+	tiers := []*Tier{gold, bronze, silver}
+	goldID, silverID := gold.ID, silver.ID
+
+	tests := []struct {
+		name     string
+		tiers    []*Tier
+		standing TierStanding
+		wantFrom string
+		wantTo   string
+		noChange bool
+	}{
+		{
+			name:     "first tier",
+			tiers:    tiers,
+			standing: TierStanding{UserID: "user-1", QualifyingPoints: 10},
+			wantTo:   "Bronze",
+		},
+		{
+			name:     "upgrade on reaching a threshold",
+			tiers:    tiers,
+			standing: TierStanding{UserID: "user-1", TierID: &silverID, QualifyingPoints: 5000},
+			wantFrom: "Silver",
+			wantTo:   "Gold",
+		},
+		{
+			name:     "downgrade as points leave the window",
+			tiers:    tiers,
+			standing: TierStanding{UserID: "user-1", TierID: &goldID, QualifyingPoints: 4999},
+			wantFrom: "Gold",
+			wantTo:   "Silver",
+		},
+		{
+			name:     "unchanged",
+			tiers:    tiers,
+			standing: TierStanding{UserID: "user-1", TierID: &silverID, QualifyingPoints: 4999},
+			noChange: true,
+		},
+		{
+			name:     "no tier qualifies",
+			tiers:    []*Tier{silver, gold},
+			standing: TierStanding{UserID: "user-1", QualifyingPoints: 999},
+			noChange: true,
+		},
+		{
+			name:     "no longer qualifies for any tier",
+			tiers:    []*Tier{silver, gold},
+			standing: TierStanding{UserID: "user-1", TierID: &silverID, QualifyingPoints: 999},
+			wantFrom: "Silver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := NextTierChange(&tt.standing, tt.tiers, TierChangeScheduled, time.Now())
+
+			if tt.noChange {
+				if change != nil {
+					t.Errorf("NextTierChange() = %+v, want no change", change)
+				}
+				return
+			}
+
+			if change == nil {
+				t.Fatal("NextTierChange() = nil, want a change")
+			}
+			if got := tierRefName(change.FromTier); got != tt.wantFrom {
+				t.Errorf("NextTierChange() FromTier = %q, want %q", got, tt.wantFrom)
+			}
+			if got := tierRefName(change.ToTier); got != tt.wantTo {
+				t.Errorf("NextTierChange() ToTier = %q, want %q", got, tt.wantTo)
+			}
+			if change.QualifyingPoints != tt.standing.QualifyingPoints || change.Reason != TierChangeScheduled {
+				t.Errorf("NextTierChange() = %+v, want points %d and reason %v", change, tt.standing.QualifyingPoints, TierChangeScheduled)
+			}
+		})
+	}
+}
+
+// tierRefName returns the name of an optional tier reference
+func tierRefName(ref *TierRef) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transaction is one line of a user's statement: a journal entry as it changed the user's available balance
+type Transaction struct {
+	PostingID    int64 // orders the statement and positions its cursor
+	EntryID      string
+	Kind         EntryKind
+	PointType    PointType
+	Source       string // what produced the entry, see EntrySource
+	Description  string
+	Amount       int64 // positive when points came in, negative when they went out
+	BalanceAfter int64 // the available balance right after the entry
+	CreatedAt    time.Time
+}
+
+// EntrySource returns what produced an entry, the prefix of its reference such as "earn_rule",
+// "referral", "redemption", "adjustment", "reversal" or "credit_lot"; entries without a reference
+// have no source
+func EntrySource(reference *string) string {
+	if reference == nil {
+		return ""
+	}
+	source, _, _ := strings.Cut(*reference, ":")
+	return source
+}
+
+// TransactionFilter narrows a user's statement; zero fields match every transaction
+type TransactionFilter struct {
+	PointType PointType   // statement of this point type; defaults to credits
+	Kinds     []EntryKind // any of these entry kinds
+	Source    string      // produced by this source
+	From      *time.Time  // created at or after
+	To        *time.Time  // created before
+	MinAmount *int64      // moving at least this many points, in or out
+	MaxAmount *int64      // moving at most this many points, in or out
+}
+
+// Validate normalizes the filter and checks that its kinds exist and its ranges are not empty
+func (f *TransactionFilter) Validate() error {
+	if f.PointType == "" {
+		f.PointType = PointTypeCredits
+	}
+	if !f.PointType.IsValid() {
+		return ErrInvalidPointType
+	}
+
+	for _, kind := range f.Kinds {
+		if !kind.IsValid() {
+			return ErrInvalidEntryKind
+		}
+	}
+
+	f.Source = strings.TrimSpace(f.Source)
+
+	if f.From != nil && f.To != nil && !f.To.After(*f.From) {
+		return ErrInvalidTransactionFilter
+	}
+
+	if (f.MinAmount != nil && *f.MinAmount < 0) || (f.MaxAmount != nil && *f.MaxAmount < 0) {
+		return ErrInvalidTransactionFilter
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return ErrInvalidTransactionFilter
+	}
+
+	return nil
+}
+
+// TransactionPage is a page of a user's statement, newest first, with the cursor of the next page
+type TransactionPage struct {
+	Transactions []*Transaction
+	NextCursor   string // empty on the last page
+}
+
+// EncodeTransactionCursor returns the opaque cursor of the page following the given transaction
+func EncodeTransactionCursor(transaction *Transaction) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(transaction.PostingID, 10)))
+}
+
+// DecodeTransactionCursor returns the posting ID a cursor continues after; an empty cursor starts
+// at the newest transaction and decodes to zero
+func DecodeTransactionCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	postingID, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || postingID <= 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return postingID, nil
+}
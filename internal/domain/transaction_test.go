@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransactionCursor(t *testing.T) {
+	cursor := EncodeTransactionCursor(&Transaction{PostingID: 42})
+
+	postingID, err := DecodeTransactionCursor(cursor)
+	if err != nil || postingID != 42 {
+		t.Errorf("DecodeTransactionCursor(%q) = %d, %v; want 42, nil", cursor, postingID, err)
+	}
+
+	if postingID, err := DecodeTransactionCursor(""); err != nil || postingID != 0 {
+		t.Errorf("DecodeTransactionCursor(\"\") = %d, %v; want 0, nil", postingID, err)
+	}
+
+	for _, invalid := range []string{"%%%", EncodeTransactionCursor(&Transaction{PostingID: -1}), "YWJj"} {
+		if _, err := DecodeTransactionCursor(invalid); !containsTargetError(err, ErrInvalidCursor) {
+			t.Errorf("DecodeTransactionCursor(%q) expected error %v, got %v", invalid, ErrInvalidCursor, err)
+		}
+	}
+}
+
+func TestTransactionFilter_Validate(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	small, large := int64(10), int64(100)
+
+	tests := []struct {
+		name    string
+		filter  TransactionFilter
+		errType error
+	}{
+		{"no filter", TransactionFilter{}, nil},
+		{"every filter", TransactionFilter{PointType: PointTypeTierPoints, Kinds: []EntryKind{EntryKindEarn, EntryKindReversal}, Source: "earn_rule", From: &earlier, To: &now, MinAmount: &small, MaxAmount: &large}, nil},
+		{"unknown point type", TransactionFilter{PointType: "miles"}, ErrInvalidPointType},
+		{"unknown type", TransactionFilter{Kinds: []EntryKind{"refund"}}, ErrInvalidEntryKind},
+		{"date range ends before it starts", TransactionFilter{From: &now, To: &earlier}, ErrInvalidTransactionFilter},
+		{"amount range ends before it starts", TransactionFilter{MinAmount: &large, MaxAmount: &small}, ErrInvalidTransactionFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("Validate() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if tt.filter.PointType == "" {
+				t.Errorf("Validate() left the point type empty")
+			}
+		})
+	}
+}
+
+func TestEntrySource(t *testing.T) {
+	reference := "earn_rule:rule-1:order-42"
+	if source := EntrySource(&reference); source != "earn_rule" {
+		t.Errorf("EntrySource(%q) = %q, want earn_rule", reference, source)
+	}
+	if source := EntrySource(nil); source != "" {
+		t.Errorf("EntrySource(nil) = %q, want empty", source)
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTransferNoteLength is the maximum number of characters in a transfer note
+const MaxTransferNoteLength = 280
+
+// Transfer records points a user gifted to another user
+type Transfer struct {
+	ID          string
+	SenderID    string
+	RecipientID string
+	PointType   PointType
+	Amount      int64
+	Note        string
+	EntryID     string
+	CreatedAt   time.Time
+}
+
+// TransferLimits caps how many points a user may send
+type TransferLimits struct {
+	MaxAmount  int64 // per transfer
+	DailyLimit int64 // across the transfers of one point type a user sent in the last 24 hours
+}
+
+// CanTransferCredits reports whether the user may send or receive credit transfers
+func (u *User) CanTransferCredits() bool {
+	return u.IsActive && u.IsEmailVerified
+}
+
+// NewTransfer creates a transfer between two eligible users with validation (ID will be generated by database)
+func NewTransfer(sender, recipient *User, pointType PointType, amount int64, note string, limits TransferLimits) (*Transfer, error) {
+	if !pointType.IsValid() {
+		return nil, ErrInvalidPointType
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if limits.MaxAmount > 0 && amount > limits.MaxAmount {
+		return nil, ErrTransferLimitExceeded
+	}
+
+	if sender.ID == recipient.ID {
+		return nil, ErrSelfTransfer
+	}
+
+	if !sender.CanTransferCredits() {
+		return nil, ErrTransferSenderIneligible
+	}
+
+	if !recipient.CanTransferCredits() {
+		return nil, ErrTransferRecipientIneligible
+	}
+
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxTransferNoteLength {
+		return nil, ErrInvalidTransferNote
+	}
+
+	return &Transfer{
+		SenderID:    sender.ID,
+		RecipientID: recipient.ID,
+		PointType:   pointType,
+		Amount:      amount,
+		Note:        note,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// DailyWindowStart returns the start of the 24 hours counted towards the sender's daily limit
+func (t *Transfer) DailyWindowStart() time.Time {
+	return t.CreatedAt.Add(-24 * time.Hour)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewTransfer(t *testing.T) {
+	sender := &User{ID: "sender", IsActive: true, IsEmailVerified: true}
+	recipient := &User{ID: "recipient", IsActive: true, IsEmailVerified: true}
+	inactive := &User{ID: "inactive", IsEmailVerified: true}
+	unverified := &User{ID: "unverified", IsActive: true}
+	limits := TransferLimits{MaxAmount: 100, DailyLimit: 500}
+
+	tests := []struct {
+		name      string
+		sender    *User
+		recipient *User
+		amount    int64
+		note      string
+		errType   error
+	}{
+		{name: "valid transfer", sender: sender, recipient: recipient, amount: 100, note: " Thanks! "},
+		{name: "non-positive amount", sender: sender, recipient: recipient, amount: 0, errType: ErrInvalidAmount},
+		{name: "over per-transfer limit", sender: sender, recipient: recipient, amount: 101, errType: ErrTransferLimitExceeded},
+		{name: "to self", sender: sender, recipient: sender, amount: 10, errType: ErrSelfTransfer},
+		{name: "inactive sender", sender: inactive, recipient: recipient, amount: 10, errType: ErrTransferSenderIneligible},
+		{name: "unverified recipient", sender: sender, recipient: unverified, amount: 10, errType: ErrTransferRecipientIneligible},
+		{name: "note too long", sender: sender, recipient: recipient, amount: 10, note: strings.Repeat("a", MaxTransferNoteLength+1), errType: ErrInvalidTransferNote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer, err := NewTransfer(tt.sender, tt.recipient, PointTypeCredits, tt.amount, tt.note, limits)
+
+			if tt.errType != nil {
+				if !containsTargetError(err, tt.errType) {
+					t.Errorf("NewTransfer() expected error %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewTransfer() unexpected error: %v", err)
+			}
+
+			if transfer.Note != "Thanks!" || transfer.SenderID != "sender" || transfer.RecipientID != "recipient" {
+				t.Errorf("NewTransfer() = %+v, want trimmed note between sender and recipient", transfer)
+			}
+		})
+	}
+}
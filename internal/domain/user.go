@@ -3,28 +3,46 @@ package domain
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 )
 
 type Role string
 
+// OTPLength is the number of digits in a one-time password
+const OTPLength = 6
+
 const (
 	RoleAdmin Role = "admin"
 	RoleUser  Role = "user"
 )
 
+// IsValid reports whether the role is one the system knows about
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleUser
+}
+
 // User represents a user entity in the domain
 type User struct {
-	ID              string
-	Name            string
-	Email           string
-	IsEmailVerified bool
-	IsActive        bool
-	OTP             *string
-	OTPExpiresAt    *time.Time
-	Role            Role
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID                 string
+	Name               string
+	Email              string
+	IsEmailVerified    bool
+	IsActive           bool
+	OTPHash            *string
+	OTPExpiresAt       *time.Time
+	OTPAttempts        int
+	OTPLockedUntil     *time.Time
+	OTPLastSentAt      *time.Time
+	DeactivatedAt      *time.Time
+	DeactivatedBy      *string
+	DeactivationReason *string
+	Role               Role
+	Tier               *TierRef // current loyalty tier; nil until the user qualifies for one
+	ReferralCode       string   // code other users sign up with to be referred by this user
+	ReferredBy         *string  // ID of the user whose referral code this user signed up with
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 // NewUser creates a new user with validation (ID will be generated by database)
@@ -32,6 +50,7 @@ func NewUser(email, name string) (*User, error) {
 	user := &User{
 		Email:     email,
 		Name:      name,
+		IsActive:  true,
 		Role:      RoleUser, // Default role
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -45,7 +64,7 @@ func NewUser(email, name string) (*User, error) {
 }
 
 // NewUserWithID creates a user with an existing ID (for loading from database)
-func NewUserWithID(id, email, name string, role Role, isEmailVerified, isActive bool, otp *string, otpExpiresAt *time.Time, createdAt, updatedAt time.Time) (*User, error) {
+func NewUserWithID(id, email, name string, role Role, isEmailVerified, isActive bool, otpHash *string, otpExpiresAt *time.Time, createdAt, updatedAt time.Time) (*User, error) {
 	user := &User{
 		ID:              id,
 		Email:           email,
@@ -53,7 +72,7 @@ func NewUserWithID(id, email, name string, role Role, isEmailVerified, isActive
 		Role:            role,
 		IsEmailVerified: isEmailVerified,
 		IsActive:        isActive,
-		OTP:             otp,
+		OTPHash:         otpHash,
 		OTPExpiresAt:    otpExpiresAt,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
@@ -94,13 +113,18 @@ func (u *User) ValidateForCreation() error {
 
 // IsValidEmail validates the email format
 func (u *User) IsValidEmail() bool {
-	if u.Email == "" {
+	return isValidEmail(u.Email)
+}
+
+// isValidEmail validates an email address format
+func isValidEmail(email string) bool {
+	if email == "" {
 		return false
 	}
 
 	// Basic email regex
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	return emailRegex.MatchString(u.Email)
+	return emailRegex.MatchString(email)
 }
 
 // UpdateEmail updates the user's email with validation
@@ -117,6 +141,33 @@ func (u *User) UpdateEmail(email string) error {
 	return nil
 }
 
+// ApplyEmailChange switches the user to an email address whose ownership has been proven
+func (u *User) ApplyEmailChange(email string, now time.Time) error {
+	if !isValidEmail(email) {
+		return ErrInvalidUserEmail
+	}
+
+	u.Email = email
+	u.IsEmailVerified = true
+	u.UpdatedAt = now
+	return nil
+}
+
+// ChangeRole moves the user to a different role
+func (u *User) ChangeRole(role Role, now time.Time) error {
+	if !role.IsValid() {
+		return ErrInvalidUserRole
+	}
+
+	if role == u.Role {
+		return ErrRoleUnchanged
+	}
+
+	u.Role = role
+	u.UpdatedAt = now
+	return nil
+}
+
 // UpdateName updates the user's name with validation
 func (u *User) UpdateName(name string) error {
 	if name == "" {
@@ -127,3 +178,123 @@ func (u *User) UpdateName(name string) error {
 	u.UpdatedAt = time.Now()
 	return nil
 }
+
+// EnsureActive checks that the account has not been deactivated
+func (u *User) EnsureActive() error {
+	if !u.IsActive {
+		return ErrUserInactive
+	}
+	return nil
+}
+
+// Deactivate disables the account, recording why and by whom
+func (u *User) Deactivate(reason, deactivatedBy string, now time.Time) error {
+	if !u.IsActive {
+		return ErrUserAlreadyInactive
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrInvalidDeactivationReason
+	}
+
+	u.IsActive = false
+	u.DeactivatedAt = &now
+	u.DeactivationReason = &reason
+	u.DeactivatedBy = nil
+	if deactivatedBy != "" {
+		u.DeactivatedBy = &deactivatedBy
+	}
+	u.UpdatedAt = now
+	return nil
+}
+
+// Reactivate re-enables a deactivated account and clears the deactivation details
+func (u *User) Reactivate(now time.Time) error {
+	if u.IsActive {
+		return ErrUserAlreadyActive
+	}
+
+	u.IsActive = true
+	u.DeactivatedAt = nil
+	u.DeactivatedBy = nil
+	u.DeactivationReason = nil
+	u.UpdatedAt = now
+	return nil
+}
+
+// CanRequestOTP checks whether a new one-time password may be sent to the user
+func (u *User) CanRequestOTP(now time.Time, resendCooldown time.Duration) error {
+	if u.IsOTPLocked(now) {
+		return ErrOTPLocked
+	}
+
+	if u.OTPLastSentAt != nil && now.Before(u.OTPLastSentAt.Add(resendCooldown)) {
+		return ErrOTPResendCooldown
+	}
+
+	return nil
+}
+
+// SetOTP stores the hash of a newly sent one-time password and resets the attempt counter
+func (u *User) SetOTP(otpHash string, expiresAt, now time.Time) error {
+	if otpHash == "" {
+		return ErrInvalidOTP
+	}
+
+	if !expiresAt.After(now) {
+		return ErrInvalidOTPExpiresAt
+	}
+
+	u.OTPHash = &otpHash
+	u.OTPExpiresAt = &expiresAt
+	u.OTPAttempts = 0
+	u.OTPLastSentAt = &now
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// EnsureOTPUsable checks that a one-time password is pending, unexpired and not locked out
+func (u *User) EnsureOTPUsable(now time.Time) error {
+	if u.IsOTPLocked(now) {
+		return ErrOTPLocked
+	}
+
+	if u.OTPHash == nil || u.OTPExpiresAt == nil {
+		return ErrInvalidOTP
+	}
+
+	if !now.Before(*u.OTPExpiresAt) {
+		return ErrInvalidOTPExpiresAt
+	}
+
+	return nil
+}
+
+// IsOTPLocked reports whether OTP verification is locked at the given time
+func (u *User) IsOTPLocked(now time.Time) bool {
+	return u.OTPLockedUntil != nil && now.Before(*u.OTPLockedUntil)
+}
+
+// LockOTP discards the pending one-time password and blocks new ones until the given time
+func (u *User) LockOTP(until time.Time) {
+	u.OTPHash = nil
+	u.OTPExpiresAt = nil
+	u.OTPLockedUntil = &until
+	u.UpdatedAt = time.Now()
+}
+
+// ClearOTP removes the stored one-time password and resets attempt limiting
+func (u *User) ClearOTP() {
+	u.OTPHash = nil
+	u.OTPExpiresAt = nil
+	u.OTPAttempts = 0
+	u.OTPLockedUntil = nil
+	u.UpdatedAt = time.Now()
+}
+
+// MarkEmailVerified flags the user's email address as verified
+func (u *User) MarkEmailVerified() {
+	u.IsEmailVerified = true
+	u.UpdatedAt = time.Now()
+}
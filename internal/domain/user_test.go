@@ -82,6 +82,10 @@ func TestNewUser(t *testing.T) {
 				t.Errorf("NewUser() Name = %v, want %v", user.Name, tt.userName)
 			}
 
+			if !user.IsActive {
+				t.Errorf("NewUser() IsActive = false, want true")
+			}
+
 			// Check timestamps
 			if user.CreatedAt.IsZero() {
 				t.Errorf("NewUser() CreatedAt should not be zero")
@@ -312,6 +316,111 @@ func TestUser_UpdateName(t *testing.T) {
 	}
 }
 
+func TestUser_EnsureOTPUsable(t *testing.T) {
+	now := time.Now()
+	hash := "salt$mac"
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		user    *User
+		errType error
+	}{
+		{
+			name: "pending code",
+			user: &User{OTPHash: &hash, OTPExpiresAt: &future},
+		},
+		{
+			name:    "no code requested",
+			user:    &User{},
+			errType: ErrInvalidOTP,
+		},
+		{
+			name:    "expired code",
+			user:    &User{OTPHash: &hash, OTPExpiresAt: &past},
+			errType: ErrInvalidOTPExpiresAt,
+		},
+		{
+			name:    "locked out",
+			user:    &User{OTPHash: &hash, OTPExpiresAt: &future, OTPLockedUntil: &future},
+			errType: ErrOTPLocked,
+		},
+		{
+			name: "lockout elapsed",
+			user: &User{OTPHash: &hash, OTPExpiresAt: &future, OTPLockedUntil: &past},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.user.EnsureOTPUsable(now); err != tt.errType {
+				t.Errorf("EnsureOTPUsable() error = %v, want %v", err, tt.errType)
+			}
+		})
+	}
+}
+
+func TestUser_CanRequestOTP(t *testing.T) {
+	now := time.Now()
+	user := &User{}
+
+	if err := user.CanRequestOTP(now, time.Minute); err != nil {
+		t.Errorf("CanRequestOTP() first request unexpected error: %v", err)
+	}
+
+	if err := user.SetOTP("salt$mac", now.Add(10*time.Minute), now); err != nil {
+		t.Fatalf("SetOTP() unexpected error: %v", err)
+	}
+
+	if err := user.CanRequestOTP(now.Add(30*time.Second), time.Minute); err != ErrOTPResendCooldown {
+		t.Errorf("CanRequestOTP() within cooldown error = %v, want %v", err, ErrOTPResendCooldown)
+	}
+
+	if err := user.CanRequestOTP(now.Add(2*time.Minute), time.Minute); err != nil {
+		t.Errorf("CanRequestOTP() after cooldown unexpected error: %v", err)
+	}
+
+	user.LockOTP(now.Add(time.Hour))
+	if user.OTPHash != nil {
+		t.Errorf("LockOTP() should discard the pending code")
+	}
+
+	if err := user.CanRequestOTP(now.Add(2*time.Minute), time.Minute); err != ErrOTPLocked {
+		t.Errorf("CanRequestOTP() while locked error = %v, want %v", err, ErrOTPLocked)
+	}
+}
+
+func TestUser_ChangeRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  Role
+		newRole  Role
+		wantRole Role
+		errType  error
+	}{
+		{name: "promote to admin", current: RoleUser, newRole: RoleAdmin, wantRole: RoleAdmin},
+		{name: "demote to user", current: RoleAdmin, newRole: RoleUser, wantRole: RoleUser},
+		{name: "same role", current: RoleUser, newRole: RoleUser, wantRole: RoleUser, errType: ErrRoleUnchanged},
+		{name: "unknown role", current: RoleUser, newRole: Role("superuser"), wantRole: RoleUser, errType: ErrInvalidUserRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Role: tt.current}
+
+			err := user.ChangeRole(tt.newRole, time.Now())
+			if err != tt.errType {
+				t.Errorf("ChangeRole() error = %v, want %v", err, tt.errType)
+			}
+
+			if user.Role != tt.wantRole {
+				t.Errorf("ChangeRole() Role = %v, want %v", user.Role, tt.wantRole)
+			}
+		})
+	}
+}
+
 // Helper function to check if an error contains a specific target error
 func containsTargetError(err, target error) bool {
 	return errors.Is(err, target)
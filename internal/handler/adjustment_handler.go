@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// AdjustmentService interface defines what the handler needs from the adjustment service
+type AdjustmentService interface {
+	RequestAdjustment(ctx context.Context, userID string, pointType domain.PointType, direction domain.AdjustmentDirection, amount int64, reason domain.AdjustmentReason, note string) (*domain.Adjustment, error)
+	GetAdjustment(ctx context.Context, id string) (*domain.Adjustment, error)
+	ListAdjustments(ctx context.Context, status domain.AdjustmentStatus, limit, offset int) ([]*domain.Adjustment, error)
+	ApproveAdjustment(ctx context.Context, id, note string) (*domain.Adjustment, error)
+	RejectAdjustment(ctx context.Context, id, note string) (*domain.Adjustment, error)
+}
+
+// AdjustmentHandler handles HTTP requests for manual point adjustments
+type AdjustmentHandler struct {
+	adjustmentService AdjustmentService
+}
+
+// NewAdjustmentHandler creates a new adjustment handler
+func NewAdjustmentHandler(adjustmentService AdjustmentService) *AdjustmentHandler {
+	return &AdjustmentHandler{
+		adjustmentService: adjustmentService,
+	}
+}
+
+// CreateAdjustmentRequest represents the request body for requesting an adjustment
+type CreateAdjustmentRequest struct {
+	UserID    string `json:"user_id"`
+	PointType string `json:"point_type,omitempty"` // defaults to credits
+	Direction string `json:"direction"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason"`
+	Note      string `json:"note,omitempty"`
+}
+
+// ReviewAdjustmentRequest represents the optional request body for approving or rejecting an adjustment
+type ReviewAdjustmentRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// AdjustmentResponse represents the response body for adjustment operations
+type AdjustmentResponse struct {
+	ID          string  `json:"id"`
+	UserID      string  `json:"user_id"`
+	PointType   string  `json:"point_type"`
+	Direction   string  `json:"direction"`
+	Amount      int64   `json:"amount"`
+	Reason      string  `json:"reason"`
+	Note        string  `json:"note"`
+	Status      string  `json:"status"`
+	RequestedBy string  `json:"requested_by"`
+	ReviewedBy  *string `json:"reviewed_by,omitempty"`
+	ReviewNote  string  `json:"review_note,omitempty"`
+	EntryID     *string `json:"entry_id,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	ReviewedAt  *string `json:"reviewed_at,omitempty"`
+}
+
+// CreateAdjustment handles POST /adjustments
+func (h *AdjustmentHandler) CreateAdjustment(c *gin.Context) {
+	var req CreateAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.UserID == "" || req.Direction == "" || req.Reason == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "user_id, direction and reason are required")
+		return
+	}
+
+	adjustment, err := h.adjustmentService.RequestAdjustment(c.Request.Context(), req.UserID, domain.PointType(req.PointType),
+		domain.AdjustmentDirection(req.Direction), req.Amount, domain.AdjustmentReason(req.Reason), req.Note)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to request adjustment", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, adjustmentToResponse(adjustment))
+}
+
+// GetAdjustment handles GET /adjustments/{id}
+func (h *AdjustmentHandler) GetAdjustment(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing adjustment ID", "")
+		return
+	}
+
+	adjustment, err := h.adjustmentService.GetAdjustment(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get adjustment", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustmentToResponse(adjustment))
+}
+
+// ListAdjustments handles GET /adjustments, optionally filtered with ?status=pending
+func (h *AdjustmentHandler) ListAdjustments(c *gin.Context) {
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	status := domain.AdjustmentStatus(c.Query("status"))
+
+	adjustments, err := h.adjustmentService.ListAdjustments(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list adjustments", err.Error())
+		return
+	}
+
+	responses := make([]AdjustmentResponse, len(adjustments))
+	for i, adjustment := range adjustments {
+		responses[i] = adjustmentToResponse(adjustment)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// ApproveAdjustment handles POST /adjustments/{id}/approve
+func (h *AdjustmentHandler) ApproveAdjustment(c *gin.Context) {
+	h.review(c, h.adjustmentService.ApproveAdjustment, "Failed to approve adjustment")
+}
+
+// RejectAdjustment handles POST /adjustments/{id}/reject
+func (h *AdjustmentHandler) RejectAdjustment(c *gin.Context) {
+	h.review(c, h.adjustmentService.RejectAdjustment, "Failed to reject adjustment")
+}
+
+// review binds the optional review note and responds with the adjustment fn reviewed
+func (h *AdjustmentHandler) review(c *gin.Context, fn func(ctx context.Context, id, note string) (*domain.Adjustment, error), errTitle string) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing adjustment ID", "")
+		return
+	}
+
+	// The body is optional
+	var req ReviewAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	adjustment, err := fn(c.Request.Context(), id, req.Note)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, errTitle, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustmentToResponse(adjustment))
+}
+
+// adjustmentToResponse converts a domain adjustment to response format
+func adjustmentToResponse(adjustment *domain.Adjustment) AdjustmentResponse {
+	return AdjustmentResponse{
+		ID:          adjustment.ID,
+		UserID:      adjustment.UserID,
+		PointType:   string(adjustment.PointType),
+		Direction:   string(adjustment.Direction),
+		Amount:      adjustment.Amount,
+		Reason:      string(adjustment.Reason),
+		Note:        adjustment.Note,
+		Status:      string(adjustment.Status),
+		RequestedBy: adjustment.RequestedBy,
+		ReviewedBy:  adjustment.ReviewedBy,
+		ReviewNote:  adjustment.ReviewNote,
+		EntryID:     adjustment.EntryID,
+		CreatedAt:   adjustment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ReviewedAt:  formatOptionalTime(adjustment.ReviewedAt),
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// APIKeyService interface defines what the handler needs from the API key service
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, name, ownerUserID string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, limit, offset int) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// APIKeyHandler handles HTTP requests for API key management
+type APIKeyHandler struct {
+	apiKeyService APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name"`
+	Scopes      []string   `json:"scopes"`
+	OwnerUserID string     `json:"owner_user_id,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse represents the response body for API key operations
+type APIKeyResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Prefix      string   `json:"prefix"`
+	Scopes      []string `json:"scopes"`
+	OwnerUserID string   `json:"owner_user_id"`
+	ExpiresAt   *string  `json:"expires_at,omitempty"`
+	RevokedAt   *string  `json:"revoked_at,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents the response body for a newly created API key
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// CreateAPIKey handles POST /api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Name == "" || len(req.Scopes) == 0 {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "name and scopes are required")
+		return
+	}
+
+	key, plaintext, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), req.Name, req.OwnerUserID, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create API key", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		APIKeyResponse: apiKeyToResponse(key),
+		Key:            plaintext,
+	})
+}
+
+// ListAPIKeys handles GET /api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list API keys", err.Error())
+		return
+	}
+
+	responses := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = apiKeyToResponse(key)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// RevokeAPIKey handles DELETE /api-keys/{id}
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing API key ID", "")
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to revoke API key", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// apiKeyToResponse converts a domain API key to response format
+func apiKeyToResponse(key *domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Scopes:      key.Scopes,
+		OwnerUserID: key.OwnerUserID,
+		ExpiresAt:   formatOptionalTime(key.ExpiresAt),
+		RevokedAt:   formatOptionalTime(key.RevokedAt),
+		CreatedAt:   key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
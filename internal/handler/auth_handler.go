@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// OTPService interface defines the one-time password operations the auth handler needs
+type OTPService interface {
+	RequestOTP(ctx context.Context, email string) error
+	VerifyOTP(ctx context.Context, email, code string) (*domain.User, error)
+}
+
+// TokenService interface defines the token operations the auth handler needs
+type TokenService interface {
+	IssueTokens(ctx context.Context, user *domain.User) (*domain.TokenPair, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*domain.TokenPair, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+}
+
+// AuthHandler handles HTTP requests for authentication
+type AuthHandler struct {
+	otpService   OTPService
+	tokenService TokenService
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(otpService OTPService, tokenService TokenService) *AuthHandler {
+	return &AuthHandler{
+		otpService:   otpService,
+		tokenService: tokenService,
+	}
+}
+
+// RequestOTPRequest represents the request body for requesting an OTP
+type RequestOTPRequest struct {
+	Email string `json:"email"`
+}
+
+// VerifyOTPRequest represents the request body for verifying an OTP
+type VerifyOTPRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+// RefreshTokenRequest represents the request body for refreshing or revoking tokens
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse represents the response body for issued tokens
+type TokenResponse struct {
+	AccessToken           string `json:"access_token"`
+	AccessTokenExpiresAt  string `json:"access_token_expires_at"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at"`
+	TokenType             string `json:"token_type"`
+}
+
+// LoginResponse represents the response body for a successful login
+type LoginResponse struct {
+	User   UserResponse  `json:"user"`
+	Tokens TokenResponse `json:"tokens"`
+}
+
+// RequestOTP handles POST /auth/otp/request
+func (h *AuthHandler) RequestOTP(c *gin.Context) {
+	var req RequestOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Email == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "email is required")
+		return
+	}
+
+	if err := h.otpService.RequestOTP(c.Request.Context(), req.Email); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to request OTP", err.Error())
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyOTP handles POST /auth/otp/verify
+func (h *AuthHandler) VerifyOTP(c *gin.Context) {
+	var req VerifyOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Email == "" || req.Code == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "email and code are required")
+		return
+	}
+
+	user, err := h.otpService.VerifyOTP(c.Request.Context(), req.Email, req.Code)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to verify OTP", err.Error())
+		return
+	}
+
+	tokens, err := h.tokenService.IssueTokens(c.Request.Context(), user)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to issue tokens", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, LoginResponse{
+		User:   userToResponse(user),
+		Tokens: tokensToResponse(tokens),
+	})
+}
+
+// RefreshTokens handles POST /auth/token/refresh
+func (h *AuthHandler) RefreshTokens(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.RefreshToken == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "refresh_token is required")
+		return
+	}
+
+	tokens, err := h.tokenService.RefreshTokens(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to refresh tokens", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tokensToResponse(tokens))
+}
+
+// Logout handles POST /auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.RefreshToken == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "refresh_token is required")
+		return
+	}
+
+	if err := h.tokenService.RevokeRefreshToken(c.Request.Context(), req.RefreshToken); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to logout", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// tokensToResponse converts a domain token pair to response format
+func tokensToResponse(tokens *domain.TokenPair) TokenResponse {
+	return TokenResponse{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		TokenType:             "Bearer",
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// CampaignService interface defines what the handler needs from the campaign service
+type CampaignService interface {
+	CreateCampaign(ctx context.Context, spec domain.CampaignSpec) (*domain.Campaign, error)
+	GetCampaign(ctx context.Context, id string) (*domain.Campaign, error)
+	UpdateCampaign(ctx context.Context, id string, spec domain.CampaignSpec) (*domain.Campaign, error)
+	ListCampaigns(ctx context.Context, limit, offset int) ([]*domain.Campaign, error)
+}
+
+// CampaignHandler handles HTTP requests for promotional campaigns
+type CampaignHandler struct {
+	campaignService CampaignService
+}
+
+// NewCampaignHandler creates a new campaign handler
+func NewCampaignHandler(campaignService CampaignService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+// CampaignRequest represents the request body for creating or replacing a campaign
+type CampaignRequest struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	StartsAt    time.Time               `json:"starts_at"`
+	EndsAt      time.Time               `json:"ends_at"`
+	Audience    domain.CampaignAudience `json:"audience"`
+	Multiplier  int64                   `json:"multiplier,omitempty"` // basis points, e.g. 20000 doubles awards
+	Bonus       int64                   `json:"bonus,omitempty"`
+	Budget      int64                   `json:"budget"`
+	IsActive    *bool                   `json:"is_active,omitempty"`
+}
+
+// CampaignResponse represents the response body for campaign operations
+type CampaignResponse struct {
+	ID              string                  `json:"id"`
+	Name            string                  `json:"name"`
+	Description     string                  `json:"description"`
+	StartsAt        string                  `json:"starts_at"`
+	EndsAt          string                  `json:"ends_at"`
+	Audience        domain.CampaignAudience `json:"audience"`
+	Multiplier      int64                   `json:"multiplier"`
+	Bonus           int64                   `json:"bonus"`
+	Budget          int64                   `json:"budget"`
+	Spent           int64                   `json:"spent"`
+	RemainingBudget int64                   `json:"remaining_budget"`
+	IsActive        bool                    `json:"is_active"`
+	CreatedAt       string                  `json:"created_at"`
+	UpdatedAt       string                  `json:"updated_at"`
+}
+
+// CreateCampaign handles POST /campaigns
+func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
+	var req CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(c.Request.Context(), req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create campaign", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, campaignToResponse(campaign))
+}
+
+// GetCampaign handles GET /campaigns/{id}
+func (h *CampaignHandler) GetCampaign(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing campaign ID", "")
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get campaign", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, campaignToResponse(campaign))
+}
+
+// UpdateCampaign handles PUT /campaigns/{id}
+func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing campaign ID", "")
+		return
+	}
+
+	var req CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	campaign, err := h.campaignService.UpdateCampaign(c.Request.Context(), id, req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to update campaign", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, campaignToResponse(campaign))
+}
+
+// ListCampaigns handles GET /campaigns
+func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	campaigns, err := h.campaignService.ListCampaigns(c.Request.Context(), limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list campaigns", err.Error())
+		return
+	}
+
+	responses := make([]CampaignResponse, len(campaigns))
+	for i, campaign := range campaigns {
+		responses[i] = campaignToResponse(campaign)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// toSpec converts the request to campaign settings; campaigns are active unless disabled explicitly
+func (req *CampaignRequest) toSpec() domain.CampaignSpec {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return domain.CampaignSpec{
+		Name:        req.Name,
+		Description: req.Description,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Audience:    req.Audience,
+		Multiplier:  req.Multiplier,
+		Bonus:       req.Bonus,
+		Budget:      req.Budget,
+		IsActive:    isActive,
+	}
+}
+
+// campaignToResponse converts a domain campaign to response format
+func campaignToResponse(campaign *domain.Campaign) CampaignResponse {
+	return CampaignResponse{
+		ID:              campaign.ID,
+		Name:            campaign.Name,
+		Description:     campaign.Description,
+		StartsAt:        campaign.StartsAt.Format("2006-01-02T15:04:05Z07:00"),
+		EndsAt:          campaign.EndsAt.Format("2006-01-02T15:04:05Z07:00"),
+		Audience:        campaign.Audience,
+		Multiplier:      campaign.Multiplier,
+		Bonus:           campaign.Bonus,
+		Budget:          campaign.Budget,
+		Spent:           campaign.Spent,
+		RemainingBudget: campaign.RemainingBudget(),
+		IsActive:        campaign.IsActive,
+		CreatedAt:       campaign.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       campaign.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// EarnService interface defines what the handler needs from the earn service
+type EarnService interface {
+	CreateRule(ctx context.Context, spec domain.EarnRuleSpec) (*domain.EarnRule, error)
+	GetRule(ctx context.Context, id string) (*domain.EarnRule, error)
+	UpdateRule(ctx context.Context, id string, spec domain.EarnRuleSpec) (*domain.EarnRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	ListRules(ctx context.Context, limit, offset int) ([]*domain.EarnRule, error)
+	Evaluate(ctx context.Context, event *domain.EarnEvent) ([]*domain.EarnAward, error)
+	ProcessEvent(ctx context.Context, event *domain.EarnEvent) ([]*domain.EarnAward, error)
+}
+
+// EarnRuleHandler handles HTTP requests for earn rules and the events they award credits for
+type EarnRuleHandler struct {
+	earnService EarnService
+}
+
+// NewEarnRuleHandler creates a new earn rule handler
+func NewEarnRuleHandler(earnService EarnService) *EarnRuleHandler {
+	return &EarnRuleHandler{
+		earnService: earnService,
+	}
+}
+
+// EarnRuleRequest represents the request body for creating or replacing an earn rule
+type EarnRuleRequest struct {
+	Name             string                 `json:"name"`
+	EventType        string                 `json:"event_type"`
+	Conditions       []domain.RuleCondition `json:"conditions"`
+	AwardType        string                 `json:"award_type"`
+	Amount           int64                  `json:"amount"`
+	PointType        string                 `json:"point_type,omitempty"` // defaults to credits
+	BaseAttribute    string                 `json:"base_attribute,omitempty"`
+	MaxAward         *int64                 `json:"max_award,omitempty"`
+	MaxAwardsPerUser *int                   `json:"max_awards_per_user,omitempty"`
+	StartsAt         *time.Time             `json:"starts_at,omitempty"`
+	EndsAt           *time.Time             `json:"ends_at,omitempty"`
+	IsActive         *bool                  `json:"is_active,omitempty"`
+}
+
+// EarnEventRequest represents the request body for reporting an earn event
+type EarnEventRequest struct {
+	EventType  string         `json:"event_type"`
+	UserID     string         `json:"user_id"`
+	Reference  string         `json:"reference,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	OccurredAt *time.Time     `json:"occurred_at,omitempty"`
+}
+
+// EarnRuleResponse represents the response body for earn rule operations
+type EarnRuleResponse struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	EventType        string                 `json:"event_type"`
+	Conditions       []domain.RuleCondition `json:"conditions"`
+	AwardType        string                 `json:"award_type"`
+	Amount           int64                  `json:"amount"`
+	PointType        string                 `json:"point_type"`
+	BaseAttribute    string                 `json:"base_attribute,omitempty"`
+	MaxAward         *int64                 `json:"max_award,omitempty"`
+	MaxAwardsPerUser *int                   `json:"max_awards_per_user,omitempty"`
+	StartsAt         *string                `json:"starts_at,omitempty"`
+	EndsAt           *string                `json:"ends_at,omitempty"`
+	IsActive         bool                   `json:"is_active"`
+	CreatedAt        string                 `json:"created_at"`
+	UpdatedAt        string                 `json:"updated_at"`
+}
+
+// EarnAwardResponse represents an award granted for an earn event
+type EarnAwardResponse struct {
+	RuleID     string  `json:"rule_id"`
+	UserID     string  `json:"user_id"`
+	PointType  string  `json:"point_type"`
+	Amount     int64   `json:"amount"`
+	CampaignID *string `json:"campaign_id,omitempty"`
+	Bonus      int64   `json:"bonus"`
+	Total      int64   `json:"total"`
+	EntryID    string  `json:"entry_id,omitempty"`
+}
+
+// CreateRule handles POST /earn-rules
+func (h *EarnRuleHandler) CreateRule(c *gin.Context) {
+	var req EarnRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	rule, err := h.earnService.CreateRule(c.Request.Context(), req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create earn rule", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, earnRuleToResponse(rule))
+}
+
+// GetRule handles GET /earn-rules/{id}
+func (h *EarnRuleHandler) GetRule(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing earn rule ID", "")
+		return
+	}
+
+	rule, err := h.earnService.GetRule(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get earn rule", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, earnRuleToResponse(rule))
+}
+
+// UpdateRule handles PUT /earn-rules/{id}
+func (h *EarnRuleHandler) UpdateRule(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing earn rule ID", "")
+		return
+	}
+
+	var req EarnRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	rule, err := h.earnService.UpdateRule(c.Request.Context(), id, req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to update earn rule", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, earnRuleToResponse(rule))
+}
+
+// DeleteRule handles DELETE /earn-rules/{id}
+func (h *EarnRuleHandler) DeleteRule(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing earn rule ID", "")
+		return
+	}
+
+	if err := h.earnService.DeleteRule(c.Request.Context(), id); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to delete earn rule", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRules handles GET /earn-rules
+func (h *EarnRuleHandler) ListRules(c *gin.Context) {
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	rules, err := h.earnService.ListRules(c.Request.Context(), limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list earn rules", err.Error())
+		return
+	}
+
+	responses := make([]EarnRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = earnRuleToResponse(rule)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// EvaluateEvent handles POST /earn-events/evaluate, previewing awards without crediting them
+func (h *EarnRuleHandler) EvaluateEvent(c *gin.Context) {
+	h.handleEvent(c, h.earnService.Evaluate, http.StatusOK, "Failed to evaluate earn event")
+}
+
+// ProcessEvent handles POST /earn-events
+func (h *EarnRuleHandler) ProcessEvent(c *gin.Context) {
+	h.handleEvent(c, h.earnService.ProcessEvent, http.StatusCreated, "Failed to process earn event")
+}
+
+// handleEvent binds an earn event and responds with the awards fn grants for it
+func (h *EarnRuleHandler) handleEvent(c *gin.Context, fn func(context.Context, *domain.EarnEvent) ([]*domain.EarnAward, error), successStatus int, errTitle string) {
+	var req EarnEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.EventType == "" || req.UserID == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "event_type and user_id are required")
+		return
+	}
+
+	var occurredAt time.Time
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	event, err := domain.NewEarnEvent(req.EventType, req.UserID, req.Reference, req.Attributes, occurredAt)
+	if err != nil {
+		writeError(c, getStatusCodeFromError(err), errTitle, err.Error())
+		return
+	}
+
+	awards, err := fn(c.Request.Context(), event)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, errTitle, err.Error())
+		return
+	}
+
+	responses := make([]EarnAwardResponse, len(awards))
+	for i, award := range awards {
+		responses[i] = EarnAwardResponse{
+			RuleID:     award.RuleID,
+			UserID:     award.UserID,
+			PointType:  string(award.PointType),
+			Amount:     award.Amount,
+			CampaignID: award.CampaignID,
+			Bonus:      award.Bonus,
+			Total:      award.Total(),
+			EntryID:    award.EntryID,
+		}
+	}
+
+	c.JSON(successStatus, responses)
+}
+
+// toSpec converts the request to earn rule settings; rules are active unless disabled explicitly
+func (req *EarnRuleRequest) toSpec() domain.EarnRuleSpec {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return domain.EarnRuleSpec{
+		Name:             req.Name,
+		EventType:        req.EventType,
+		Conditions:       req.Conditions,
+		AwardType:        domain.AwardType(req.AwardType),
+		Amount:           req.Amount,
+		PointType:        domain.PointType(req.PointType),
+		BaseAttribute:    req.BaseAttribute,
+		MaxAward:         req.MaxAward,
+		MaxAwardsPerUser: req.MaxAwardsPerUser,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		IsActive:         isActive,
+	}
+}
+
+// earnRuleToResponse converts a domain earn rule to response format
+func earnRuleToResponse(rule *domain.EarnRule) EarnRuleResponse {
+	return EarnRuleResponse{
+		ID:               rule.ID,
+		Name:             rule.Name,
+		EventType:        rule.EventType,
+		Conditions:       rule.Conditions,
+		AwardType:        string(rule.AwardType),
+		Amount:           rule.Amount,
+		PointType:        string(rule.PointType),
+		BaseAttribute:    rule.BaseAttribute,
+		MaxAward:         rule.MaxAward,
+		MaxAwardsPerUser: rule.MaxAwardsPerUser,
+		StartsAt:         formatOptionalTime(rule.StartsAt),
+		EndsAt:           formatOptionalTime(rule.EndsAt),
+		IsActive:         rule.IsActive,
+		CreatedAt:        rule.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        rule.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RedemptionService interface defines what the handler needs from the redemption service
+type RedemptionService interface {
+	CreateRedemption(ctx context.Context, userID, rewardID string) (*domain.Redemption, error)
+	GetRedemption(ctx context.Context, id string) (*domain.Redemption, error)
+	ListUserRedemptions(ctx context.Context, userID string, limit, offset int) ([]*domain.Redemption, error)
+	ConfirmRedemption(ctx context.Context, id string) (*domain.Redemption, error)
+	CancelRedemption(ctx context.Context, id string) (*domain.Redemption, error)
+}
+
+// RedemptionHandler handles HTTP requests for reward redemptions
+type RedemptionHandler struct {
+	redemptionService RedemptionService
+}
+
+// NewRedemptionHandler creates a new redemption handler
+func NewRedemptionHandler(redemptionService RedemptionService) *RedemptionHandler {
+	return &RedemptionHandler{
+		redemptionService: redemptionService,
+	}
+}
+
+// CreateRedemptionRequest represents the request body for redeeming a reward
+type CreateRedemptionRequest struct {
+	RewardID string `json:"reward_id"`
+	UserID   string `json:"user_id,omitempty"`
+}
+
+// RedemptionResponse represents the response body for redemption operations
+type RedemptionResponse struct {
+	ID        string  `json:"id"`
+	UserID    string  `json:"user_id"`
+	RewardID  string  `json:"reward_id"`
+	Cost      int64   `json:"cost"`
+	PointType string  `json:"point_type"`
+	Status    string  `json:"status"`
+	ExpiresAt string  `json:"expires_at"`
+	SettledAt *string `json:"settled_at,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// CreateRedemption handles POST /redemptions; the user defaults to the caller
+func (h *RedemptionHandler) CreateRedemption(c *gin.Context) {
+	var req CreateRedemptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.RewardID == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "reward_id is required")
+		return
+	}
+
+	if req.UserID == "" {
+		if principal, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+			req.UserID = principal.UserID
+		}
+	}
+
+	redemption, err := h.redemptionService.CreateRedemption(c.Request.Context(), req.UserID, req.RewardID)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to redeem reward", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, redemptionToResponse(redemption))
+}
+
+// GetRedemption handles GET /redemptions/{id}
+func (h *RedemptionHandler) GetRedemption(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing redemption ID", "")
+		return
+	}
+
+	redemption, err := h.redemptionService.GetRedemption(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get redemption", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, redemptionToResponse(redemption))
+}
+
+// ListUserRedemptions handles GET /users/{id}/redemptions
+func (h *RedemptionHandler) ListUserRedemptions(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	redemptions, err := h.redemptionService.ListUserRedemptions(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list redemptions", err.Error())
+		return
+	}
+
+	responses := make([]RedemptionResponse, len(redemptions))
+	for i, redemption := range redemptions {
+		responses[i] = redemptionToResponse(redemption)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// ConfirmRedemption handles POST /redemptions/{id}/confirm
+func (h *RedemptionHandler) ConfirmRedemption(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing redemption ID", "")
+		return
+	}
+
+	redemption, err := h.redemptionService.ConfirmRedemption(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to confirm redemption", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, redemptionToResponse(redemption))
+}
+
+// CancelRedemption handles POST /redemptions/{id}/cancel
+func (h *RedemptionHandler) CancelRedemption(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing redemption ID", "")
+		return
+	}
+
+	redemption, err := h.redemptionService.CancelRedemption(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to cancel redemption", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, redemptionToResponse(redemption))
+}
+
+// redemptionToResponse converts a domain redemption to response format
+func redemptionToResponse(redemption *domain.Redemption) RedemptionResponse {
+	return RedemptionResponse{
+		ID:        redemption.ID,
+		UserID:    redemption.UserID,
+		RewardID:  redemption.RewardID,
+		Cost:      redemption.Cost,
+		PointType: string(redemption.PointType),
+		Status:    string(redemption.Status),
+		ExpiresAt: redemption.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		SettledAt: formatOptionalTime(redemption.SettledAt),
+		CreatedAt: redemption.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
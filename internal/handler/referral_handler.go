@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReferralService interface defines what the handler needs from the referral service
+type ReferralService interface {
+	ListReferrals(ctx context.Context, referrerID string, limit, offset int) ([]*domain.Referral, error)
+}
+
+// ReferralHandler handles HTTP requests for the referral program
+type ReferralHandler struct {
+	referralService ReferralService
+}
+
+// NewReferralHandler creates a new referral handler
+func NewReferralHandler(referralService ReferralService) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+	}
+}
+
+// ReferralResponse represents the response body for a user's referrals
+type ReferralResponse struct {
+	ID          string  `json:"id"`
+	RefereeID   string  `json:"referee_id"`
+	RefereeName string  `json:"referee_name"`
+	Status      string  `json:"status"`
+	CreatedAt   string  `json:"created_at"`
+	RewardedAt  *string `json:"rewarded_at,omitempty"`
+}
+
+// ListReferrals handles GET /users/{id}/referrals
+func (h *ReferralHandler) ListReferrals(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	referrals, err := h.referralService.ListReferrals(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list referrals", err.Error())
+		return
+	}
+
+	responses := make([]ReferralResponse, len(referrals))
+	for i, referral := range referrals {
+		responses[i] = ReferralResponse{
+			ID:          referral.ID,
+			RefereeID:   referral.RefereeID,
+			RefereeName: referral.RefereeName,
+			Status:      string(referral.Status),
+			CreatedAt:   referral.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			RewardedAt:  formatOptionalTime(referral.RewardedAt),
+		}
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
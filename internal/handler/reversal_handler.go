@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReversalService interface defines what the handler needs from the reversal service
+type ReversalService interface {
+	ReverseEntry(ctx context.Context, entryID string, amount int64, reason string) (*domain.Reversal, error)
+}
+
+// ReversalHandler handles HTTP requests for reversing transactions
+type ReversalHandler struct {
+	reversalService ReversalService
+}
+
+// NewReversalHandler creates a new reversal handler
+func NewReversalHandler(reversalService ReversalService) *ReversalHandler {
+	return &ReversalHandler{
+		reversalService: reversalService,
+	}
+}
+
+// ReverseTransactionRequest represents the request body for reversing a transaction
+type ReverseTransactionRequest struct {
+	Amount int64  `json:"amount,omitempty"` // defaults to whatever is left of the transaction
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReversalResponse represents the response body for reversal operations; shortfall is the part
+// of amount that could not be clawed back because the user already spent it
+type ReversalResponse struct {
+	ID                    string  `json:"id"`
+	TransactionID         string  `json:"transaction_id"`
+	TransactionKind       string  `json:"transaction_kind"`
+	UserID                string  `json:"user_id"`
+	PointType             string  `json:"point_type"`
+	Amount                int64   `json:"amount"`
+	Posted                int64   `json:"posted"`
+	Shortfall             int64   `json:"shortfall"`
+	ReversalTransactionID *string `json:"reversal_transaction_id"` // null when nothing could be clawed back
+	Reason                string  `json:"reason,omitempty"`
+	CreatedAt             string  `json:"created_at"`
+}
+
+// ReverseTransaction handles POST /transactions/{id}/reverse
+func (h *ReversalHandler) ReverseTransaction(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing transaction ID", "")
+		return
+	}
+
+	// The body is optional; without one the whole transaction is reversed
+	var req ReverseTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	reversal, err := h.reversalService.ReverseEntry(c.Request.Context(), id, req.Amount, req.Reason)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to reverse transaction", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, ReversalResponse{
+		ID:                    reversal.ID,
+		TransactionID:         reversal.EntryID,
+		TransactionKind:       string(reversal.EntryKind),
+		UserID:                reversal.UserID,
+		PointType:             string(reversal.PointType),
+		Amount:                reversal.Amount,
+		Posted:                reversal.Posted,
+		Shortfall:             reversal.Shortfall(),
+		ReversalTransactionID: reversal.ReversalEntryID,
+		Reason:                reversal.Reason,
+		CreatedAt:             reversal.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RewardService interface defines what the handler needs from the reward service
+type RewardService interface {
+	CreateReward(ctx context.Context, spec domain.RewardSpec) (*domain.Reward, error)
+	GetReward(ctx context.Context, id string) (*domain.Reward, error)
+	UpdateReward(ctx context.Context, id string, spec domain.RewardSpec) (*domain.Reward, error)
+	DeleteReward(ctx context.Context, id string) error
+	ListRewards(ctx context.Context, includeUnavailable bool, limit, offset int) ([]*domain.Reward, error)
+}
+
+// RewardHandler handles HTTP requests for the reward catalog
+type RewardHandler struct {
+	rewardService RewardService
+}
+
+// NewRewardHandler creates a new reward handler
+func NewRewardHandler(rewardService RewardService) *RewardHandler {
+	return &RewardHandler{
+		rewardService: rewardService,
+	}
+}
+
+// RewardRequest represents the request body for creating or replacing a reward
+type RewardRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Cost        int64      `json:"cost"`
+	PointType   string     `json:"point_type,omitempty"` // defaults to credits
+	Stock       int        `json:"stock"`
+	Status      string     `json:"status,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+}
+
+// RewardResponse represents the response body for reward operations
+type RewardResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Cost        int64   `json:"cost"`
+	PointType   string  `json:"point_type"`
+	Stock       int     `json:"stock"`
+	Status      string  `json:"status"`
+	StartsAt    *string `json:"starts_at,omitempty"`
+	EndsAt      *string `json:"ends_at,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// CreateReward handles POST /rewards
+func (h *RewardHandler) CreateReward(c *gin.Context) {
+	var req RewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	reward, err := h.rewardService.CreateReward(c.Request.Context(), req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create reward", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, rewardToResponse(reward))
+}
+
+// GetReward handles GET /rewards/{id}
+func (h *RewardHandler) GetReward(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing reward ID", "")
+		return
+	}
+
+	reward, err := h.rewardService.GetReward(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get reward", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, rewardToResponse(reward))
+}
+
+// UpdateReward handles PUT /rewards/{id}
+func (h *RewardHandler) UpdateReward(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing reward ID", "")
+		return
+	}
+
+	var req RewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	reward, err := h.rewardService.UpdateReward(c.Request.Context(), id, req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to update reward", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, rewardToResponse(reward))
+}
+
+// DeleteReward handles DELETE /rewards/{id}
+func (h *RewardHandler) DeleteReward(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing reward ID", "")
+		return
+	}
+
+	if err := h.rewardService.DeleteReward(c.Request.Context(), id); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to delete reward", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRewards handles GET /rewards; admins can pass all=true to include unavailable rewards
+func (h *RewardHandler) ListRewards(c *gin.Context) {
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	includeUnavailable, _ := strconv.ParseBool(c.Query("all"))
+
+	rewards, err := h.rewardService.ListRewards(c.Request.Context(), includeUnavailable, limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list rewards", err.Error())
+		return
+	}
+
+	responses := make([]RewardResponse, len(rewards))
+	for i, reward := range rewards {
+		responses[i] = rewardToResponse(reward)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// toSpec converts the request to reward settings
+func (req *RewardRequest) toSpec() domain.RewardSpec {
+	return domain.RewardSpec{
+		Name:        req.Name,
+		Description: req.Description,
+		Cost:        req.Cost,
+		PointType:   domain.PointType(req.PointType),
+		Stock:       req.Stock,
+		Status:      domain.RewardStatus(req.Status),
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}
+}
+
+// rewardToResponse converts a domain reward to response format
+func rewardToResponse(reward *domain.Reward) RewardResponse {
+	return RewardResponse{
+		ID:          reward.ID,
+		Name:        reward.Name,
+		Description: reward.Description,
+		Cost:        reward.Cost,
+		PointType:   string(reward.PointType),
+		Stock:       reward.Stock,
+		Status:      string(reward.Status),
+		StartsAt:    formatOptionalTime(reward.StartsAt),
+		EndsAt:      formatOptionalTime(reward.EndsAt),
+		CreatedAt:   reward.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   reward.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// TierService interface defines what the handler needs from the tier service
+type TierService interface {
+	CreateTier(ctx context.Context, spec domain.TierSpec) (*domain.Tier, error)
+	GetTier(ctx context.Context, id string) (*domain.Tier, error)
+	UpdateTier(ctx context.Context, id string, spec domain.TierSpec) (*domain.Tier, error)
+	DeleteTier(ctx context.Context, id string) error
+	ListTiers(ctx context.Context) ([]*domain.Tier, error)
+	ListTierHistory(ctx context.Context, userID string, limit, offset int) ([]*domain.TierChange, error)
+}
+
+// TierHandler handles HTTP requests for loyalty tiers
+type TierHandler struct {
+	tierService TierService
+}
+
+// NewTierHandler creates a new tier handler
+func NewTierHandler(tierService TierService) *TierHandler {
+	return &TierHandler{
+		tierService: tierService,
+	}
+}
+
+// TierRequest represents the request body for creating or replacing a tier
+type TierRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Threshold   int64  `json:"threshold"`
+}
+
+// TierResponse represents the response body for tier operations
+type TierResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Threshold   int64  `json:"threshold"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// TierRefResponse represents a tier a user holds or held
+type TierRefResponse struct {
+	ID   string `json:"id,omitempty"` // omitted once the tier is deleted
+	Name string `json:"name"`
+}
+
+// TierChangeResponse represents the response body for tier history records
+type TierChangeResponse struct {
+	ID               string           `json:"id"`
+	UserID           string           `json:"user_id"`
+	FromTier         *TierRefResponse `json:"from_tier"`
+	ToTier           *TierRefResponse `json:"to_tier"`
+	QualifyingPoints int64            `json:"qualifying_points"`
+	Reason           string           `json:"reason"`
+	CreatedAt        string           `json:"created_at"`
+}
+
+// CreateTier handles POST /tiers
+func (h *TierHandler) CreateTier(c *gin.Context) {
+	var req TierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	tier, err := h.tierService.CreateTier(c.Request.Context(), req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create tier", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, tierToResponse(tier))
+}
+
+// GetTier handles GET /tiers/{id}
+func (h *TierHandler) GetTier(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing tier ID", "")
+		return
+	}
+
+	tier, err := h.tierService.GetTier(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get tier", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tierToResponse(tier))
+}
+
+// UpdateTier handles PUT /tiers/{id}
+func (h *TierHandler) UpdateTier(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing tier ID", "")
+		return
+	}
+
+	var req TierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	tier, err := h.tierService.UpdateTier(c.Request.Context(), id, req.toSpec())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to update tier", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tierToResponse(tier))
+}
+
+// DeleteTier handles DELETE /tiers/{id}
+func (h *TierHandler) DeleteTier(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing tier ID", "")
+		return
+	}
+
+	if err := h.tierService.DeleteTier(c.Request.Context(), id); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to delete tier", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListTiers handles GET /tiers
+func (h *TierHandler) ListTiers(c *gin.Context) {
+	tiers, err := h.tierService.ListTiers(c.Request.Context())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list tiers", err.Error())
+		return
+	}
+
+	responses := make([]TierResponse, len(tiers))
+	for i, tier := range tiers {
+		responses[i] = tierToResponse(tier)
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// ListTierHistory handles GET /users/{id}/tier-history
+func (h *TierHandler) ListTierHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	offset := 0 // Default offset
+	if parsedOffset, err := strconv.Atoi(c.Query("offset")); err == nil && parsedOffset >= 0 {
+		offset = parsedOffset
+	}
+
+	changes, err := h.tierService.ListTierHistory(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list tier history", err.Error())
+		return
+	}
+
+	responses := make([]TierChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = TierChangeResponse{
+			ID:               change.ID,
+			UserID:           change.UserID,
+			FromTier:         tierRefToResponse(change.FromTier),
+			ToTier:           tierRefToResponse(change.ToTier),
+			QualifyingPoints: change.QualifyingPoints,
+			Reason:           string(change.Reason),
+			CreatedAt:        change.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// toSpec converts the request to tier settings
+func (req *TierRequest) toSpec() domain.TierSpec {
+	return domain.TierSpec{
+		Name:        req.Name,
+		Description: req.Description,
+		Threshold:   req.Threshold,
+	}
+}
+
+// tierToResponse converts a domain tier to response format
+func tierToResponse(tier *domain.Tier) TierResponse {
+	return TierResponse{
+		ID:          tier.ID,
+		Name:        tier.Name,
+		Description: tier.Description,
+		Threshold:   tier.Threshold,
+		CreatedAt:   tier.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   tier.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// tierRefToResponse converts an optional tier reference to response format
+func tierRefToResponse(ref *domain.TierRef) *TierRefResponse {
+	if ref == nil {
+		return nil
+	}
+	return &TierRefResponse{ID: ref.ID, Name: ref.Name}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// TransferService interface defines what the handler needs from the transfer service
+type TransferService interface {
+	CreateTransfer(ctx context.Context, senderID, recipientEmail string, pointType domain.PointType, amount int64, note string) (*domain.Transfer, error)
+}
+
+// TransferHandler handles HTTP requests for peer-to-peer credit transfers
+type TransferHandler struct {
+	transferService TransferService
+}
+
+// NewTransferHandler creates a new transfer handler
+func NewTransferHandler(transferService TransferService) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+	}
+}
+
+// CreateTransferRequest represents the request body for gifting credits to another user
+type CreateTransferRequest struct {
+	RecipientEmail string `json:"recipient_email"`
+	PointType      string `json:"point_type,omitempty"` // defaults to credits
+	Amount         int64  `json:"amount"`
+	Note           string `json:"note,omitempty"`
+}
+
+// TransferResponse represents the response body for transfer operations
+type TransferResponse struct {
+	ID          string `json:"id"`
+	SenderID    string `json:"sender_id"`
+	RecipientID string `json:"recipient_id"`
+	PointType   string `json:"point_type"`
+	Amount      int64  `json:"amount"`
+	Note        string `json:"note,omitempty"`
+	EntryID     string `json:"entry_id"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// CreateTransfer handles POST /transfers, sending credits from the caller's wallet
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	// API keys do not own a wallet to send from
+	if principal.IsAPIKey() {
+		writeError(c, http.StatusForbidden, "Forbidden", domain.ErrForbidden.Error())
+		return
+	}
+
+	var req CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.RecipientEmail == "" || req.Amount == 0 {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "recipient_email and amount are required")
+		return
+	}
+
+	transfer, err := h.transferService.CreateTransfer(c.Request.Context(), principal.UserID, req.RecipientEmail, domain.PointType(req.PointType), req.Amount, req.Note)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to transfer credits", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, TransferResponse{
+		ID:          transfer.ID,
+		SenderID:    transfer.SenderID,
+		RecipientID: transfer.RecipientID,
+		PointType:   string(transfer.PointType),
+		Amount:      transfer.Amount,
+		Note:        transfer.Note,
+		EntryID:     transfer.EntryID,
+		CreatedAt:   transfer.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
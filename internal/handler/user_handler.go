@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"html/template"
 	"net/http"
 	"strconv"
 
@@ -13,12 +14,18 @@ import (
 
 // UserService interface defines what the handler needs from the service layer
 type UserService interface {
-	CreateUser(ctx context.Context, email, name string) (*domain.User, error)
+	CreateUser(ctx context.Context, email, name, referralCode string) (*domain.User, error)
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	UpdateUser(ctx context.Context, id string, email, name string) (*domain.User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	DeactivateUser(ctx context.Context, id, reason string) (*domain.User, error)
+	ReactivateUser(ctx context.Context, id string) (*domain.User, error)
+	RestoreUser(ctx context.Context, id string) (*domain.User, error)
+	ChangeUserRole(ctx context.Context, id string, role domain.Role) (*domain.User, error)
+	ConfirmEmailChange(ctx context.Context, id, code string) (*domain.User, error)
+	RevertEmailChange(ctx context.Context, revertToken string) (*domain.User, error)
 }
 
 // UserHandler handles HTTP requests for user operations
@@ -35,8 +42,9 @@ func NewUserHandler(userService UserService) *UserHandler {
 
 // CreateUserRequest represents the request body for creating a user
 type CreateUserRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	ReferralCode string `json:"referral_code,omitempty"` // code of the user who referred the new user
 }
 
 // UpdateUserRequest represents the request body for updating a user
@@ -45,37 +53,63 @@ type UpdateUserRequest struct {
 	Name  string `json:"name,omitempty"`
 }
 
+// ConfirmEmailChangeRequest represents the request body for confirming an email change
+type ConfirmEmailChangeRequest struct {
+	Code string `json:"code"`
+}
+
+// RevertEmailChangeRequest represents the request body for reverting an email change; the token
+// arrives as JSON from API clients or as a form field from the revert page
+type RevertEmailChangeRequest struct {
+	Token string `json:"token" form:"token"`
+}
+
+// ChangeRoleRequest represents the request body for changing a user's role
+type ChangeRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// DeactivateUserRequest represents the request body for deactivating a user
+type DeactivateUserRequest struct {
+	Reason string `json:"reason"`
+}
+
 // UserResponse represents the response body for user operations
 type UserResponse struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID            string           `json:"id"`
+	Email         string           `json:"email"`
+	Name          string           `json:"name"`
+	Role          string           `json:"role"`
+	Tier          *TierRefResponse `json:"tier"` // null until the user qualifies for a tier
+	ReferralCode  string           `json:"referral_code"`
+	IsActive      bool             `json:"is_active"`
+	DeactivatedAt *string          `json:"deactivated_at,omitempty"`
+	CreatedAt     string           `json:"created_at"`
+	UpdatedAt     string           `json:"updated_at"`
 }
 
 // CreateUser handles POST /users
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
 		return
 	}
 
 	// Validate required fields
 	if req.Email == "" || req.Name == "" {
-		h.writeError(c, http.StatusBadRequest, "Missing required fields", "email and name are required")
+		writeError(c, http.StatusBadRequest, "Missing required fields", "email and name are required")
 		return
 	}
 
-	user, err := h.userService.CreateUser(c.Request.Context(), req.Email, req.Name)
+	user, err := h.userService.CreateUser(c.Request.Context(), req.Email, req.Name, req.ReferralCode)
 	if err != nil {
-		statusCode := h.getStatusCodeFromError(err)
-		h.writeError(c, statusCode, "Failed to create user", err.Error())
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to create user", err.Error())
 		return
 	}
 
-	response := h.userToResponse(user)
+	response := userToResponse(user)
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -84,18 +118,43 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	id := c.Param("id")
 
 	if id == "" {
-		h.writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
 		return
 	}
 
 	user, err := h.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
-		statusCode := h.getStatusCodeFromError(err)
-		h.writeError(c, statusCode, "Failed to get user", err.Error())
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get user", err.Error())
 		return
 	}
 
-	response := h.userToResponse(user)
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCurrentUser handles GET /users/me
+func (h *UserHandler) GetCurrentUser(c *gin.Context) {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		writeError(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	// API keys do not belong to a user session
+	if principal.IsAPIKey() {
+		writeError(c, http.StatusForbidden, "Forbidden", domain.ErrForbidden.Error())
+		return
+	}
+
+	user, err := h.userService.GetUserByID(c.Request.Context(), principal.UserID)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get user", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -104,24 +163,117 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	id := c.Param("id")
 
 	if id == "" {
-		h.writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
 		return
 	}
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
 		return
 	}
 
 	user, err := h.userService.UpdateUser(c.Request.Context(), id, req.Email, req.Name)
 	if err != nil {
-		statusCode := h.getStatusCodeFromError(err)
-		h.writeError(c, statusCode, "Failed to update user", err.Error())
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to update user", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfirmEmailChange handles POST /users/{id}/email/confirm
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	var req ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Code == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "code is required")
+		return
+	}
+
+	user, err := h.userService.ConfirmEmailChange(c.Request.Context(), id, req.Code)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to confirm email change", err.Error())
 		return
 	}
 
-	response := h.userToResponse(user)
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// revertEmailChangePage asks the owner of the old address to confirm the revert. Opening the emailed
+// link changes nothing, so mail scanners and link prefetchers cannot revert a change by following it.
+var revertEmailChangePage = template.Must(template.New("revert").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Undo email change</title></head>
+<body>
+<p>Someone changed the email address of your account. If it was not you, undo the change to restore
+your address and sign out all sessions.</p>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Undo email change</button>
+</form>
+</body>
+</html>
+`))
+
+// RevertEmailChangePage handles GET /users/email/revert?token=..., serving the page that submits the revert
+func (h *UserHandler) RevertEmailChangePage(c *gin.Context) {
+	revertToken := c.Query("token")
+
+	if revertToken == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "token is required")
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Status(http.StatusOK)
+	err := revertEmailChangePage.Execute(c.Writer, map[string]string{
+		"Action": c.Request.URL.Path,
+		"Token":  revertToken,
+	})
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// RevertEmailChange handles POST /users/email/revert
+func (h *UserHandler) RevertEmailChange(c *gin.Context) {
+	var req RevertEmailChangeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if req.Token == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "token is required")
+		return
+	}
+
+	user, err := h.userService.RevertEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to revert email change", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -130,20 +282,122 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id := c.Param("id")
 
 	if id == "" {
-		h.writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
 		return
 	}
 
 	err := h.userService.DeleteUser(c.Request.Context(), id)
 	if err != nil {
-		statusCode := h.getStatusCodeFromError(err)
-		h.writeError(c, statusCode, "Failed to delete user", err.Error())
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to delete user", err.Error())
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// DeactivateUser handles POST /users/{id}/deactivate
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	var req DeactivateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Reason == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "reason is required")
+		return
+	}
+
+	user, err := h.userService.DeactivateUser(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to deactivate user", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// ReactivateUser handles POST /users/{id}/reactivate
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	user, err := h.userService.ReactivateUser(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to reactivate user", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// ChangeUserRole handles PUT /users/{id}/role
+func (h *UserHandler) ChangeUserRole(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Role == "" {
+		writeError(c, http.StatusBadRequest, "Missing required fields", "role is required")
+		return
+	}
+
+	user, err := h.userService.ChangeUserRole(c.Request.Context(), id, domain.Role(req.Role))
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to change user role", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreUser handles POST /users/{id}/restore
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	user, err := h.userService.RestoreUser(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to restore user", err.Error())
+		return
+	}
+
+	response := userToResponse(user)
+	c.JSON(http.StatusOK, response)
+}
+
 // ListUsers handles GET /users
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	// Parse query parameters
@@ -166,27 +420,32 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 
 	users, err := h.userService.ListUsers(c.Request.Context(), limit, offset)
 	if err != nil {
-		statusCode := h.getStatusCodeFromError(err)
-		h.writeError(c, statusCode, "Failed to list users", err.Error())
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list users", err.Error())
 		return
 	}
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = h.userToResponse(user)
+		responses[i] = userToResponse(user)
 	}
 
 	c.JSON(http.StatusOK, responses)
 }
 
 // userToResponse converts a domain user to response format
-func (h *UserHandler) userToResponse(user *domain.User) UserResponse {
+func userToResponse(user *domain.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		Role:          string(user.Role),
+		Tier:          tierRefToResponse(user.Tier),
+		ReferralCode:  user.ReferralCode,
+		IsActive:      user.IsActive,
+		DeactivatedAt: formatOptionalTime(user.DeactivatedAt),
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -200,33 +459,3 @@ func (h *UserHandler) dtoToResponse(userDTO *dto.UserDTO) UserResponse {
 		UpdatedAt: userDTO.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
-
-// getStatusCodeFromError maps domain errors to HTTP status codes
-func (h *UserHandler) getStatusCodeFromError(err error) int {
-	switch {
-	case containsError(err, domain.ErrUserNotFound):
-		return http.StatusNotFound
-	case containsError(err, domain.ErrUserAlreadyExists):
-		return http.StatusConflict
-	case containsError(err, domain.ErrInvalidUserID),
-		containsError(err, domain.ErrInvalidUserEmail),
-		containsError(err, domain.ErrInvalidUserName),
-		containsError(err, domain.ErrInvalidInput),
-		containsError(err, domain.ErrValidationFailed):
-		return http.StatusBadRequest
-	case containsError(err, domain.ErrUnauthorized):
-		return http.StatusUnauthorized
-	case containsError(err, domain.ErrForbidden):
-		return http.StatusForbidden
-	default:
-		return http.StatusInternalServerError
-	}
-}
-
-// writeError writes an error response
-func (h *UserHandler) writeError(c *gin.Context, statusCode int, errTitle, message string) {
-	c.JSON(statusCode, ErrorResponse{
-		Error:   errTitle,
-		Message: message,
-	})
-}
@@ -1,6 +1,14 @@
 package handler
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
 
 // containsError checks if an error contains a specific error
 func containsError(err, target error) bool {
@@ -13,3 +21,134 @@ type ErrorResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// getStatusCodeFromError maps domain errors to HTTP status codes
+func getStatusCodeFromError(err error) int {
+	switch {
+	case containsError(err, domain.ErrUserNotFound),
+		containsError(err, domain.ErrEmailChangeNotFound),
+		containsError(err, domain.ErrAPIKeyNotFound),
+		containsError(err, domain.ErrLedgerAccountNotFound),
+		containsError(err, domain.ErrWalletNotFound),
+		containsError(err, domain.ErrEarnRuleNotFound),
+		containsError(err, domain.ErrRewardNotFound),
+		containsError(err, domain.ErrRedemptionNotFound),
+		containsError(err, domain.ErrTierNotFound),
+		containsError(err, domain.ErrReferralNotFound),
+		containsError(err, domain.ErrCampaignNotFound),
+		containsError(err, domain.ErrAdjustmentNotFound),
+		containsError(err, domain.ErrJournalEntryNotFound):
+		return http.StatusNotFound
+	case containsError(err, domain.ErrUserAlreadyExists),
+		containsError(err, domain.ErrUserAlreadyActive),
+		containsError(err, domain.ErrUserAlreadyInactive),
+		containsError(err, domain.ErrRoleUnchanged),
+		containsError(err, domain.ErrLastAdmin),
+		containsError(err, domain.ErrInsufficientCredits),
+		containsError(err, domain.ErrDuplicateEntry),
+		containsError(err, domain.ErrRewardUnavailable),
+		containsError(err, domain.ErrRedemptionNotHeld),
+		containsError(err, domain.ErrRedemptionExpired),
+		containsError(err, domain.ErrDailyTransferLimitExceeded),
+		containsError(err, domain.ErrTransferRecipientIneligible),
+		containsError(err, domain.ErrPointTypeNotRedeemable),
+		containsError(err, domain.ErrTierAlreadyExists),
+		containsError(err, domain.ErrReferralLimitReached),
+		containsError(err, domain.ErrReferralNotPending),
+		containsError(err, domain.ErrAdjustmentNotPending),
+		containsError(err, domain.ErrEntryAlreadyReversed),
+		containsError(err, domain.ErrReversalConflict):
+		return http.StatusConflict
+	case containsError(err, domain.ErrInvalidUserID),
+		containsError(err, domain.ErrInvalidUserEmail),
+		containsError(err, domain.ErrInvalidUserName),
+		containsError(err, domain.ErrInvalidUserRole),
+		containsError(err, domain.ErrInvalidDeactivationReason),
+		containsError(err, domain.ErrEmailUnchanged),
+		containsError(err, domain.ErrInvalidAPIKeyName),
+		containsError(err, domain.ErrInvalidAPIKeyScope),
+		containsError(err, domain.ErrInvalidAPIKeyExpiry),
+		containsError(err, domain.ErrInvalidAccountType),
+		containsError(err, domain.ErrInvalidEntryKind),
+		containsError(err, domain.ErrInvalidPosting),
+		containsError(err, domain.ErrUnbalancedEntry),
+		containsError(err, domain.ErrInvalidAmount),
+		containsError(err, domain.ErrInvalidTransactionFilter),
+		containsError(err, domain.ErrInvalidCursor),
+		containsError(err, domain.ErrEntryNotReversible),
+		containsError(err, domain.ErrReversalExceedsEntry),
+		containsError(err, domain.ErrInvalidReversalReason),
+		containsError(err, domain.ErrInvalidEarnRuleName),
+		containsError(err, domain.ErrInvalidEventType),
+		containsError(err, domain.ErrInvalidRuleCondition),
+		containsError(err, domain.ErrInvalidEarnRuleAward),
+		containsError(err, domain.ErrInvalidEarnRuleCap),
+		containsError(err, domain.ErrInvalidEarnRuleWindow),
+		containsError(err, domain.ErrInvalidCampaignName),
+		containsError(err, domain.ErrInvalidCampaignWindow),
+		containsError(err, domain.ErrInvalidCampaignAudience),
+		containsError(err, domain.ErrInvalidCampaignBoost),
+		containsError(err, domain.ErrInvalidCampaignBudget),
+		containsError(err, domain.ErrInvalidAdjustmentDirection),
+		containsError(err, domain.ErrInvalidAdjustmentReason),
+		containsError(err, domain.ErrInvalidAdjustmentNote),
+		containsError(err, domain.ErrInvalidAdjustmentStatus),
+		containsError(err, domain.ErrInvalidRewardName),
+		containsError(err, domain.ErrInvalidRewardCost),
+		containsError(err, domain.ErrInvalidRewardStock),
+		containsError(err, domain.ErrInvalidRewardStatus),
+		containsError(err, domain.ErrInvalidRewardWindow),
+		containsError(err, domain.ErrSelfTransfer),
+		containsError(err, domain.ErrInvalidTransferNote),
+		containsError(err, domain.ErrTransferLimitExceeded),
+		containsError(err, domain.ErrInvalidPointType),
+		containsError(err, domain.ErrPointTypeNotTransferable),
+		containsError(err, domain.ErrInvalidTierName),
+		containsError(err, domain.ErrInvalidTierThreshold),
+		containsError(err, domain.ErrInvalidReferralCode),
+		containsError(err, domain.ErrSelfReferral),
+		containsError(err, domain.ErrInvalidInput),
+		containsError(err, domain.ErrValidationFailed):
+		return http.StatusBadRequest
+	case containsError(err, domain.ErrUnauthorized),
+		containsError(err, domain.ErrInvalidToken),
+		containsError(err, domain.ErrTokenExpired),
+		containsError(err, domain.ErrRefreshTokenReused),
+		containsError(err, domain.ErrInvalidAPIKey),
+		containsError(err, domain.ErrAPIKeyRevoked),
+		containsError(err, domain.ErrAPIKeyExpired),
+		containsError(err, domain.ErrInvalidOTP),
+		containsError(err, domain.ErrInvalidOTPExpiresAt),
+		containsError(err, domain.ErrInvalidEmailChangeCode),
+		containsError(err, domain.ErrEmailChangeExpired):
+		return http.StatusUnauthorized
+	case containsError(err, domain.ErrForbidden),
+		containsError(err, domain.ErrInvalidAdjustmentActor),
+		containsError(err, domain.ErrSelfApproval),
+		containsError(err, domain.ErrUserInactive),
+		containsError(err, domain.ErrTransferSenderIneligible):
+		return http.StatusForbidden
+	case containsError(err, domain.ErrOTPLocked),
+		containsError(err, domain.ErrOTPResendCooldown),
+		containsError(err, domain.ErrEmailChangeAttemptsExceeded):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError writes an error response
+func writeError(c *gin.Context, statusCode int, errTitle, message string) {
+	c.JSON(statusCode, ErrorResponse{
+		Error:   errTitle,
+		Message: message,
+	})
+}
+
+// formatOptionalTime formats an optional timestamp for responses
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// WalletService interface defines what the handler needs from the ledger service
+type WalletService interface {
+	GetWallet(ctx context.Context, userID string) (*domain.Wallet, error)
+	GetExpiringCredits(ctx context.Context, userID string, within time.Duration) (*domain.ExpiringCredits, error)
+	ListTransactions(ctx context.Context, userID string, filter domain.TransactionFilter, cursor string, limit int) (*domain.TransactionPage, error)
+}
+
+// WalletHandler handles HTTP requests for user wallets
+type WalletHandler struct {
+	walletService WalletService
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(walletService WalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+	}
+}
+
+// WalletResponse represents the response body for wallet operations; the totals count credits
+// and balances maps each point type to its available amount
+type WalletResponse struct {
+	UserID         string           `json:"user_id"`
+	Available      int64            `json:"available"`
+	Pending        int64            `json:"pending"`
+	Held           int64            `json:"held"`
+	LifetimeEarned int64            `json:"lifetime_earned"`
+	Balances       map[string]int64 `json:"balances"`
+}
+
+// ExpiringCreditsResponse represents the response body for a user's expiring credits
+type ExpiringCreditsResponse struct {
+	UserID string              `json:"user_id"`
+	Before string              `json:"before"`
+	Total  int64               `json:"total"`
+	Lots   []CreditLotResponse `json:"lots"`
+}
+
+// CreditLotResponse represents unspent points of one lot and when they expire
+type CreditLotResponse struct {
+	PointType  string  `json:"point_type"`
+	Amount     int64   `json:"amount"`
+	ExpiresAt  *string `json:"expires_at,omitempty"`
+	ReceivedAt string  `json:"received_at"`
+}
+
+// TransactionListResponse represents a page of a user's statement; next_cursor is null on the last page
+type TransactionListResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   *string               `json:"next_cursor"`
+}
+
+// TransactionResponse represents one transaction of a user's statement with their available balance after it
+type TransactionResponse struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	PointType    string `json:"point_type"`
+	Source       string `json:"source,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Amount       int64  `json:"amount"`
+	BalanceAfter int64  `json:"balance_after"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// GetWallet handles GET /users/{id}/wallet
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	wallet, err := h.walletService.GetWallet(c.Request.Context(), id)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get wallet", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, walletToResponse(wallet))
+}
+
+// GetExpiringCredits handles GET /users/{id}/credits/expiring?days=30
+func (h *WalletHandler) GetExpiringCredits(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	days := 30 // Default window
+	if parsedDays, err := strconv.Atoi(c.Query("days")); err == nil && parsedDays > 0 {
+		days = parsedDays
+	}
+
+	expiring, err := h.walletService.GetExpiringCredits(c.Request.Context(), id, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to get expiring credits", err.Error())
+		return
+	}
+
+	response := ExpiringCreditsResponse{
+		UserID: expiring.UserID,
+		Before: expiring.Before.Format("2006-01-02T15:04:05Z07:00"),
+		Total:  expiring.Total,
+		Lots:   make([]CreditLotResponse, len(expiring.Lots)),
+	}
+	for i, lot := range expiring.Lots {
+		response.Lots[i] = CreditLotResponse{
+			PointType:  string(lot.PointType),
+			Amount:     lot.Remaining,
+			ExpiresAt:  formatOptionalTime(lot.ExpiresAt),
+			ReceivedAt: lot.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// walletToResponse converts a domain wallet to its response body
+func walletToResponse(wallet *domain.Wallet) WalletResponse {
+	response := WalletResponse{
+		UserID:         wallet.UserID,
+		Available:      wallet.Available,
+		Pending:        wallet.Pending(),
+		Held:           wallet.Held,
+		LifetimeEarned: wallet.LifetimeEarned,
+		Balances:       make(map[string]int64, len(domain.PointTypes)),
+	}
+	// Every point type is listed, even before the user has received any
+	for _, pointType := range domain.PointTypes {
+		response.Balances[string(pointType)] = wallet.Balances[pointType]
+	}
+	return response
+}
+
+// ListTransactions handles GET /users/{id}/transactions?type=earn,redeem&source=earn_rule&from=...&to=...
+// &min_amount=10&max_amount=500&point_type=credits&cursor=...&limit=10
+func (h *WalletHandler) ListTransactions(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		writeError(c, http.StatusBadRequest, "Missing user ID", "")
+		return
+	}
+
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, "Invalid query parameter", err.Error())
+		return
+	}
+
+	limit := 10 // Default limit
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 {
+		limit = parsedLimit
+	}
+
+	page, err := h.walletService.ListTransactions(c.Request.Context(), id, filter, c.Query("cursor"), limit)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		writeError(c, statusCode, "Failed to list transactions", err.Error())
+		return
+	}
+
+	response := TransactionListResponse{
+		Transactions: make([]TransactionResponse, len(page.Transactions)),
+	}
+	for i, transaction := range page.Transactions {
+		response.Transactions[i] = TransactionResponse{
+			ID:           transaction.EntryID,
+			Type:         string(transaction.Kind),
+			PointType:    string(transaction.PointType),
+			Source:       transaction.Source,
+			Description:  transaction.Description,
+			Amount:       transaction.Amount,
+			BalanceAfter: transaction.BalanceAfter,
+			CreatedAt:    transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseTransactionFilter reads a statement filter from the query string; types are comma separated
+// and the date range is given as RFC 3339 timestamps
+func parseTransactionFilter(c *gin.Context) (domain.TransactionFilter, error) {
+	filter := domain.TransactionFilter{
+		PointType: domain.PointType(c.Query("point_type")),
+		Source:    c.Query("source"),
+	}
+
+	if types := c.Query("type"); types != "" {
+		for _, kind := range strings.Split(types, ",") {
+			filter.Kinds = append(filter.Kinds, domain.EntryKind(strings.TrimSpace(kind)))
+		}
+	}
+
+	var err error
+	if filter.From, err = parseOptionalTime(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseOptionalTime(c, "to"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount, err = parseOptionalInt(c, "min_amount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseOptionalInt(c, "max_amount"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseOptionalTime reads an RFC 3339 timestamp query parameter, returning nil if it is absent
+func parseOptionalTime(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", param)
+	}
+	return &parsed, nil
+}
+
+// parseOptionalInt reads an integer query parameter, returning nil if it is absent
+func parseOptionalInt(c *gin.Context, param string) (*int64, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", param)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/pkg/mailer"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// MessageQueue accepts messages for asynchronous delivery
+type MessageQueue interface {
+	Enqueue(msg *mailer.Message) error
+}
+
+// EmailNotifierConfig holds email notification settings
+type EmailNotifierConfig struct {
+	From           string
+	OTPTTL         time.Duration
+	EmailChangeTTL time.Duration
+	EmailRevertTTL time.Duration
+	EmailRevertURL string // link target for reverting an email change; the token is appended as a query parameter
+}
+
+// EmailNotifier renders notifications from templates and queues them for delivery
+type EmailNotifier struct {
+	renderer *mailer.Renderer
+	queue    MessageQueue
+	config   EmailNotifierConfig
+}
+
+// NewEmailNotifier creates a new email notifier
+func NewEmailNotifier(queue MessageQueue, config EmailNotifierConfig) (*EmailNotifier, error) {
+	templates, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
+
+	renderer, err := mailer.NewRenderer(templates, config.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailNotifier{
+		renderer: renderer,
+		queue:    queue,
+		config:   config,
+	}, nil
+}
+
+// SendOTP emails a one-time password to the user
+func (n *EmailNotifier) SendOTP(ctx context.Context, email, code string) error {
+	return n.send("otp", email, map[string]any{
+		"Code":      code,
+		"ExpiresIn": n.config.OTPTTL.String(),
+	})
+}
+
+// SendEmailChangeCode emails the code that confirms a new address
+func (n *EmailNotifier) SendEmailChangeCode(ctx context.Context, newEmail, code string) error {
+	return n.send("email_change_code", newEmail, map[string]any{
+		"Code":      code,
+		"ExpiresIn": n.config.EmailChangeTTL.String(),
+	})
+}
+
+// SendEmailChangeNotice tells the old address about an email change and how to revert it
+func (n *EmailNotifier) SendEmailChangeNotice(ctx context.Context, oldEmail, newEmail, revertToken string) error {
+	return n.send("email_change_notice", oldEmail, map[string]any{
+		"NewEmail":  newEmail,
+		"RevertURL": n.config.EmailRevertURL + "?token=" + url.QueryEscape(revertToken),
+		"ExpiresIn": n.config.EmailRevertTTL.String(),
+	})
+}
+
+// send renders the named template and queues it for delivery
+func (n *EmailNotifier) send(templateName, to string, data any) error {
+	msg, err := n.renderer.Render(templateName, []string{to}, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s email: %w", templateName, err)
+	}
+
+	if err := n.queue.Enqueue(msg); err != nil {
+		return fmt.Errorf("failed to queue %s email: %w", templateName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// adjustmentColumns lists the adjustments table columns mapped by dto.AdjustmentDTO
+const adjustmentColumns = `id, user_id, point_type, direction, amount, reason, note, status, requested_by, reviewed_by,
+		review_note, entry_id, created_at, reviewed_at`
+
+// PostgresAdjustmentRepository implements the AdjustmentRepository interface
+type PostgresAdjustmentRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAdjustmentRepository creates a new PostgreSQL adjustment repository
+func NewPostgresAdjustmentRepository(db *sqlx.DB) *PostgresAdjustmentRepository {
+	return &PostgresAdjustmentRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new pending adjustment and sets its generated ID
+func (r *PostgresAdjustmentRepository) Create(ctx context.Context, adjustment *domain.Adjustment) error {
+	adjustmentDTO := dto.AdjustmentFromDomain(adjustment)
+
+	query := `
+		INSERT INTO adjustments (user_id, point_type, direction, amount, reason, note, status, requested_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	var generatedID string
+	err := r.db.QueryRowContext(ctx, query,
+		adjustmentDTO.UserID,
+		adjustmentDTO.PointType,
+		adjustmentDTO.Direction,
+		adjustmentDTO.Amount,
+		adjustmentDTO.Reason,
+		adjustmentDTO.Note,
+		adjustmentDTO.Status,
+		adjustmentDTO.RequestedBy,
+		adjustmentDTO.CreatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create adjustment: %w", err)
+	}
+
+	adjustment.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves an adjustment by ID
+func (r *PostgresAdjustmentRepository) GetByID(ctx context.Context, id string) (*domain.Adjustment, error) {
+	query := `
+		SELECT ` + adjustmentColumns + `
+		FROM adjustments
+		WHERE id = $1`
+
+	var adjustmentDTO dto.AdjustmentDTO
+	err := r.db.GetContext(ctx, &adjustmentDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAdjustmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get adjustment by ID: %w", err)
+	}
+
+	return adjustmentDTO.ToDomain(), nil
+}
+
+// List retrieves a paginated list of adjustments in the given status, or in any status if it is empty, oldest first
+func (r *PostgresAdjustmentRepository) List(ctx context.Context, status domain.AdjustmentStatus, limit, offset int) ([]*domain.Adjustment, error) {
+	query := `
+		SELECT ` + adjustmentColumns + `
+		FROM adjustments
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3`
+
+	var adjustmentDTOs []dto.AdjustmentDTO
+	if err := r.db.SelectContext(ctx, &adjustmentDTOs, query, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list adjustments: %w", err)
+	}
+
+	adjustments := make([]*domain.Adjustment, 0, len(adjustmentDTOs))
+	for _, adjustmentDTO := range adjustmentDTOs {
+		adjustments = append(adjustments, adjustmentDTO.ToDomain())
+	}
+	return adjustments, nil
+}
+
+// Approve records the approval and posts the adjustment's journal entry in one transaction.
+// It fails with ErrAdjustmentNotPending if the adjustment was reviewed concurrently.
+func (r *PostgresAdjustmentRepository) Approve(ctx context.Context, adjustment *domain.Adjustment, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := reviewAdjustment(ctx, tx, adjustment); err != nil {
+			return err
+		}
+
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		entryQuery := `UPDATE adjustments SET entry_id = $2 WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, entryQuery, adjustment.ID, entry.ID); err != nil {
+			return fmt.Errorf("failed to link adjustment entry: %w", err)
+		}
+
+		adjustment.EntryID = &entry.ID
+		return nil
+	})
+}
+
+// Reject records the rejection. It fails with ErrAdjustmentNotPending if the adjustment was reviewed concurrently.
+func (r *PostgresAdjustmentRepository) Reject(ctx context.Context, adjustment *domain.Adjustment) error {
+	return reviewAdjustment(ctx, r.db, adjustment)
+}
+
+// reviewAdjustment saves the outcome of reviewing an adjustment that is still pending
+func reviewAdjustment(ctx context.Context, q sqlx.ExtContext, adjustment *domain.Adjustment) error {
+	adjustmentDTO := dto.AdjustmentFromDomain(adjustment)
+
+	query := `
+		UPDATE adjustments
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5
+		WHERE id = $1 AND status = $6`
+
+	result, err := q.ExecContext(ctx, query,
+		adjustmentDTO.ID,
+		adjustmentDTO.Status,
+		adjustmentDTO.ReviewedBy,
+		adjustmentDTO.ReviewNote,
+		adjustmentDTO.ReviewedAt,
+		domain.AdjustmentPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to review adjustment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAdjustmentNotPending
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// PostgresAPIKeyRepository implements the APIKeyRepository interface
+type PostgresAPIKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAPIKeyRepository creates a new PostgreSQL API key repository
+func NewPostgresAPIKeyRepository(db *sqlx.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new API key and sets its generated ID
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	keyDTO := dto.APIKeyFromDomain(key)
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, owner_user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	var generatedID string
+	err := r.db.QueryRowContext(ctx, query,
+		keyDTO.Name,
+		keyDTO.Prefix,
+		keyDTO.KeyHash,
+		keyDTO.Scopes,
+		keyDTO.OwnerUserID,
+		keyDTO.ExpiresAt,
+		keyDTO.CreatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	key.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *PostgresAPIKeyRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, owner_user_id, expires_at, revoked_at, created_at
+		FROM api_keys
+		WHERE id = $1`
+
+	var keyDTO dto.APIKeyDTO
+	err := r.db.GetContext(ctx, &keyDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key by ID: %w", err)
+	}
+
+	return keyDTO.ToDomain(), nil
+}
+
+// GetByHash retrieves an API key by the hash of its secret
+func (r *PostgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, owner_user_id, expires_at, revoked_at, created_at
+		FROM api_keys
+		WHERE key_hash = $1`
+
+	var keyDTO dto.APIKeyDTO
+	err := r.db.GetContext(ctx, &keyDTO, query, keyHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get API key by hash: %w", err)
+	}
+
+	return keyDTO.ToDomain(), nil
+}
+
+// List retrieves a paginated list of API keys
+func (r *PostgresAPIKeyRepository) List(ctx context.Context, limit, offset int) ([]*domain.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, owner_user_id, expires_at, revoked_at, created_at
+		FROM api_keys
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var keyDTOs []dto.APIKeyDTO
+	if err := r.db.SelectContext(ctx, &keyDTOs, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, 0, len(keyDTOs))
+	for _, keyDTO := range keyDTOs {
+		keys = append(keys, keyDTO.ToDomain())
+	}
+	return keys, nil
+}
+
+// Revoke marks an API key as revoked
+func (r *PostgresAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
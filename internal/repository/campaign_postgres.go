@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// campaignColumns lists the campaigns table columns mapped by dto.CampaignDTO
+const campaignColumns = `id, name, description, starts_at, ends_at, audience, multiplier, bonus, budget, spent,
+		is_active, created_at, updated_at`
+
+// campaignSpentConstraint is the check keeping a campaign's spent credits within its budget
+const campaignSpentConstraint = "check_campaigns_spent"
+
+// PostgresCampaignRepository implements the CampaignRepository interface
+type PostgresCampaignRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCampaignRepository creates a new PostgreSQL campaign repository
+func NewPostgresCampaignRepository(db *sqlx.DB) *PostgresCampaignRepository {
+	return &PostgresCampaignRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new campaign and sets its generated ID
+func (r *PostgresCampaignRepository) Create(ctx context.Context, campaign *domain.Campaign) error {
+	campaignDTO, err := dto.CampaignFromDomain(campaign)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO campaigns (name, description, starts_at, ends_at, audience, multiplier, bonus, budget,
+			is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	var generatedID string
+	err = r.db.QueryRowContext(ctx, query,
+		campaignDTO.Name,
+		campaignDTO.Description,
+		campaignDTO.StartsAt,
+		campaignDTO.EndsAt,
+		campaignDTO.Audience,
+		campaignDTO.Multiplier,
+		campaignDTO.Bonus,
+		campaignDTO.Budget,
+		campaignDTO.IsActive,
+		campaignDTO.CreatedAt,
+		campaignDTO.UpdatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	campaign.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves a campaign by ID, including the credits it has spent so far
+func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		WHERE id = $1`
+
+	var campaignDTO dto.CampaignDTO
+	err := r.db.GetContext(ctx, &campaignDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign by ID: %w", err)
+	}
+
+	return campaignDTO.ToDomain()
+}
+
+// Update saves a campaign's settings and refreshes the credits it has spent. It fails with
+// ErrInvalidCampaignBudget if awards spent more than the new budget in the meantime.
+func (r *PostgresCampaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	campaignDTO, err := dto.CampaignFromDomain(campaign)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE campaigns
+		SET name = $2, description = $3, starts_at = $4, ends_at = $5, audience = $6, multiplier = $7, bonus = $8,
+			budget = $9, is_active = $10, updated_at = $11
+		WHERE id = $1
+		RETURNING spent`
+
+	var spent int64
+	err = r.db.QueryRowContext(ctx, query,
+		campaignDTO.ID,
+		campaignDTO.Name,
+		campaignDTO.Description,
+		campaignDTO.StartsAt,
+		campaignDTO.EndsAt,
+		campaignDTO.Audience,
+		campaignDTO.Multiplier,
+		campaignDTO.Bonus,
+		campaignDTO.Budget,
+		campaignDTO.IsActive,
+		campaignDTO.UpdatedAt,
+	).Scan(&spent)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrCampaignNotFound
+		}
+		if isCheckViolation(err, campaignSpentConstraint) {
+			return domain.ErrInvalidCampaignBudget
+		}
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	campaign.Spent = spent
+	return nil
+}
+
+// List retrieves a paginated list of campaigns, newest first
+func (r *PostgresCampaignRepository) List(ctx context.Context, limit, offset int) ([]*domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var campaignDTOs []dto.CampaignDTO
+	if err := r.db.SelectContext(ctx, &campaignDTOs, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	return campaignsToDomain(campaignDTOs)
+}
+
+// ListActive retrieves the enabled campaigns whose time window contains at and whose budget is not spent
+func (r *PostgresCampaignRepository) ListActive(ctx context.Context, at time.Time) ([]*domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		WHERE is_active AND starts_at <= $1 AND ends_at > $1 AND spent < budget
+		ORDER BY created_at`
+
+	var campaignDTOs []dto.CampaignDTO
+	if err := r.db.SelectContext(ctx, &campaignDTOs, query, at); err != nil {
+		return nil, fmt.Errorf("failed to list active campaigns: %w", err)
+	}
+
+	return campaignsToDomain(campaignDTOs)
+}
+
+// campaignsToDomain converts campaign DTOs to domain campaigns
+func campaignsToDomain(campaignDTOs []dto.CampaignDTO) ([]*domain.Campaign, error) {
+	campaigns := make([]*domain.Campaign, 0, len(campaignDTOs))
+	for _, campaignDTO := range campaignDTOs {
+		campaign, err := campaignDTO.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, nil
+}
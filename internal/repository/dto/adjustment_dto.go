@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// AdjustmentDTO represents the data transfer object for adjustments in the repository layer
+type AdjustmentDTO struct {
+	ID          string     `db:"id"`
+	UserID      string     `db:"user_id"`
+	PointType   string     `db:"point_type"`
+	Direction   string     `db:"direction"`
+	Amount      int64      `db:"amount"`
+	Reason      string     `db:"reason"`
+	Note        string     `db:"note"`
+	Status      string     `db:"status"`
+	RequestedBy string     `db:"requested_by"`
+	ReviewedBy  *string    `db:"reviewed_by"`
+	ReviewNote  string     `db:"review_note"`
+	EntryID     *string    `db:"entry_id"`
+	CreatedAt   time.Time  `db:"created_at"`
+	ReviewedAt  *time.Time `db:"reviewed_at"`
+}
+
+// ToDomain converts AdjustmentDTO to domain.Adjustment
+func (dto *AdjustmentDTO) ToDomain() *domain.Adjustment {
+	return &domain.Adjustment{
+		ID:          dto.ID,
+		UserID:      dto.UserID,
+		PointType:   domain.PointType(dto.PointType),
+		Direction:   domain.AdjustmentDirection(dto.Direction),
+		Amount:      dto.Amount,
+		Reason:      domain.AdjustmentReason(dto.Reason),
+		Note:        dto.Note,
+		Status:      domain.AdjustmentStatus(dto.Status),
+		RequestedBy: dto.RequestedBy,
+		ReviewedBy:  dto.ReviewedBy,
+		ReviewNote:  dto.ReviewNote,
+		EntryID:     dto.EntryID,
+		CreatedAt:   dto.CreatedAt,
+		ReviewedAt:  dto.ReviewedAt,
+	}
+}
+
+// AdjustmentFromDomain creates AdjustmentDTO from domain.Adjustment
+func AdjustmentFromDomain(adjustment *domain.Adjustment) *AdjustmentDTO {
+	return &AdjustmentDTO{
+		ID:          adjustment.ID,
+		UserID:      adjustment.UserID,
+		PointType:   string(adjustment.PointType),
+		Direction:   string(adjustment.Direction),
+		Amount:      adjustment.Amount,
+		Reason:      string(adjustment.Reason),
+		Note:        adjustment.Note,
+		Status:      string(adjustment.Status),
+		RequestedBy: adjustment.RequestedBy,
+		ReviewedBy:  adjustment.ReviewedBy,
+		ReviewNote:  adjustment.ReviewNote,
+		EntryID:     adjustment.EntryID,
+		CreatedAt:   adjustment.CreatedAt,
+		ReviewedAt:  adjustment.ReviewedAt,
+	}
+}
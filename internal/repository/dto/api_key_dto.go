@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// APIKeyDTO represents the data transfer object for API keys in the repository layer
+type APIKeyDTO struct {
+	ID          string         `db:"id"`
+	Name        string         `db:"name"`
+	Prefix      string         `db:"prefix"`
+	KeyHash     string         `db:"key_hash"`
+	Scopes      pq.StringArray `db:"scopes"`
+	OwnerUserID string         `db:"owner_user_id"`
+	ExpiresAt   *time.Time     `db:"expires_at"`
+	RevokedAt   *time.Time     `db:"revoked_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// ToDomain converts APIKeyDTO to domain.APIKey
+func (dto *APIKeyDTO) ToDomain() *domain.APIKey {
+	return &domain.APIKey{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Prefix:      dto.Prefix,
+		KeyHash:     dto.KeyHash,
+		Scopes:      []string(dto.Scopes),
+		OwnerUserID: dto.OwnerUserID,
+		ExpiresAt:   dto.ExpiresAt,
+		RevokedAt:   dto.RevokedAt,
+		CreatedAt:   dto.CreatedAt,
+	}
+}
+
+// APIKeyFromDomain creates APIKeyDTO from domain.APIKey
+func APIKeyFromDomain(key *domain.APIKey) *APIKeyDTO {
+	return &APIKeyDTO{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		KeyHash:     key.KeyHash,
+		Scopes:      pq.StringArray(key.Scopes),
+		OwnerUserID: key.OwnerUserID,
+		ExpiresAt:   key.ExpiresAt,
+		RevokedAt:   key.RevokedAt,
+		CreatedAt:   key.CreatedAt,
+	}
+}
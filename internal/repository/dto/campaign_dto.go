@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// CampaignDTO represents the data transfer object for campaigns in the repository layer
+type CampaignDTO struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	StartsAt    time.Time `db:"starts_at"`
+	EndsAt      time.Time `db:"ends_at"`
+	Audience    []byte    `db:"audience"`
+	Multiplier  int64     `db:"multiplier"`
+	Bonus       int64     `db:"bonus"`
+	Budget      int64     `db:"budget"`
+	Spent       int64     `db:"spent"`
+	IsActive    bool      `db:"is_active"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToDomain converts CampaignDTO to domain.Campaign
+func (dto *CampaignDTO) ToDomain() (*domain.Campaign, error) {
+	var audience domain.CampaignAudience
+	if err := json.Unmarshal(dto.Audience, &audience); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign audience: %w", err)
+	}
+
+	return &domain.Campaign{
+		ID: dto.ID,
+		CampaignSpec: domain.CampaignSpec{
+			Name:        dto.Name,
+			Description: dto.Description,
+			StartsAt:    dto.StartsAt,
+			EndsAt:      dto.EndsAt,
+			Audience:    audience,
+			Multiplier:  dto.Multiplier,
+			Bonus:       dto.Bonus,
+			Budget:      dto.Budget,
+			IsActive:    dto.IsActive,
+		},
+		Spent:     dto.Spent,
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+	}, nil
+}
+
+// CampaignFromDomain creates CampaignDTO from domain.Campaign
+func CampaignFromDomain(campaign *domain.Campaign) (*CampaignDTO, error) {
+	audience, err := json.Marshal(campaign.Audience)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode campaign audience: %w", err)
+	}
+
+	return &CampaignDTO{
+		ID:          campaign.ID,
+		Name:        campaign.Name,
+		Description: campaign.Description,
+		StartsAt:    campaign.StartsAt,
+		EndsAt:      campaign.EndsAt,
+		Audience:    audience,
+		Multiplier:  campaign.Multiplier,
+		Bonus:       campaign.Bonus,
+		Budget:      campaign.Budget,
+		Spent:       campaign.Spent,
+		IsActive:    campaign.IsActive,
+		CreatedAt:   campaign.CreatedAt,
+		UpdatedAt:   campaign.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// EarnRuleDTO represents the data transfer object for earn rules in the repository layer
+type EarnRuleDTO struct {
+	ID               string     `db:"id"`
+	Name             string     `db:"name"`
+	EventType        string     `db:"event_type"`
+	Conditions       []byte     `db:"conditions"`
+	AwardType        string     `db:"award_type"`
+	Amount           int64      `db:"amount"`
+	PointType        string     `db:"point_type"`
+	BaseAttribute    string     `db:"base_attribute"`
+	MaxAward         *int64     `db:"max_award"`
+	MaxAwardsPerUser *int       `db:"max_awards_per_user"`
+	StartsAt         *time.Time `db:"starts_at"`
+	EndsAt           *time.Time `db:"ends_at"`
+	IsActive         bool       `db:"is_active"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+}
+
+// ToDomain converts EarnRuleDTO to domain.EarnRule
+func (dto *EarnRuleDTO) ToDomain() (*domain.EarnRule, error) {
+	var conditions []domain.RuleCondition
+	if err := json.Unmarshal(dto.Conditions, &conditions); err != nil {
+		return nil, fmt.Errorf("failed to decode earn rule conditions: %w", err)
+	}
+
+	return &domain.EarnRule{
+		ID: dto.ID,
+		EarnRuleSpec: domain.EarnRuleSpec{
+			Name:             dto.Name,
+			EventType:        dto.EventType,
+			Conditions:       conditions,
+			AwardType:        domain.AwardType(dto.AwardType),
+			Amount:           dto.Amount,
+			PointType:        domain.PointType(dto.PointType),
+			BaseAttribute:    dto.BaseAttribute,
+			MaxAward:         dto.MaxAward,
+			MaxAwardsPerUser: dto.MaxAwardsPerUser,
+			StartsAt:         dto.StartsAt,
+			EndsAt:           dto.EndsAt,
+			IsActive:         dto.IsActive,
+		},
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+	}, nil
+}
+
+// EarnRuleFromDomain creates EarnRuleDTO from domain.EarnRule
+func EarnRuleFromDomain(rule *domain.EarnRule) (*EarnRuleDTO, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode earn rule conditions: %w", err)
+	}
+
+	return &EarnRuleDTO{
+		ID:               rule.ID,
+		Name:             rule.Name,
+		EventType:        rule.EventType,
+		Conditions:       conditions,
+		AwardType:        string(rule.AwardType),
+		Amount:           rule.Amount,
+		PointType:        string(rule.PointType),
+		BaseAttribute:    rule.BaseAttribute,
+		MaxAward:         rule.MaxAward,
+		MaxAwardsPerUser: rule.MaxAwardsPerUser,
+		StartsAt:         rule.StartsAt,
+		EndsAt:           rule.EndsAt,
+		IsActive:         rule.IsActive,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// EmailChangeRequestDTO represents the data transfer object for email change requests in the repository layer
+type EmailChangeRequestDTO struct {
+	ID              string     `db:"id"`
+	UserID          string     `db:"user_id"`
+	OldEmail        string     `db:"old_email"`
+	NewEmail        string     `db:"new_email"`
+	CodeHash        string     `db:"code_hash"`
+	RevertTokenHash string     `db:"revert_token_hash"`
+	Attempts        int        `db:"attempts"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+	RevertExpiresAt time.Time  `db:"revert_expires_at"`
+	ConfirmedAt     *time.Time `db:"confirmed_at"`
+	RevertedAt      *time.Time `db:"reverted_at"`
+	CancelledAt     *time.Time `db:"cancelled_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// ToDomain converts EmailChangeRequestDTO to domain.EmailChangeRequest
+func (dto *EmailChangeRequestDTO) ToDomain() *domain.EmailChangeRequest {
+	return &domain.EmailChangeRequest{
+		ID:              dto.ID,
+		UserID:          dto.UserID,
+		OldEmail:        dto.OldEmail,
+		NewEmail:        dto.NewEmail,
+		CodeHash:        dto.CodeHash,
+		RevertTokenHash: dto.RevertTokenHash,
+		Attempts:        dto.Attempts,
+		ExpiresAt:       dto.ExpiresAt,
+		RevertExpiresAt: dto.RevertExpiresAt,
+		ConfirmedAt:     dto.ConfirmedAt,
+		RevertedAt:      dto.RevertedAt,
+		CancelledAt:     dto.CancelledAt,
+		CreatedAt:       dto.CreatedAt,
+	}
+}
+
+// EmailChangeRequestFromDomain creates EmailChangeRequestDTO from domain.EmailChangeRequest
+func EmailChangeRequestFromDomain(req *domain.EmailChangeRequest) *EmailChangeRequestDTO {
+	return &EmailChangeRequestDTO{
+		ID:              req.ID,
+		UserID:          req.UserID,
+		OldEmail:        req.OldEmail,
+		NewEmail:        req.NewEmail,
+		CodeHash:        req.CodeHash,
+		RevertTokenHash: req.RevertTokenHash,
+		Attempts:        req.Attempts,
+		ExpiresAt:       req.ExpiresAt,
+		RevertExpiresAt: req.RevertExpiresAt,
+		ConfirmedAt:     req.ConfirmedAt,
+		RevertedAt:      req.RevertedAt,
+		CancelledAt:     req.CancelledAt,
+		CreatedAt:       req.CreatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// IdempotencyRecordDTO represents the data transfer object for idempotency keys in the repository layer
+type IdempotencyRecordDTO struct {
+	Scope        string     `db:"scope"`
+	Key          string     `db:"key"`
+	Fingerprint  string     `db:"fingerprint"`
+	StatusCode   *int       `db:"status_code"`
+	ContentType  string     `db:"content_type"`
+	ResponseBody []byte     `db:"response_body"`
+	CompletedAt  *time.Time `db:"completed_at"`
+	ExpiresAt    time.Time  `db:"expires_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// ToDomain converts IdempotencyRecordDTO to domain.IdempotencyRecord
+func (dto *IdempotencyRecordDTO) ToDomain() *domain.IdempotencyRecord {
+	record := &domain.IdempotencyRecord{
+		Scope:        dto.Scope,
+		Key:          dto.Key,
+		Fingerprint:  dto.Fingerprint,
+		ContentType:  dto.ContentType,
+		ResponseBody: dto.ResponseBody,
+		CompletedAt:  dto.CompletedAt,
+		ExpiresAt:    dto.ExpiresAt,
+		CreatedAt:    dto.CreatedAt,
+	}
+
+	if dto.StatusCode != nil {
+		record.StatusCode = *dto.StatusCode
+	}
+
+	return record
+}
@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// LedgerAccountDTO represents the data transfer object for ledger accounts in the repository layer
+type LedgerAccountDTO struct {
+	ID            string    `db:"id"`
+	Type          string    `db:"type"`
+	PointType     string    `db:"point_type"`
+	UserID        *string   `db:"user_id"`
+	Balance       int64     `db:"balance"`
+	AllowNegative bool      `db:"allow_negative"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// ToDomain converts LedgerAccountDTO to domain.LedgerAccount
+func (dto *LedgerAccountDTO) ToDomain() *domain.LedgerAccount {
+	return &domain.LedgerAccount{
+		ID:            dto.ID,
+		Type:          domain.AccountType(dto.Type),
+		PointType:     domain.PointType(dto.PointType),
+		UserID:        dto.UserID,
+		Balance:       dto.Balance,
+		AllowNegative: dto.AllowNegative,
+		CreatedAt:     dto.CreatedAt,
+	}
+}
+
+// JournalEntryDTO represents the data transfer object for journal entries in the repository layer
+type JournalEntryDTO struct {
+	ID          string    `db:"id"`
+	Kind        string    `db:"kind"`
+	PointType   string    `db:"point_type"`
+	UserID      string    `db:"user_id"`
+	Reference   *string   `db:"reference"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// ToDomain converts JournalEntryDTO and its postings to domain.JournalEntry
+func (dto *JournalEntryDTO) ToDomain(postings []PostingDTO) *domain.JournalEntry {
+	entry := &domain.JournalEntry{
+		ID:          dto.ID,
+		Kind:        domain.EntryKind(dto.Kind),
+		PointType:   domain.PointType(dto.PointType),
+		UserID:      dto.UserID,
+		Reference:   dto.Reference,
+		Description: dto.Description,
+		Postings:    make([]domain.Posting, 0, len(postings)),
+		CreatedAt:   dto.CreatedAt,
+	}
+
+	for _, posting := range postings {
+		entry.Postings = append(entry.Postings, posting.ToDomain())
+	}
+
+	return entry
+}
+
+// PostingDTO represents the data transfer object for ledger postings in the repository layer
+type PostingDTO struct {
+	ID           int64     `db:"id"`
+	EntryID      string    `db:"entry_id"`
+	AccountID    string    `db:"account_id"`
+	Amount       int64     `db:"amount"`
+	BalanceAfter int64     `db:"balance_after"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// ToDomain converts PostingDTO to domain.Posting
+func (dto *PostingDTO) ToDomain() domain.Posting {
+	return domain.Posting{
+		ID:           dto.ID,
+		EntryID:      dto.EntryID,
+		AccountID:    dto.AccountID,
+		Amount:       dto.Amount,
+		BalanceAfter: dto.BalanceAfter,
+		CreatedAt:    dto.CreatedAt,
+	}
+}
+
+// CreditLotDTO represents the data transfer object for credit lots in the repository layer
+type CreditLotDTO struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	PointType string     `db:"point_type"`
+	EntryID   *string    `db:"entry_id"`
+	Amount    int64      `db:"amount"`
+	Remaining int64      `db:"remaining"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// ToDomain converts CreditLotDTO to domain.CreditLot
+func (dto *CreditLotDTO) ToDomain() *domain.CreditLot {
+	return &domain.CreditLot{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		PointType: domain.PointType(dto.PointType),
+		EntryID:   dto.EntryID,
+		Amount:    dto.Amount,
+		Remaining: dto.Remaining,
+		ExpiresAt: dto.ExpiresAt,
+		CreatedAt: dto.CreatedAt,
+	}
+}
+
+// TransactionDTO represents a posting to a user's account joined with its journal entry in the repository layer
+type TransactionDTO struct {
+	PostingID    int64     `db:"posting_id"`
+	EntryID      string    `db:"entry_id"`
+	Kind         string    `db:"kind"`
+	PointType    string    `db:"point_type"`
+	Reference    *string   `db:"reference"`
+	Description  string    `db:"description"`
+	Amount       int64     `db:"amount"`
+	BalanceAfter int64     `db:"balance_after"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// ToDomain converts TransactionDTO to domain.Transaction
+func (dto *TransactionDTO) ToDomain() *domain.Transaction {
+	return &domain.Transaction{
+		PostingID:    dto.PostingID,
+		EntryID:      dto.EntryID,
+		Kind:         domain.EntryKind(dto.Kind),
+		PointType:    domain.PointType(dto.PointType),
+		Source:       domain.EntrySource(dto.Reference),
+		Description:  dto.Description,
+		Amount:       dto.Amount,
+		BalanceAfter: dto.BalanceAfter,
+		CreatedAt:    dto.CreatedAt,
+	}
+}
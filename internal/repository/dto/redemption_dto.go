@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RedemptionDTO represents the data transfer object for redemptions in the repository layer
+type RedemptionDTO struct {
+	ID            string     `db:"id"`
+	UserID        string     `db:"user_id"`
+	RewardID      string     `db:"reward_id"`
+	Cost          int64      `db:"cost"`
+	PointType     string     `db:"point_type"`
+	Status        string     `db:"status"`
+	HoldEntryID   string     `db:"hold_entry_id"`
+	SettleEntryID *string    `db:"settle_entry_id"`
+	ExpiresAt     time.Time  `db:"expires_at"`
+	SettledAt     *time.Time `db:"settled_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+// ToDomain converts RedemptionDTO to domain.Redemption
+func (dto *RedemptionDTO) ToDomain() *domain.Redemption {
+	return &domain.Redemption{
+		ID:            dto.ID,
+		UserID:        dto.UserID,
+		RewardID:      dto.RewardID,
+		Cost:          dto.Cost,
+		PointType:     domain.PointType(dto.PointType),
+		Status:        domain.RedemptionStatus(dto.Status),
+		HoldEntryID:   dto.HoldEntryID,
+		SettleEntryID: dto.SettleEntryID,
+		ExpiresAt:     dto.ExpiresAt,
+		SettledAt:     dto.SettledAt,
+		CreatedAt:     dto.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReferralDTO represents the data transfer object for referrals in the repository layer
+type ReferralDTO struct {
+	ID                 string     `db:"id"`
+	ReferrerID         string     `db:"referrer_id"`
+	RefereeID          string     `db:"referee_id"`
+	RefereeName        string     `db:"referee_name"` // joined from the users table
+	RefereeEmailDomain string     `db:"referee_email_domain"`
+	Status             string     `db:"status"`
+	ReferrerEntryID    *string    `db:"referrer_entry_id"`
+	RefereeEntryID     *string    `db:"referee_entry_id"`
+	CreatedAt          time.Time  `db:"created_at"`
+	RewardedAt         *time.Time `db:"rewarded_at"`
+}
+
+// ToDomain converts ReferralDTO to domain.Referral
+func (dto *ReferralDTO) ToDomain() *domain.Referral {
+	return &domain.Referral{
+		ID:                 dto.ID,
+		ReferrerID:         dto.ReferrerID,
+		RefereeID:          dto.RefereeID,
+		RefereeName:        dto.RefereeName,
+		RefereeEmailDomain: dto.RefereeEmailDomain,
+		Status:             domain.ReferralStatus(dto.Status),
+		ReferrerEntryID:    dto.ReferrerEntryID,
+		RefereeEntryID:     dto.RefereeEntryID,
+		CreatedAt:          dto.CreatedAt,
+		RewardedAt:         dto.RewardedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RefreshTokenDTO represents the data transfer object for refresh tokens in the repository layer
+type RefreshTokenDTO struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// ToDomain converts RefreshTokenDTO to domain.RefreshToken
+func (dto *RefreshTokenDTO) ToDomain() *domain.RefreshToken {
+	return &domain.RefreshToken{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		TokenHash: dto.TokenHash,
+		ExpiresAt: dto.ExpiresAt,
+		RevokedAt: dto.RevokedAt,
+		CreatedAt: dto.CreatedAt,
+	}
+}
+
+// RefreshTokenFromDomain creates RefreshTokenDTO from domain.RefreshToken
+func RefreshTokenFromDomain(token *domain.RefreshToken) *RefreshTokenDTO {
+	return &RefreshTokenDTO{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
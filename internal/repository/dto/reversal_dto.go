@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReversalDTO represents the data transfer object for reversals in the repository layer
+type ReversalDTO struct {
+	ID              string    `db:"id"`
+	EntryID         string    `db:"entry_id"`
+	EntryKind       string    `db:"entry_kind"`
+	UserID          string    `db:"user_id"`
+	PointType       string    `db:"point_type"`
+	Amount          int64     `db:"amount"`
+	Posted          int64     `db:"posted"`
+	ReversalEntryID *string   `db:"reversal_entry_id"`
+	Reason          string    `db:"reason"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+// ToDomain converts ReversalDTO to domain.Reversal
+func (dto *ReversalDTO) ToDomain() *domain.Reversal {
+	return &domain.Reversal{
+		ID:              dto.ID,
+		EntryID:         dto.EntryID,
+		EntryKind:       domain.EntryKind(dto.EntryKind),
+		UserID:          dto.UserID,
+		PointType:       domain.PointType(dto.PointType),
+		Amount:          dto.Amount,
+		Posted:          dto.Posted,
+		ReversalEntryID: dto.ReversalEntryID,
+		Reason:          dto.Reason,
+		CreatedAt:       dto.CreatedAt,
+	}
+}
+
+// ReversalFromDomain creates ReversalDTO from domain.Reversal
+func ReversalFromDomain(reversal *domain.Reversal) *ReversalDTO {
+	return &ReversalDTO{
+		ID:              reversal.ID,
+		EntryID:         reversal.EntryID,
+		EntryKind:       string(reversal.EntryKind),
+		UserID:          reversal.UserID,
+		PointType:       string(reversal.PointType),
+		Amount:          reversal.Amount,
+		Posted:          reversal.Posted,
+		ReversalEntryID: reversal.ReversalEntryID,
+		Reason:          reversal.Reason,
+		CreatedAt:       reversal.CreatedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RewardDTO represents the data transfer object for rewards in the repository layer
+type RewardDTO struct {
+	ID          string     `db:"id"`
+	Name        string     `db:"name"`
+	Description string     `db:"description"`
+	Cost        int64      `db:"cost"`
+	PointType   string     `db:"point_type"`
+	Stock       int        `db:"stock"`
+	Status      string     `db:"status"`
+	StartsAt    *time.Time `db:"starts_at"`
+	EndsAt      *time.Time `db:"ends_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+// ToDomain converts RewardDTO to domain.Reward
+func (dto *RewardDTO) ToDomain() *domain.Reward {
+	return &domain.Reward{
+		ID: dto.ID,
+		RewardSpec: domain.RewardSpec{
+			Name:        dto.Name,
+			Description: dto.Description,
+			Cost:        dto.Cost,
+			PointType:   domain.PointType(dto.PointType),
+			Stock:       dto.Stock,
+			Status:      domain.RewardStatus(dto.Status),
+			StartsAt:    dto.StartsAt,
+			EndsAt:      dto.EndsAt,
+		},
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+	}
+}
+
+// RewardFromDomain creates RewardDTO from domain.Reward
+func RewardFromDomain(reward *domain.Reward) *RewardDTO {
+	return &RewardDTO{
+		ID:          reward.ID,
+		Name:        reward.Name,
+		Description: reward.Description,
+		Cost:        reward.Cost,
+		PointType:   string(reward.PointType),
+		Stock:       reward.Stock,
+		Status:      string(reward.Status),
+		StartsAt:    reward.StartsAt,
+		EndsAt:      reward.EndsAt,
+		CreatedAt:   reward.CreatedAt,
+		UpdatedAt:   reward.UpdatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// TierDTO represents the data transfer object for tiers in the repository layer
+type TierDTO struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Threshold   int64     `db:"threshold"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToDomain converts TierDTO to domain.Tier
+func (dto *TierDTO) ToDomain() *domain.Tier {
+	return &domain.Tier{
+		ID: dto.ID,
+		TierSpec: domain.TierSpec{
+			Name:        dto.Name,
+			Description: dto.Description,
+			Threshold:   dto.Threshold,
+		},
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+	}
+}
+
+// TierFromDomain creates TierDTO from domain.Tier
+func TierFromDomain(tier *domain.Tier) *TierDTO {
+	return &TierDTO{
+		ID:          tier.ID,
+		Name:        tier.Name,
+		Description: tier.Description,
+		Threshold:   tier.Threshold,
+		CreatedAt:   tier.CreatedAt,
+		UpdatedAt:   tier.UpdatedAt,
+	}
+}
+
+// TierStandingDTO represents a user's current tier and qualifying points in the repository layer
+type TierStandingDTO struct {
+	UserID           string  `db:"user_id"`
+	TierID           *string `db:"tier_id"`
+	QualifyingPoints int64   `db:"qualifying_points"`
+}
+
+// ToDomain converts TierStandingDTO to domain.TierStanding
+func (dto *TierStandingDTO) ToDomain() *domain.TierStanding {
+	return &domain.TierStanding{
+		UserID:           dto.UserID,
+		TierID:           dto.TierID,
+		QualifyingPoints: dto.QualifyingPoints,
+	}
+}
+
+// TierChangeDTO represents the data transfer object for tier history records in the repository layer
+type TierChangeDTO struct {
+	ID               string    `db:"id"`
+	UserID           string    `db:"user_id"`
+	FromTierID       *string   `db:"from_tier_id"`
+	FromTierName     *string   `db:"from_tier_name"`
+	ToTierID         *string   `db:"to_tier_id"`
+	ToTierName       *string   `db:"to_tier_name"`
+	QualifyingPoints int64     `db:"qualifying_points"`
+	Reason           string    `db:"reason"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// ToDomain converts TierChangeDTO to domain.TierChange
+func (dto *TierChangeDTO) ToDomain() *domain.TierChange {
+	return &domain.TierChange{
+		ID:               dto.ID,
+		UserID:           dto.UserID,
+		FromTier:         tierRefToDomain(dto.FromTierID, dto.FromTierName),
+		ToTier:           tierRefToDomain(dto.ToTierID, dto.ToTierName),
+		QualifyingPoints: dto.QualifyingPoints,
+		Reason:           domain.TierChangeReason(dto.Reason),
+		CreatedAt:        dto.CreatedAt,
+	}
+}
+
+// TierChangeFromDomain creates TierChangeDTO from domain.TierChange
+func TierChangeFromDomain(change *domain.TierChange) *TierChangeDTO {
+	changeDTO := &TierChangeDTO{
+		ID:               change.ID,
+		UserID:           change.UserID,
+		QualifyingPoints: change.QualifyingPoints,
+		Reason:           string(change.Reason),
+		CreatedAt:        change.CreatedAt,
+	}
+
+	if change.FromTier != nil {
+		changeDTO.FromTierID = &change.FromTier.ID
+		changeDTO.FromTierName = &change.FromTier.Name
+	}
+
+	if change.ToTier != nil {
+		changeDTO.ToTierID = &change.ToTier.ID
+		changeDTO.ToTierName = &change.ToTier.Name
+	}
+
+	return changeDTO
+}
+
+// tierRefToDomain converts the stored ID and name of a tier to a reference; the ID is gone once the tier is deleted
+func tierRefToDomain(id, name *string) *domain.TierRef {
+	if name == nil {
+		return nil
+	}
+
+	ref := &domain.TierRef{Name: *name}
+	if id != nil {
+		ref.ID = *id
+	}
+	return ref
+}
@@ -10,46 +10,80 @@ import (
 // This DTO is specifically designed for database operations and may include
 // database-specific fields that are not part of the domain model
 type UserDTO struct {
-	ID              string     `db:"id"`
-	Email           string     `db:"email"`
-	Name            string     `db:"name"`
-	IsEmailVerified bool       `db:"is_email_verified"`
-	IsActive        bool       `db:"is_active"`
-	OTP             *string    `db:"otp"`
-	OTPExpiresAt    *time.Time `db:"otp_expires_at"`
-	Role            string     `db:"role"`
-	CreatedAt       time.Time  `db:"created_at"`
-	UpdatedAt       time.Time  `db:"updated_at"`
+	ID                 string     `db:"id"`
+	Email              string     `db:"email"`
+	Name               string     `db:"name"`
+	IsEmailVerified    bool       `db:"is_email_verified"`
+	IsActive           bool       `db:"is_active"`
+	OTPHash            *string    `db:"otp_hash"`
+	OTPExpiresAt       *time.Time `db:"otp_expires_at"`
+	OTPAttempts        int        `db:"otp_attempts"`
+	OTPLockedUntil     *time.Time `db:"otp_locked_until"`
+	OTPLastSentAt      *time.Time `db:"otp_last_sent_at"`
+	DeactivatedAt      *time.Time `db:"deactivated_at"`
+	DeactivatedBy      *string    `db:"deactivated_by"`
+	DeactivationReason *string    `db:"deactivation_reason"`
+	Role               string     `db:"role"`
+	TierID             *string    `db:"tier_id"`   // only written by tier recalculation
+	TierName           *string    `db:"tier_name"` // joined from the tiers table
+	ReferralCode       string     `db:"referral_code"`
+	ReferredBy         *string    `db:"referred_by"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
 }
 
 // ToDomain converts UserDTO to domain.User
 func (dto *UserDTO) ToDomain() (*domain.User, error) {
-	return domain.NewUserWithID(
+	user, err := domain.NewUserWithID(
 		dto.ID,
 		dto.Email,
 		dto.Name,
 		domain.Role(dto.Role),
 		dto.IsEmailVerified,
 		dto.IsActive,
-		dto.OTP,
+		dto.OTPHash,
 		dto.OTPExpiresAt,
 		dto.CreatedAt,
 		dto.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.OTPAttempts = dto.OTPAttempts
+	user.OTPLockedUntil = dto.OTPLockedUntil
+	user.OTPLastSentAt = dto.OTPLastSentAt
+	user.DeactivatedAt = dto.DeactivatedAt
+	user.DeactivatedBy = dto.DeactivatedBy
+	user.DeactivationReason = dto.DeactivationReason
+	user.ReferralCode = dto.ReferralCode
+	user.ReferredBy = dto.ReferredBy
+	if dto.TierID != nil && dto.TierName != nil {
+		user.Tier = &domain.TierRef{ID: *dto.TierID, Name: *dto.TierName}
+	}
+	return user, nil
 }
 
 // FromDomain creates UserDTO from domain.User
 func FromDomain(user *domain.User) *UserDTO {
 	return &UserDTO{
-		ID:              user.ID,
-		Email:           user.Email,
-		Name:            user.Name,
-		IsEmailVerified: user.IsEmailVerified,
-		IsActive:        user.IsActive,
-		OTP:             user.OTP,
-		OTPExpiresAt:    user.OTPExpiresAt,
-		Role:            string(user.Role),
-		CreatedAt:       user.CreatedAt,
-		UpdatedAt:       user.UpdatedAt,
+		ID:                 user.ID,
+		Email:              user.Email,
+		Name:               user.Name,
+		IsEmailVerified:    user.IsEmailVerified,
+		IsActive:           user.IsActive,
+		OTPHash:            user.OTPHash,
+		OTPExpiresAt:       user.OTPExpiresAt,
+		OTPAttempts:        user.OTPAttempts,
+		OTPLockedUntil:     user.OTPLockedUntil,
+		OTPLastSentAt:      user.OTPLastSentAt,
+		DeactivatedAt:      user.DeactivatedAt,
+		DeactivatedBy:      user.DeactivatedBy,
+		DeactivationReason: user.DeactivationReason,
+		Role:               string(user.Role),
+		ReferralCode:       user.ReferralCode,
+		ReferredBy:         user.ReferredBy,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
 	}
 }
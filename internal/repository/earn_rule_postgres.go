@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// earnRuleColumns lists the earn_rules table columns mapped by dto.EarnRuleDTO
+const earnRuleColumns = `id, name, event_type, conditions, award_type, amount, point_type, base_attribute, max_award,
+		max_awards_per_user, starts_at, ends_at, is_active, created_at, updated_at`
+
+// PostgresEarnRuleRepository implements the EarnRuleRepository interface
+type PostgresEarnRuleRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresEarnRuleRepository creates a new PostgreSQL earn rule repository
+func NewPostgresEarnRuleRepository(db *sqlx.DB) *PostgresEarnRuleRepository {
+	return &PostgresEarnRuleRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new earn rule and sets its generated ID
+func (r *PostgresEarnRuleRepository) Create(ctx context.Context, rule *domain.EarnRule) error {
+	ruleDTO, err := dto.EarnRuleFromDomain(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO earn_rules (name, event_type, conditions, award_type, amount, point_type, base_attribute, max_award,
+			max_awards_per_user, starts_at, ends_at, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id`
+
+	var generatedID string
+	err = r.db.QueryRowContext(ctx, query,
+		ruleDTO.Name,
+		ruleDTO.EventType,
+		ruleDTO.Conditions,
+		ruleDTO.AwardType,
+		ruleDTO.Amount,
+		ruleDTO.PointType,
+		ruleDTO.BaseAttribute,
+		ruleDTO.MaxAward,
+		ruleDTO.MaxAwardsPerUser,
+		ruleDTO.StartsAt,
+		ruleDTO.EndsAt,
+		ruleDTO.IsActive,
+		ruleDTO.CreatedAt,
+		ruleDTO.UpdatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create earn rule: %w", err)
+	}
+
+	rule.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves an earn rule by ID
+func (r *PostgresEarnRuleRepository) GetByID(ctx context.Context, id string) (*domain.EarnRule, error) {
+	query := `
+		SELECT ` + earnRuleColumns + `
+		FROM earn_rules
+		WHERE id = $1`
+
+	var ruleDTO dto.EarnRuleDTO
+	err := r.db.GetContext(ctx, &ruleDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEarnRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get earn rule by ID: %w", err)
+	}
+
+	return ruleDTO.ToDomain()
+}
+
+// Update saves an earn rule's settings
+func (r *PostgresEarnRuleRepository) Update(ctx context.Context, rule *domain.EarnRule) error {
+	ruleDTO, err := dto.EarnRuleFromDomain(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE earn_rules
+		SET name = $2, event_type = $3, conditions = $4, award_type = $5, amount = $6, point_type = $7, base_attribute = $8,
+			max_award = $9, max_awards_per_user = $10, starts_at = $11, ends_at = $12, is_active = $13, updated_at = $14
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		ruleDTO.ID,
+		ruleDTO.Name,
+		ruleDTO.EventType,
+		ruleDTO.Conditions,
+		ruleDTO.AwardType,
+		ruleDTO.Amount,
+		ruleDTO.PointType,
+		ruleDTO.BaseAttribute,
+		ruleDTO.MaxAward,
+		ruleDTO.MaxAwardsPerUser,
+		ruleDTO.StartsAt,
+		ruleDTO.EndsAt,
+		ruleDTO.IsActive,
+		ruleDTO.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update earn rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrEarnRuleNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an earn rule; credits it already awarded stay in the ledger
+func (r *PostgresEarnRuleRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM earn_rules WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete earn rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrEarnRuleNotFound
+	}
+
+	return nil
+}
+
+// List retrieves a paginated list of earn rules
+func (r *PostgresEarnRuleRepository) List(ctx context.Context, limit, offset int) ([]*domain.EarnRule, error) {
+	query := `
+		SELECT ` + earnRuleColumns + `
+		FROM earn_rules
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var ruleDTOs []dto.EarnRuleDTO
+	if err := r.db.SelectContext(ctx, &ruleDTOs, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list earn rules: %w", err)
+	}
+
+	return earnRulesToDomain(ruleDTOs)
+}
+
+// ListActiveForEvent retrieves the enabled rules for an event type whose date window contains at
+func (r *PostgresEarnRuleRepository) ListActiveForEvent(ctx context.Context, eventType string, at time.Time) ([]*domain.EarnRule, error) {
+	query := `
+		SELECT ` + earnRuleColumns + `
+		FROM earn_rules
+		WHERE event_type = $1 AND is_active
+			AND (starts_at IS NULL OR starts_at <= $2)
+			AND (ends_at IS NULL OR ends_at > $2)
+		ORDER BY created_at`
+
+	var ruleDTOs []dto.EarnRuleDTO
+	if err := r.db.SelectContext(ctx, &ruleDTOs, query, eventType, at); err != nil {
+		return nil, fmt.Errorf("failed to list active earn rules: %w", err)
+	}
+
+	return earnRulesToDomain(ruleDTOs)
+}
+
+// CountAwards returns how many times a rule has awarded credits to a user
+func (r *PostgresEarnRuleRepository) CountAwards(ctx context.Context, ruleID, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM earn_rule_awards WHERE rule_id = $1 AND user_id = $2`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, ruleID, userID); err != nil {
+		return 0, fmt.Errorf("failed to count earn rule awards: %w", err)
+	}
+
+	return count, nil
+}
+
+// RecordAward posts the award's journal entry, spends any campaign bonus from the campaign's budget and
+// records the award in one transaction. Capped rules are locked first so concurrent events cannot exceed
+// the per-user limit, and it fails with ErrCampaignBudgetExhausted if the bonus no longer fits the budget.
+func (r *PostgresEarnRuleRepository) RecordAward(ctx context.Context, rule *domain.EarnRule, award *domain.EarnAward, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if rule.MaxAwardsPerUser != nil {
+			var lockedID string
+			lockQuery := `SELECT id FROM earn_rules WHERE id = $1 FOR UPDATE`
+			if err := tx.GetContext(ctx, &lockedID, lockQuery, rule.ID); err != nil {
+				if err == sql.ErrNoRows {
+					return domain.ErrEarnRuleNotFound
+				}
+				return fmt.Errorf("failed to lock earn rule: %w", err)
+			}
+
+			var count int
+			countQuery := `SELECT COUNT(*) FROM earn_rule_awards WHERE rule_id = $1 AND user_id = $2`
+			if err := tx.GetContext(ctx, &count, countQuery, rule.ID, award.UserID); err != nil {
+				return fmt.Errorf("failed to count earn rule awards: %w", err)
+			}
+
+			if count >= *rule.MaxAwardsPerUser {
+				return domain.ErrEarnRuleCapReached
+			}
+		}
+
+		if award.CampaignID != nil {
+			spendQuery := `
+				UPDATE campaigns
+				SET spent = spent + $2
+				WHERE id = $1 AND spent + $2 <= budget`
+
+			result, err := tx.ExecContext(ctx, spendQuery, *award.CampaignID, award.Bonus)
+			if err != nil {
+				return fmt.Errorf("failed to spend campaign budget: %w", err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get rows affected: %w", err)
+			}
+
+			if rowsAffected == 0 {
+				return domain.ErrCampaignBudgetExhausted
+			}
+		}
+
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		insertQuery := `
+			INSERT INTO earn_rule_awards (rule_id, user_id, entry_id, amount, campaign_id, bonus, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id`
+
+		var generatedID string
+		err := tx.QueryRowContext(ctx, insertQuery,
+			award.RuleID,
+			award.UserID,
+			entry.ID,
+			award.Amount,
+			award.CampaignID,
+			award.Bonus,
+			award.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to record earn rule award: %w", err)
+		}
+
+		award.ID = generatedID
+		award.EntryID = entry.ID
+		return nil
+	})
+}
+
+// earnRulesToDomain converts earn rule DTOs to domain rules
+func earnRulesToDomain(ruleDTOs []dto.EarnRuleDTO) ([]*domain.EarnRule, error) {
+	rules := make([]*domain.EarnRule, 0, len(ruleDTOs))
+	for _, ruleDTO := range ruleDTOs {
+		rule, err := ruleDTO.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// emailChangeColumns lists the columns selected for email change requests
+const emailChangeColumns = `id, user_id, old_email, new_email, code_hash, revert_token_hash, attempts,
+	expires_at, revert_expires_at, confirmed_at, reverted_at, cancelled_at, created_at`
+
+// PostgresEmailChangeRepository implements the EmailChangeRepository interface
+type PostgresEmailChangeRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresEmailChangeRepository creates a new PostgreSQL email change repository
+func NewPostgresEmailChangeRepository(db *sqlx.DB) *PostgresEmailChangeRepository {
+	return &PostgresEmailChangeRepository{
+		db: db,
+	}
+}
+
+// Create cancels any pending email change of the user and inserts the new one, setting its generated ID
+func (r *PostgresEmailChangeRepository) Create(ctx context.Context, req *domain.EmailChangeRequest) error {
+	reqDTO := dto.EmailChangeRequestFromDomain(req)
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		cancelQuery := `
+			UPDATE email_change_requests
+			SET cancelled_at = $2
+			WHERE user_id = $1 AND confirmed_at IS NULL AND reverted_at IS NULL AND cancelled_at IS NULL`
+
+		if _, err := tx.ExecContext(ctx, cancelQuery, reqDTO.UserID, reqDTO.CreatedAt); err != nil {
+			return fmt.Errorf("failed to cancel pending email changes: %w", err)
+		}
+
+		insertQuery := `
+			INSERT INTO email_change_requests (user_id, old_email, new_email, code_hash, revert_token_hash,
+				expires_at, revert_expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`
+
+		var generatedID string
+		err := tx.QueryRowContext(ctx, insertQuery,
+			reqDTO.UserID,
+			reqDTO.OldEmail,
+			reqDTO.NewEmail,
+			reqDTO.CodeHash,
+			reqDTO.RevertTokenHash,
+			reqDTO.ExpiresAt,
+			reqDTO.RevertExpiresAt,
+			reqDTO.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to create email change request: %w", err)
+		}
+
+		req.ID = generatedID
+		return nil
+	})
+}
+
+// GetPendingByUserID retrieves the pending email change of a user
+func (r *PostgresEmailChangeRepository) GetPendingByUserID(ctx context.Context, userID string) (*domain.EmailChangeRequest, error) {
+	query := `
+		SELECT ` + emailChangeColumns + `
+		FROM email_change_requests
+		WHERE user_id = $1 AND confirmed_at IS NULL AND reverted_at IS NULL AND cancelled_at IS NULL`
+
+	var reqDTO dto.EmailChangeRequestDTO
+	err := r.db.GetContext(ctx, &reqDTO, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEmailChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get pending email change: %w", err)
+	}
+
+	return reqDTO.ToDomain(), nil
+}
+
+// GetByRevertTokenHash retrieves an email change by the hash of its revert token
+func (r *PostgresEmailChangeRepository) GetByRevertTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChangeRequest, error) {
+	query := `
+		SELECT ` + emailChangeColumns + `
+		FROM email_change_requests
+		WHERE revert_token_hash = $1`
+
+	var reqDTO dto.EmailChangeRequestDTO
+	err := r.db.GetContext(ctx, &reqDTO, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get email change by revert token: %w", err)
+	}
+
+	return reqDTO.ToDomain(), nil
+}
+
+// IncrementAttempts atomically records a confirmation attempt and returns the new attempt count
+func (r *PostgresEmailChangeRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	query := `
+		UPDATE email_change_requests
+		SET attempts = attempts + 1
+		WHERE id = $1
+		RETURNING attempts`
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrEmailChangeNotFound
+		}
+		return 0, fmt.Errorf("failed to increment email change attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Cancel discards a pending email change
+func (r *PostgresEmailChangeRepository) Cancel(ctx context.Context, id string, cancelledAt time.Time) error {
+	query := `
+		UPDATE email_change_requests
+		SET cancelled_at = $2
+		WHERE id = $1 AND confirmed_at IS NULL AND reverted_at IS NULL AND cancelled_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, cancelledAt)
+	if err != nil {
+		return fmt.Errorf("failed to cancel email change: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrEmailChangeNotFound
+	}
+
+	return nil
+}
+
+// Complete persists a confirmed or reverted email change together with the user's new email
+// in one transaction, so the address is only swapped if the request state is recorded too
+func (r *PostgresEmailChangeRepository) Complete(ctx context.Context, req *domain.EmailChangeRequest, user *domain.User) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		// Guard against concurrent confirmation or revert of the same request
+		requestQuery := `
+			UPDATE email_change_requests
+			SET confirmed_at = $2, reverted_at = $3
+			WHERE id = $1 AND reverted_at IS NULL AND cancelled_at IS NULL
+				AND (confirmed_at IS NULL OR $3::TIMESTAMPTZ IS NOT NULL)`
+
+		result, err := tx.ExecContext(ctx, requestQuery, req.ID, req.ConfirmedAt, req.RevertedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update email change request: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrEmailChangeNotFound
+		}
+
+		userQuery := `
+			UPDATE users
+			SET email = $2, is_email_verified = $3, updated_at = $4
+			WHERE id = $1 AND deleted_at IS NULL`
+
+		result, err = tx.ExecContext(ctx, userQuery, user.ID, user.Email, user.IsEmailVerified, user.UpdatedAt)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return domain.ErrUserAlreadyExists
+			}
+			return fmt.Errorf("failed to update user email: %w", err)
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return nil
+	})
+}
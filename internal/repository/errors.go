@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes
+const (
+	pqUniqueViolation = "23505"
+	pqCheckViolation  = "23514"
+)
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation
+}
+
+// isUniqueConstraintViolation reports whether err violates the named PostgreSQL unique constraint or index
+func isUniqueConstraintViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation && pqErr.Constraint == constraint
+}
+
+// isCheckViolation reports whether err violates the named PostgreSQL check constraint
+func isCheckViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqCheckViolation && pqErr.Constraint == constraint
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// PostgresIdempotencyRepository implements the IdempotencyRepository interface
+type PostgresIdempotencyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresIdempotencyRepository creates a new PostgreSQL idempotency repository
+func NewPostgresIdempotencyRepository(db *sqlx.DB) *PostgresIdempotencyRepository {
+	return &PostgresIdempotencyRepository{
+		db: db,
+	}
+}
+
+// Create reserves an idempotency key, taking over an expired record of the same key, whether
+// an in-progress reservation whose lease ran out or a completed response past its TTL.
+// It returns ErrIdempotencyKeyExists while the key is held by an unexpired record.
+func (r *PostgresIdempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `
+		INSERT INTO idempotency_keys (scope, key, fingerprint, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			content_type = '',
+			response_body = NULL,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`
+
+	result, err := r.db.ExecContext(ctx, query,
+		record.Scope,
+		record.Key,
+		record.Fingerprint,
+		record.ExpiresAt,
+		record.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrIdempotencyKeyExists
+	}
+
+	return nil
+}
+
+// Get retrieves an idempotency key record; a key released since it was found taken
+// reports ErrIdempotencyKeyInProgress so the client retries
+func (r *PostgresIdempotencyRepository) Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	query := `
+		SELECT scope, key, fingerprint, status_code, content_type, response_body, completed_at, expires_at, created_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2`
+
+	var recordDTO dto.IdempotencyRecordDTO
+	err := r.db.GetContext(ctx, &recordDTO, query, scope, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrIdempotencyKeyInProgress
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return recordDTO.ToDomain(), nil
+}
+
+// Complete stores the response sent for a reserved key and extends its expiry to the replay window
+func (r *PostgresIdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, response_body = $5, completed_at = $6, expires_at = $8
+		WHERE scope = $1 AND key = $2 AND fingerprint = $7 AND completed_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.Scope,
+		record.Key,
+		record.StatusCode,
+		record.ContentType,
+		record.ResponseBody,
+		record.CompletedAt,
+		record.Fingerprint,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Delete releases a reserved key that has not been completed, so the request can be retried
+func (r *PostgresIdempotencyRepository) Delete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE scope = $1 AND key = $2 AND fingerprint = $3 AND completed_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, record.Scope, record.Key, record.Fingerprint); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently removes keys that expired before the given time
+func (r *PostgresIdempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,488 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// ledgerAccountColumns lists the columns selected for ledger accounts
+const ledgerAccountColumns = `id, type, point_type, user_id, balance, allow_negative, created_at`
+
+// creditLotColumns lists the columns selected for credit lots
+const creditLotColumns = `id, user_id, point_type, entry_id, amount, remaining, expires_at, created_at`
+
+// ledgerBalanceConstraint is the check constraint keeping non-system balances from going negative
+const ledgerBalanceConstraint = "check_ledger_accounts_balance"
+
+// PostgresLedgerRepository implements the LedgerRepository interface
+type PostgresLedgerRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresLedgerRepository creates a new PostgreSQL ledger repository
+func NewPostgresLedgerRepository(db *sqlx.DB) *PostgresLedgerRepository {
+	return &PostgresLedgerRepository{
+		db: db,
+	}
+}
+
+// OpenUserAccount creates a user's ledger account, or loads it if the user already has one of that type and point type
+func (r *PostgresLedgerRepository) OpenUserAccount(ctx context.Context, account *domain.LedgerAccount) error {
+	return openUserAccount(ctx, r.db, account)
+}
+
+// GetUserAccount retrieves a user's ledger account of the given point type and account type
+func (r *PostgresLedgerRepository) GetUserAccount(ctx context.Context, userID string, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error) {
+	query := `
+		SELECT ` + ledgerAccountColumns + `
+		FROM ledger_accounts
+		WHERE user_id = $1 AND point_type = $2 AND type = $3`
+
+	var accountDTO dto.LedgerAccountDTO
+	err := r.db.GetContext(ctx, &accountDTO, query, userID, pointType, accountType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrLedgerAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	return accountDTO.ToDomain(), nil
+}
+
+// GetSystemAccount retrieves the system ledger account of the given point type and account type
+func (r *PostgresLedgerRepository) GetSystemAccount(ctx context.Context, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error) {
+	query := `
+		SELECT ` + ledgerAccountColumns + `
+		FROM ledger_accounts
+		WHERE user_id IS NULL AND point_type = $1 AND type = $2`
+
+	var accountDTO dto.LedgerAccountDTO
+	err := r.db.GetContext(ctx, &accountDTO, query, pointType, accountType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrLedgerAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get system ledger account: %w", err)
+	}
+
+	return accountDTO.ToDomain(), nil
+}
+
+// GetWallet summarizes a user's wallet from their account balances and earn postings
+func (r *PostgresLedgerRepository) GetWallet(ctx context.Context, userID string) (*domain.Wallet, error) {
+	balanceQuery := `
+		SELECT
+			point_type,
+			COALESCE(SUM(balance) FILTER (WHERE type = $2), 0) AS available,
+			COALESCE(SUM(balance) FILTER (WHERE type = $3), 0) AS held
+		FROM ledger_accounts
+		WHERE user_id = $1
+		GROUP BY point_type`
+
+	var balances []struct {
+		PointType string `db:"point_type"`
+		Available int64  `db:"available"`
+		Held      int64  `db:"held"`
+	}
+	err := r.db.SelectContext(ctx, &balances, balanceQuery, userID,
+		domain.AccountUserAvailable, domain.AccountUserHeld)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet balances: %w", err)
+	}
+
+	if len(balances) == 0 {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	wallet := &domain.Wallet{
+		UserID:   userID,
+		Balances: make(map[domain.PointType]int64, len(balances)),
+	}
+	for _, balance := range balances {
+		pointType := domain.PointType(balance.PointType)
+		wallet.Balances[pointType] = balance.Available
+		if pointType == domain.PointTypeCredits {
+			wallet.Available = balance.Available
+			wallet.Held = balance.Held
+		}
+	}
+
+	// Lifetime earnings are what the issuance account paid out on the user's earn entries
+	earnedQuery := `
+		SELECT COALESCE(-SUM(p.amount), 0)
+		FROM journal_entries e
+		JOIN ledger_postings p ON p.entry_id = e.id
+		JOIN ledger_accounts a ON a.id = p.account_id
+		WHERE e.user_id = $1 AND e.kind = $2 AND e.point_type = $3 AND a.type = $4`
+
+	err = r.db.GetContext(ctx, &wallet.LifetimeEarned, earnedQuery, userID, domain.EntryKindEarn, domain.PointTypeCredits, domain.AccountIssuance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet lifetime earnings: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// PostEntry records a journal entry and applies its postings to account balances in one transaction
+func (r *PostgresLedgerRepository) PostEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return postEntry(ctx, tx, entry)
+	})
+}
+
+// GetEntry retrieves a journal entry with its postings
+func (r *PostgresLedgerRepository) GetEntry(ctx context.Context, id string) (*domain.JournalEntry, error) {
+	entryQuery := `
+		SELECT id, kind, point_type, user_id, reference, description, created_at
+		FROM journal_entries
+		WHERE id = $1`
+
+	var entryDTO dto.JournalEntryDTO
+	err := r.db.GetContext(ctx, &entryDTO, entryQuery, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrJournalEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get journal entry: %w", err)
+	}
+
+	postingsQuery := `
+		SELECT id, entry_id, account_id, amount, balance_after, created_at
+		FROM ledger_postings
+		WHERE entry_id = $1
+		ORDER BY id`
+
+	var postingDTOs []dto.PostingDTO
+	if err := r.db.SelectContext(ctx, &postingDTOs, postingsQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to get journal entry postings: %w", err)
+	}
+
+	return entryDTO.ToDomain(postingDTOs), nil
+}
+
+// ListTransactions retrieves up to limit postings to a user's available account of the filter's point type,
+// newest first, starting after the posting with the given ID, or at the newest one if it is zero
+func (r *PostgresLedgerRepository) ListTransactions(ctx context.Context, userID string, filter domain.TransactionFilter, afterPostingID int64, limit int) ([]*domain.Transaction, error) {
+	query := `
+		SELECT p.id AS posting_id, e.id AS entry_id, e.kind, e.point_type, e.reference, e.description,
+			p.amount, p.balance_after, e.created_at
+		FROM ledger_postings p
+		JOIN ledger_accounts a ON a.id = p.account_id
+		JOIN journal_entries e ON e.id = p.entry_id
+		WHERE a.user_id = $1 AND a.point_type = $2 AND a.type = $3
+			AND ($4::bigint = 0 OR p.id < $4::bigint)
+			AND (cardinality($5::text[]) = 0 OR e.kind = ANY($5))
+			AND ($6 = '' OR split_part(e.reference, ':', 1) = $6)
+			AND ($7::timestamptz IS NULL OR e.created_at >= $7)
+			AND ($8::timestamptz IS NULL OR e.created_at < $8)
+			AND ($9::bigint IS NULL OR ABS(p.amount) >= $9)
+			AND ($10::bigint IS NULL OR ABS(p.amount) <= $10)
+		ORDER BY p.id DESC
+		LIMIT $11`
+
+	kinds := make([]string, len(filter.Kinds))
+	for i, kind := range filter.Kinds {
+		kinds[i] = string(kind)
+	}
+
+	var transactionDTOs []dto.TransactionDTO
+	err := r.db.SelectContext(ctx, &transactionDTOs, query,
+		userID,
+		filter.PointType,
+		domain.AccountUserAvailable,
+		afterPostingID,
+		pq.Array(kinds),
+		filter.Source,
+		filter.From,
+		filter.To,
+		filter.MinAmount,
+		filter.MaxAmount,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(transactionDTOs))
+	for _, transactionDTO := range transactionDTOs {
+		transactions = append(transactions, transactionDTO.ToDomain())
+	}
+	return transactions, nil
+}
+
+// ListExpiringLots retrieves a user's unspent credit lots that expire at or before the given time, soonest first
+func (r *PostgresLedgerRepository) ListExpiringLots(ctx context.Context, userID string, before time.Time) ([]*domain.CreditLot, error) {
+	query := `
+		SELECT ` + creditLotColumns + `
+		FROM credit_lots
+		WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+		ORDER BY expires_at, created_at`
+
+	var lotDTOs []dto.CreditLotDTO
+	if err := r.db.SelectContext(ctx, &lotDTOs, query, userID, before); err != nil {
+		return nil, fmt.Errorf("failed to list expiring credit lots: %w", err)
+	}
+
+	return creditLotsToDomain(lotDTOs), nil
+}
+
+// ListExpiredLots retrieves up to limit unspent credit lots of any user that expired at or before the given time
+func (r *PostgresLedgerRepository) ListExpiredLots(ctx context.Context, before time.Time, limit int) ([]*domain.CreditLot, error) {
+	query := `
+		SELECT ` + creditLotColumns + `
+		FROM credit_lots
+		WHERE remaining > 0 AND expires_at <= $1
+		ORDER BY expires_at, created_at
+		LIMIT $2`
+
+	var lotDTOs []dto.CreditLotDTO
+	if err := r.db.SelectContext(ctx, &lotDTOs, query, before, limit); err != nil {
+		return nil, fmt.Errorf("failed to list expired credit lots: %w", err)
+	}
+
+	return creditLotsToDomain(lotDTOs), nil
+}
+
+// ExpireLot posts an entry expiring credits of a lot. The owner's lot-tracked accounts of the lot's point type are locked
+// before the lot, as every entry changing the lot does, and the entry is only posted while the lot
+// still holds what was listed.
+func (r *PostgresLedgerRepository) ExpireLot(ctx context.Context, lot *domain.CreditLot, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		accountsQuery := `
+			SELECT id
+			FROM ledger_accounts
+			WHERE user_id = $1 AND point_type = $2 AND type IN ($3, $4)
+			ORDER BY id
+			FOR UPDATE`
+
+		var accountIDs []string
+		err := tx.SelectContext(ctx, &accountIDs, accountsQuery, lot.UserID, lot.PointType, domain.AccountUserAvailable, domain.AccountUserHeld)
+		if err != nil {
+			return fmt.Errorf("failed to lock user ledger accounts: %w", err)
+		}
+
+		lotQuery := `
+			SELECT remaining
+			FROM credit_lots
+			WHERE id = $1
+			FOR UPDATE`
+
+		var remaining int64
+		if err := tx.QueryRowContext(ctx, lotQuery, lot.ID).Scan(&remaining); err != nil {
+			return fmt.Errorf("failed to lock credit lot: %w", err)
+		}
+
+		if remaining != lot.Remaining {
+			return domain.ErrCreditLotChanged
+		}
+
+		return postEntry(ctx, tx, entry)
+	})
+}
+
+// openUserAccount inserts a user ledger account unless one of the same type and point type exists, then loads it
+func openUserAccount(ctx context.Context, q sqlx.ExtContext, account *domain.LedgerAccount) error {
+	insertQuery := `
+		INSERT INTO ledger_accounts (type, point_type, user_id, allow_negative, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING`
+
+	_, err := q.ExecContext(ctx, insertQuery, account.Type, account.PointType, account.UserID, account.AllowNegative, account.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger account: %w", err)
+	}
+
+	selectQuery := `
+		SELECT ` + ledgerAccountColumns + `
+		FROM ledger_accounts
+		WHERE user_id = $1 AND point_type = $2 AND type = $3`
+
+	var accountDTO dto.LedgerAccountDTO
+	if err := sqlx.GetContext(ctx, q, &accountDTO, selectQuery, account.UserID, account.PointType, account.Type); err != nil {
+		return fmt.Errorf("failed to load ledger account: %w", err)
+	}
+
+	*account = *accountDTO.ToDomain()
+	return nil
+}
+
+// postEntry inserts a journal entry and its postings within tx, updating each account's balance.
+// Accounts are updated in ID order so concurrent entries always lock them in the same order,
+// and must all hold the entry's point type. Credits an entry takes out of a user's wallet are consumed from the user's lots oldest first;
+// credits it adds open new lots.
+func postEntry(ctx context.Context, tx *sqlx.Tx, entry *domain.JournalEntry) error {
+	entryQuery := `
+		INSERT INTO journal_entries (kind, point_type, user_id, reference, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	var entryID string
+	err := tx.QueryRowContext(ctx, entryQuery,
+		entry.Kind,
+		entry.PointType,
+		entry.UserID,
+		entry.Reference,
+		entry.Description,
+		entry.CreatedAt,
+	).Scan(&entryID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrDuplicateEntry
+		}
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+
+	order := make([]int, len(entry.Postings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return entry.Postings[order[a]].AccountID < entry.Postings[order[b]].AccountID
+	})
+
+	balanceQuery := `
+		UPDATE ledger_accounts
+		SET balance = balance + $2
+		WHERE id = $1
+		RETURNING balance, type, point_type, user_id`
+
+	postingQuery := `
+		INSERT INTO ledger_postings (entry_id, account_id, amount, balance_after, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	// Net change of each user's lot-tracked balance; moves between a user's own accounts cancel out
+	var lotUsers []string
+	lotChanges := make(map[string]int64)
+
+	for _, i := range order {
+		posting := &entry.Postings[i]
+
+		var balance int64
+		var accountType domain.AccountType
+		var pointType domain.PointType
+		var userID *string
+		err := tx.QueryRowContext(ctx, balanceQuery, posting.AccountID, posting.Amount).Scan(&balance, &accountType, &pointType, &userID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return domain.ErrLedgerAccountNotFound
+			}
+			if isCheckViolation(err, ledgerBalanceConstraint) {
+				return domain.ErrInsufficientCredits
+			}
+			return fmt.Errorf("failed to update ledger account balance: %w", err)
+		}
+
+		if pointType != entry.PointType {
+			return domain.ErrPointTypeMismatch
+		}
+
+		var postingID int64
+		err = tx.QueryRowContext(ctx, postingQuery, entryID, posting.AccountID, posting.Amount, balance, entry.CreatedAt).Scan(&postingID)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger posting: %w", err)
+		}
+
+		posting.ID = postingID
+		posting.EntryID = entryID
+		posting.BalanceAfter = balance
+		posting.CreatedAt = entry.CreatedAt
+
+		if userID != nil && accountType.IsLotTracked() {
+			if _, seen := lotChanges[*userID]; !seen {
+				lotUsers = append(lotUsers, *userID)
+			}
+			lotChanges[*userID] += posting.Amount
+		}
+	}
+
+	entry.ID = entryID
+
+	// Take credits out of wallets first so the credits moved into other wallets keep their expiry
+	var draws []domain.LotDraw
+	for _, userID := range lotUsers {
+		if change := lotChanges[userID]; change < 0 {
+			userDraws, err := consumeLots(ctx, tx, userID, entry.PointType, -change)
+			if err != nil {
+				return err
+			}
+			draws = append(draws, userDraws...)
+		}
+	}
+
+	for _, userID := range lotUsers {
+		if change := lotChanges[userID]; change > 0 {
+			lots, rest, err := domain.NewCreditLots(userID, entry, change, draws)
+			if err != nil {
+				return fmt.Errorf("failed to build credit lots: %w", err)
+			}
+			if err := createLots(ctx, tx, lots); err != nil {
+				return err
+			}
+			draws = rest
+		}
+	}
+
+	return nil
+}
+
+// consumeLots spends amount from a user's lots of a point type oldest first, returning what it drew from each lot
+func consumeLots(ctx context.Context, tx *sqlx.Tx, userID string, pointType domain.PointType, amount int64) ([]domain.LotDraw, error) {
+	lotsQuery := `
+		SELECT ` + creditLotColumns + `
+		FROM credit_lots
+		WHERE user_id = $1 AND point_type = $2 AND remaining > 0
+		ORDER BY expires_at NULLS LAST, created_at
+		FOR UPDATE`
+
+	var lotDTOs []dto.CreditLotDTO
+	if err := sqlx.SelectContext(ctx, tx, &lotDTOs, lotsQuery, userID, pointType); err != nil {
+		return nil, fmt.Errorf("failed to lock credit lots: %w", err)
+	}
+
+	draws := domain.ConsumeLots(creditLotsToDomain(lotDTOs), amount)
+
+	updateQuery := `UPDATE credit_lots SET remaining = $2 WHERE id = $1`
+	for _, draw := range draws {
+		if _, err := tx.ExecContext(ctx, updateQuery, draw.Lot.ID, draw.Lot.Remaining); err != nil {
+			return nil, fmt.Errorf("failed to consume credit lot: %w", err)
+		}
+	}
+
+	return draws, nil
+}
+
+// createLots inserts credit lots within tx
+func createLots(ctx context.Context, tx *sqlx.Tx, lots []*domain.CreditLot) error {
+	insertQuery := `
+		INSERT INTO credit_lots (user_id, point_type, entry_id, amount, remaining, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, lot := range lots {
+		_, err := tx.ExecContext(ctx, insertQuery, lot.UserID, lot.PointType, lot.EntryID, lot.Amount, lot.Remaining, lot.ExpiresAt, lot.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create credit lot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// creditLotsToDomain converts credit lot DTOs to domain credit lots
+func creditLotsToDomain(lotDTOs []dto.CreditLotDTO) []*domain.CreditLot {
+	lots := make([]*domain.CreditLot, 0, len(lotDTOs))
+	for _, lotDTO := range lotDTOs {
+		lots = append(lots, lotDTO.ToDomain())
+	}
+	return lots
+}
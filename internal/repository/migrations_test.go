@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// userReference matches a column referencing users together with the rest of its definition
+var userReference = regexp.MustCompile(`(?i)REFERENCES\s+users\s*\(\s*id\s*\)[^,\n]*`)
+
+// createTable matches the start of a table definition and captures the table name
+var createTable = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+
+// TestMigrations_UserReferencesAllowPurge checks that purging soft-deleted users never hits a foreign key:
+// PurgeDeleted removes them in one DELETE, so a single restricting reference, such as from an adjustment
+// the user received, requested or reviewed, would block every purge from then on. A restricting reference
+// is allowed only if a later migration drops its constraint.
+func TestMigrations_UserReferencesAllowPurge(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	contents := make([]string, len(files))
+	for i, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		contents[i] = string(content)
+	}
+
+	for i, file := range files {
+		table := ""
+		for _, line := range strings.Split(contents[i], "\n") {
+			if match := createTable.FindStringSubmatch(line); match != nil {
+				table = match[1]
+			}
+
+			reference := userReference.FindString(line)
+			upper := strings.ToUpper(reference)
+			if reference == "" || strings.Contains(upper, "ON DELETE CASCADE") || strings.Contains(upper, "ON DELETE SET NULL") {
+				continue
+			}
+
+			// Inline references get PostgreSQL's default constraint name
+			column := strings.Fields(line)[0]
+			drop := "DROP CONSTRAINT IF EXISTS " + table + "_" + column + "_fkey"
+			if !droppedLater(contents[i+1:], drop) {
+				t.Errorf("%s: %q blocks purging users; use ON DELETE CASCADE or SET NULL, or drop the foreign key",
+					filepath.Base(file), strings.TrimSpace(line))
+			}
+		}
+	}
+}
+
+// droppedLater reports whether any of the later migrations contains the statement dropping a constraint
+func droppedLater(later []string, drop string) bool {
+	for _, content := range later {
+		if strings.Contains(content, drop) {
+			return true
+		}
+	}
+	return false
+}
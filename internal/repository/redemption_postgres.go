@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// redemptionColumns lists the redemptions table columns mapped by dto.RedemptionDTO
+const redemptionColumns = `id, user_id, reward_id, cost, point_type, status, hold_entry_id, settle_entry_id, expires_at, settled_at, created_at`
+
+// PostgresRedemptionRepository implements the RedemptionRepository interface.
+// Transactions lock the reward row before ledger accounts so holds and releases cannot deadlock.
+type PostgresRedemptionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRedemptionRepository creates a new PostgreSQL redemption repository
+func NewPostgresRedemptionRepository(db *sqlx.DB) *PostgresRedemptionRepository {
+	return &PostgresRedemptionRepository{
+		db: db,
+	}
+}
+
+// Hold reserves one unit of the reward's stock, posts the credit hold entry and records the
+// redemption in one transaction. Stock is only taken while the reward is still available at
+// the redemption's cost and point type, and the ledger's balance check rejects holds the user cannot cover.
+func (r *PostgresRedemptionRepository) Hold(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		stockQuery := `
+			UPDATE rewards
+			SET stock = stock - 1, updated_at = $3
+			WHERE id = $1 AND cost = $2 AND point_type = $5 AND status = $4 AND stock > 0
+				AND (starts_at IS NULL OR starts_at <= $3)
+				AND (ends_at IS NULL OR ends_at > $3)`
+
+		result, err := tx.ExecContext(ctx, stockQuery, redemption.RewardID, redemption.Cost, redemption.CreatedAt, domain.RewardActive, redemption.PointType)
+		if err != nil {
+			return fmt.Errorf("failed to reserve reward stock: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrRewardUnavailable
+		}
+
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		insertQuery := `
+			INSERT INTO redemptions (user_id, reward_id, cost, point_type, status, hold_entry_id, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`
+
+		var generatedID string
+		err = tx.QueryRowContext(ctx, insertQuery,
+			redemption.UserID,
+			redemption.RewardID,
+			redemption.Cost,
+			redemption.PointType,
+			redemption.Status,
+			entry.ID,
+			redemption.ExpiresAt,
+			redemption.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to create redemption: %w", err)
+		}
+
+		redemption.ID = generatedID
+		redemption.HoldEntryID = entry.ID
+		return nil
+	})
+}
+
+// Settle records a confirmed, cancelled or expired redemption together with the entry that moves
+// its held credits, in one transaction. Released redemptions return their unit of stock.
+// Only redemptions still on hold can be settled, so concurrent settlements cannot both apply.
+func (r *PostgresRedemptionRepository) Settle(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		statusQuery := `
+			UPDATE redemptions
+			SET status = $2, settled_at = $3
+			WHERE id = $1 AND status = $4`
+
+		result, err := tx.ExecContext(ctx, statusQuery, redemption.ID, redemption.Status, redemption.SettledAt, domain.RedemptionHeld)
+		if err != nil {
+			return fmt.Errorf("failed to settle redemption: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrRedemptionNotHeld
+		}
+
+		if redemption.Status != domain.RedemptionConfirmed {
+			restockQuery := `
+				UPDATE rewards
+				SET stock = stock + 1, updated_at = $2
+				WHERE id = $1`
+
+			if _, err := tx.ExecContext(ctx, restockQuery, redemption.RewardID, redemption.SettledAt); err != nil {
+				return fmt.Errorf("failed to restock reward: %w", err)
+			}
+		}
+
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		entryQuery := `UPDATE redemptions SET settle_entry_id = $2 WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, entryQuery, redemption.ID, entry.ID); err != nil {
+			return fmt.Errorf("failed to link redemption settlement: %w", err)
+		}
+
+		redemption.SettleEntryID = &entry.ID
+		return nil
+	})
+}
+
+// GetByID retrieves a redemption by ID
+func (r *PostgresRedemptionRepository) GetByID(ctx context.Context, id string) (*domain.Redemption, error) {
+	query := `
+		SELECT ` + redemptionColumns + `
+		FROM redemptions
+		WHERE id = $1`
+
+	var redemptionDTO dto.RedemptionDTO
+	err := r.db.GetContext(ctx, &redemptionDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRedemptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get redemption by ID: %w", err)
+	}
+
+	return redemptionDTO.ToDomain(), nil
+}
+
+// ListByUser retrieves a paginated list of a user's redemptions, newest first
+func (r *PostgresRedemptionRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Redemption, error) {
+	query := `
+		SELECT ` + redemptionColumns + `
+		FROM redemptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var redemptionDTOs []dto.RedemptionDTO
+	if err := r.db.SelectContext(ctx, &redemptionDTOs, query, userID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list redemptions: %w", err)
+	}
+
+	return redemptionsToDomain(redemptionDTOs), nil
+}
+
+// ListExpiredHolds retrieves up to limit redemptions whose hold timed out before the given time
+func (r *PostgresRedemptionRepository) ListExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*domain.Redemption, error) {
+	query := `
+		SELECT ` + redemptionColumns + `
+		FROM redemptions
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3`
+
+	var redemptionDTOs []dto.RedemptionDTO
+	if err := r.db.SelectContext(ctx, &redemptionDTOs, query, domain.RedemptionHeld, before, limit); err != nil {
+		return nil, fmt.Errorf("failed to list expired redemption holds: %w", err)
+	}
+
+	return redemptionsToDomain(redemptionDTOs), nil
+}
+
+// redemptionsToDomain converts redemption DTOs to domain redemptions
+func redemptionsToDomain(redemptionDTOs []dto.RedemptionDTO) []*domain.Redemption {
+	redemptions := make([]*domain.Redemption, 0, len(redemptionDTOs))
+	for _, redemptionDTO := range redemptionDTOs {
+		redemptions = append(redemptions, redemptionDTO.ToDomain())
+	}
+	return redemptions
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// referralColumns lists the referrals table columns mapped by dto.ReferralDTO, with the referee's name
+const referralColumns = `r.id, r.referrer_id, r.referee_id, u.name AS referee_name, r.referee_email_domain, r.status,
+		r.referrer_entry_id, r.referee_entry_id, r.created_at, r.rewarded_at`
+
+// PostgresReferralRepository implements the ReferralRepository interface; referrals are created together with the referee
+type PostgresReferralRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresReferralRepository creates a new PostgreSQL referral repository
+func NewPostgresReferralRepository(db *sqlx.DB) *PostgresReferralRepository {
+	return &PostgresReferralRepository{
+		db: db,
+	}
+}
+
+// GetByReferee retrieves the referral a user signed up with
+func (r *PostgresReferralRepository) GetByReferee(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals r
+		JOIN users u ON u.id = r.referee_id
+		WHERE r.referee_id = $1`
+
+	var referralDTO dto.ReferralDTO
+	err := r.db.GetContext(ctx, &referralDTO, query, refereeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrReferralNotFound
+		}
+		return nil, fmt.Errorf("failed to get referral by referee: %w", err)
+	}
+
+	return referralDTO.ToDomain(), nil
+}
+
+// CountByReferrerAndDomain counts the users a referrer referred who signed up on an email domain
+func (r *PostgresReferralRepository) CountByReferrerAndDomain(ctx context.Context, referrerID, emailDomain string) (int, error) {
+	query := `SELECT COUNT(*) FROM referrals WHERE referrer_id = $1 AND referee_email_domain = $2`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, referrerID, emailDomain); err != nil {
+		return 0, fmt.Errorf("failed to count referrals: %w", err)
+	}
+
+	return count, nil
+}
+
+// Reward posts the credits owed to the referrer and the referee and marks the referral rewarded in one transaction.
+// It fails with ErrReferralNotPending if the referral was rewarded concurrently.
+func (r *PostgresReferralRepository) Reward(ctx context.Context, referral *domain.Referral, referrerEntry, refereeEntry *domain.JournalEntry) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		updateQuery := `
+			UPDATE referrals
+			SET status = $2, rewarded_at = $3
+			WHERE id = $1 AND status = $4`
+
+		result, err := tx.ExecContext(ctx, updateQuery, referral.ID, referral.Status, referral.RewardedAt, domain.ReferralPending)
+		if err != nil {
+			return fmt.Errorf("failed to update referral: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrReferralNotPending
+		}
+
+		for _, entry := range []*domain.JournalEntry{referrerEntry, refereeEntry} {
+			if err := postEntry(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+
+		entriesQuery := `UPDATE referrals SET referrer_entry_id = $2, referee_entry_id = $3 WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, entriesQuery, referral.ID, referrerEntry.ID, refereeEntry.ID); err != nil {
+			return fmt.Errorf("failed to link referral entries: %w", err)
+		}
+
+		referral.ReferrerEntryID = &referrerEntry.ID
+		referral.RefereeEntryID = &refereeEntry.ID
+		return nil
+	})
+}
+
+// ListByReferrer retrieves a paginated list of the users a referrer referred, newest first
+func (r *PostgresReferralRepository) ListByReferrer(ctx context.Context, referrerID string, limit, offset int) ([]*domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals r
+		JOIN users u ON u.id = r.referee_id
+		WHERE r.referrer_id = $1
+		ORDER BY r.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var referralDTOs []dto.ReferralDTO
+	if err := r.db.SelectContext(ctx, &referralDTOs, query, referrerID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list referrals: %w", err)
+	}
+
+	referrals := make([]*domain.Referral, 0, len(referralDTOs))
+	for _, referralDTO := range referralDTOs {
+		referrals = append(referrals, referralDTO.ToDomain())
+	}
+	return referrals, nil
+}
+
+// ListRewardable retrieves up to limit pending referrals, oldest first, whose referee is an active user
+// who already verified their email, i.e. referrals whose payout failed at verification
+func (r *PostgresReferralRepository) ListRewardable(ctx context.Context, limit int) ([]*domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals r
+		JOIN users u ON u.id = r.referee_id
+		WHERE r.status = $1 AND u.is_email_verified = TRUE AND u.is_active = TRUE AND u.deleted_at IS NULL
+		ORDER BY r.created_at
+		LIMIT $2`
+
+	var referralDTOs []dto.ReferralDTO
+	if err := r.db.SelectContext(ctx, &referralDTOs, query, domain.ReferralPending, limit); err != nil {
+		return nil, fmt.Errorf("failed to list rewardable referrals: %w", err)
+	}
+
+	referrals := make([]*domain.Referral, 0, len(referralDTOs))
+	for _, referralDTO := range referralDTOs {
+		referrals = append(referrals, referralDTO.ToDomain())
+	}
+	return referrals, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// PostgresRefreshTokenRepository implements the RefreshTokenRepository interface
+type PostgresRefreshTokenRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRefreshTokenRepository creates a new PostgreSQL refresh token repository
+func NewPostgresRefreshTokenRepository(db *sqlx.DB) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new refresh token and sets its generated ID
+func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	tokenDTO := dto.RefreshTokenFromDomain(token)
+
+	query := `
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	var generatedID string
+	err := r.db.QueryRowContext(ctx, query,
+		tokenDTO.UserID,
+		tokenDTO.TokenHash,
+		tokenDTO.ExpiresAt,
+		tokenDTO.CreatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	token.ID = generatedID
+	return nil
+}
+
+// GetByHash retrieves a refresh token by its hash
+func (r *PostgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1`
+
+	var tokenDTO dto.RefreshTokenDTO
+	err := r.db.GetContext(ctx, &tokenDTO, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return tokenDTO.ToDomain(), nil
+}
+
+// Revoke marks a refresh token as revoked, failing if it was already revoked
+func (r *PostgresRefreshTokenRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRefreshTokenRevoked
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every active refresh token belonging to a user
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, revokedAt); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
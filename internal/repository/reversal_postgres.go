@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// reversalColumns lists the reversals table columns mapped by dto.ReversalDTO
+const reversalColumns = `id, entry_id, entry_kind, user_id, point_type, amount, posted, reversal_entry_id, reason, created_at`
+
+// PostgresReversalRepository implements the ReversalRepository interface
+type PostgresReversalRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresReversalRepository creates a new PostgreSQL reversal repository
+func NewPostgresReversalRepository(db *sqlx.DB) *PostgresReversalRepository {
+	return &PostgresReversalRepository{
+		db: db,
+	}
+}
+
+// ListByEntry retrieves the reversals of a journal entry, oldest first
+func (r *PostgresReversalRepository) ListByEntry(ctx context.Context, entryID string) ([]*domain.Reversal, error) {
+	query := `
+		SELECT ` + reversalColumns + `
+		FROM reversals
+		WHERE entry_id = $1
+		ORDER BY created_at`
+
+	var reversalDTOs []dto.ReversalDTO
+	if err := r.db.SelectContext(ctx, &reversalDTOs, query, entryID); err != nil {
+		return nil, fmt.Errorf("failed to list reversals: %w", err)
+	}
+
+	reversals := make([]*domain.Reversal, 0, len(reversalDTOs))
+	for _, reversalDTO := range reversalDTOs {
+		reversals = append(reversals, reversalDTO.ToDomain())
+	}
+	return reversals, nil
+}
+
+// Reverse posts the reversal's compensating entry, if it has one, and records the reversal in one transaction.
+// The reversed entry is locked first, and the reversal only goes ahead while the points reversed of it
+// are still what the caller saw; otherwise it fails with ErrReversalConflict.
+func (r *PostgresReversalRepository) Reverse(ctx context.Context, reversal *domain.Reversal, reversed int64, entry *domain.JournalEntry) error {
+	reversalDTO := dto.ReversalFromDomain(reversal)
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		lockQuery := `
+			SELECT id
+			FROM journal_entries
+			WHERE id = $1
+			FOR UPDATE`
+
+		var entryID string
+		if err := tx.QueryRowContext(ctx, lockQuery, reversalDTO.EntryID).Scan(&entryID); err != nil {
+			if err == sql.ErrNoRows {
+				return domain.ErrJournalEntryNotFound
+			}
+			return fmt.Errorf("failed to lock journal entry: %w", err)
+		}
+
+		reversedQuery := `SELECT COALESCE(SUM(amount), 0) FROM reversals WHERE entry_id = $1`
+
+		var current int64
+		if err := tx.QueryRowContext(ctx, reversedQuery, reversalDTO.EntryID).Scan(&current); err != nil {
+			return fmt.Errorf("failed to sum reversals: %w", err)
+		}
+
+		if current != reversed {
+			return domain.ErrReversalConflict
+		}
+
+		if entry != nil {
+			if err := postEntry(ctx, tx, entry); err != nil {
+				return err
+			}
+			reversalDTO.ReversalEntryID = &entry.ID
+		}
+
+		insertQuery := `
+			INSERT INTO reversals (entry_id, entry_kind, user_id, point_type, amount, posted, reversal_entry_id, reason, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`
+
+		var generatedID string
+		err := tx.QueryRowContext(ctx, insertQuery,
+			reversalDTO.EntryID,
+			reversalDTO.EntryKind,
+			reversalDTO.UserID,
+			reversalDTO.PointType,
+			reversalDTO.Amount,
+			reversalDTO.Posted,
+			reversalDTO.ReversalEntryID,
+			reversalDTO.Reason,
+			reversalDTO.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to create reversal: %w", err)
+		}
+
+		reversal.ID = generatedID
+		reversal.ReversalEntryID = reversalDTO.ReversalEntryID
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// rewardColumns lists the rewards table columns mapped by dto.RewardDTO
+const rewardColumns = `id, name, description, cost, point_type, stock, status, starts_at, ends_at, created_at, updated_at`
+
+// PostgresRewardRepository implements the RewardRepository interface
+type PostgresRewardRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRewardRepository creates a new PostgreSQL reward repository
+func NewPostgresRewardRepository(db *sqlx.DB) *PostgresRewardRepository {
+	return &PostgresRewardRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new reward and sets its generated ID
+func (r *PostgresRewardRepository) Create(ctx context.Context, reward *domain.Reward) error {
+	rewardDTO := dto.RewardFromDomain(reward)
+
+	query := `
+		INSERT INTO rewards (name, description, cost, point_type, stock, status, starts_at, ends_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	var generatedID string
+	err := r.db.QueryRowContext(ctx, query,
+		rewardDTO.Name,
+		rewardDTO.Description,
+		rewardDTO.Cost,
+		rewardDTO.PointType,
+		rewardDTO.Stock,
+		rewardDTO.Status,
+		rewardDTO.StartsAt,
+		rewardDTO.EndsAt,
+		rewardDTO.CreatedAt,
+		rewardDTO.UpdatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		return fmt.Errorf("failed to create reward: %w", err)
+	}
+
+	reward.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves a reward by ID
+func (r *PostgresRewardRepository) GetByID(ctx context.Context, id string) (*domain.Reward, error) {
+	query := `
+		SELECT ` + rewardColumns + `
+		FROM rewards
+		WHERE id = $1`
+
+	var rewardDTO dto.RewardDTO
+	err := r.db.GetContext(ctx, &rewardDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRewardNotFound
+		}
+		return nil, fmt.Errorf("failed to get reward by ID: %w", err)
+	}
+
+	return rewardDTO.ToDomain(), nil
+}
+
+// Update saves a reward's settings
+func (r *PostgresRewardRepository) Update(ctx context.Context, reward *domain.Reward) error {
+	rewardDTO := dto.RewardFromDomain(reward)
+
+	query := `
+		UPDATE rewards
+		SET name = $2, description = $3, cost = $4, point_type = $5, stock = $6, status = $7, starts_at = $8, ends_at = $9,
+			updated_at = $10
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		rewardDTO.ID,
+		rewardDTO.Name,
+		rewardDTO.Description,
+		rewardDTO.Cost,
+		rewardDTO.PointType,
+		rewardDTO.Stock,
+		rewardDTO.Status,
+		rewardDTO.StartsAt,
+		rewardDTO.EndsAt,
+		rewardDTO.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update reward: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRewardNotFound
+	}
+
+	return nil
+}
+
+// List retrieves a paginated list of every reward in the catalog
+func (r *PostgresRewardRepository) List(ctx context.Context, limit, offset int) ([]*domain.Reward, error) {
+	query := `
+		SELECT ` + rewardColumns + `
+		FROM rewards
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var rewardDTOs []dto.RewardDTO
+	if err := r.db.SelectContext(ctx, &rewardDTOs, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list rewards: %w", err)
+	}
+
+	return rewardsToDomain(rewardDTOs), nil
+}
+
+// ListAvailable retrieves a paginated list of active, in-stock rewards whose window contains at
+func (r *PostgresRewardRepository) ListAvailable(ctx context.Context, at time.Time, limit, offset int) ([]*domain.Reward, error) {
+	query := `
+		SELECT ` + rewardColumns + `
+		FROM rewards
+		WHERE status = $1 AND stock > 0
+			AND (starts_at IS NULL OR starts_at <= $2)
+			AND (ends_at IS NULL OR ends_at > $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	var rewardDTOs []dto.RewardDTO
+	if err := r.db.SelectContext(ctx, &rewardDTOs, query, domain.RewardActive, at, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list available rewards: %w", err)
+	}
+
+	return rewardsToDomain(rewardDTOs), nil
+}
+
+// rewardsToDomain converts reward DTOs to domain rewards
+func rewardsToDomain(rewardDTOs []dto.RewardDTO) []*domain.Reward {
+	rewards := make([]*domain.Reward, 0, len(rewardDTOs))
+	for _, rewardDTO := range rewardDTOs {
+		rewards = append(rewards, rewardDTO.ToDomain())
+	}
+	return rewards
+}
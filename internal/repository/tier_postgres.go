@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
+)
+
+// tierColumns lists the tiers table columns mapped by dto.TierDTO
+const tierColumns = `id, name, description, threshold, created_at, updated_at`
+
+// tierChangeColumns lists the tier_changes table columns mapped by dto.TierChangeDTO
+const tierChangeColumns = `id, user_id, from_tier_id, from_tier_name, to_tier_id, to_tier_name, qualifying_points, reason, created_at`
+
+// tierStandingSelect selects users' current tier with the points of type $2 they gained from entries
+// of kind $1 since $3, less what was reversed of those entries, mapped by dto.TierStandingDTO
+const tierStandingSelect = `
+		SELECT u.id AS user_id, u.tier_id, COALESCE((
+			SELECT SUM(p.amount)
+			FROM journal_entries e
+			JOIN ledger_postings p ON p.entry_id = e.id
+			JOIN ledger_accounts a ON a.id = p.account_id
+			WHERE e.user_id = u.id AND e.kind = $1 AND e.point_type = $2 AND e.created_at >= $3
+				AND a.user_id = u.id AND a.type = $4
+		), 0) - COALESCE((
+			SELECT SUM(r.amount)
+			FROM reversals r
+			JOIN journal_entries e ON e.id = r.entry_id
+			WHERE e.user_id = u.id AND e.kind = $1 AND e.point_type = $2 AND e.created_at >= $3
+		), 0) AS qualifying_points
+		FROM users u`
+
+// PostgresTierRepository implements the TierRepository interface
+type PostgresTierRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTierRepository creates a new PostgreSQL tier repository
+func NewPostgresTierRepository(db *sqlx.DB) *PostgresTierRepository {
+	return &PostgresTierRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new tier and sets its generated ID
+func (r *PostgresTierRepository) Create(ctx context.Context, tier *domain.Tier) error {
+	tierDTO := dto.TierFromDomain(tier)
+
+	query := `
+		INSERT INTO tiers (name, description, threshold, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var generatedID string
+	err := r.db.QueryRowContext(ctx, query,
+		tierDTO.Name,
+		tierDTO.Description,
+		tierDTO.Threshold,
+		tierDTO.CreatedAt,
+		tierDTO.UpdatedAt,
+	).Scan(&generatedID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTierAlreadyExists
+		}
+		return fmt.Errorf("failed to create tier: %w", err)
+	}
+
+	tier.ID = generatedID
+	return nil
+}
+
+// GetByID retrieves a tier by ID
+func (r *PostgresTierRepository) GetByID(ctx context.Context, id string) (*domain.Tier, error) {
+	query := `
+		SELECT ` + tierColumns + `
+		FROM tiers
+		WHERE id = $1`
+
+	var tierDTO dto.TierDTO
+	err := r.db.GetContext(ctx, &tierDTO, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTierNotFound
+		}
+		return nil, fmt.Errorf("failed to get tier by ID: %w", err)
+	}
+
+	return tierDTO.ToDomain(), nil
+}
+
+// Update saves a tier's settings
+func (r *PostgresTierRepository) Update(ctx context.Context, tier *domain.Tier) error {
+	tierDTO := dto.TierFromDomain(tier)
+
+	query := `
+		UPDATE tiers
+		SET name = $2, description = $3, threshold = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		tierDTO.ID,
+		tierDTO.Name,
+		tierDTO.Description,
+		tierDTO.Threshold,
+		tierDTO.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTierAlreadyExists
+		}
+		return fmt.Errorf("failed to update tier: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTierNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a tier; its members hold no tier until they are recalculated
+func (r *PostgresTierRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM tiers WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tier: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTierNotFound
+	}
+
+	return nil
+}
+
+// List retrieves every tier ordered from the lowest threshold to the highest
+func (r *PostgresTierRepository) List(ctx context.Context) ([]*domain.Tier, error) {
+	query := `
+		SELECT ` + tierColumns + `
+		FROM tiers
+		ORDER BY threshold`
+
+	var tierDTOs []dto.TierDTO
+	if err := r.db.SelectContext(ctx, &tierDTOs, query); err != nil {
+		return nil, fmt.Errorf("failed to list tiers: %w", err)
+	}
+
+	tiers := make([]*domain.Tier, 0, len(tierDTOs))
+	for _, tierDTO := range tierDTOs {
+		tiers = append(tiers, tierDTO.ToDomain())
+	}
+	return tiers, nil
+}
+
+// GetStanding retrieves a user's current tier and the qualifying points they earned since the given time
+func (r *PostgresTierRepository) GetStanding(ctx context.Context, userID string, since time.Time) (*domain.TierStanding, error) {
+	query := tierStandingSelect + `
+		WHERE u.id = $5 AND u.deleted_at IS NULL`
+
+	var standingDTO dto.TierStandingDTO
+	err := r.db.GetContext(ctx, &standingDTO, query,
+		domain.EntryKindEarn, domain.QualifyingPointType, since, domain.AccountUserAvailable, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get tier standing: %w", err)
+	}
+
+	return standingDTO.ToDomain(), nil
+}
+
+// ListStandings retrieves a page of users' current tiers and the qualifying points they earned since the given time
+func (r *PostgresTierRepository) ListStandings(ctx context.Context, since time.Time, limit, offset int) ([]*domain.TierStanding, error) {
+	query := tierStandingSelect + `
+		WHERE u.deleted_at IS NULL
+		ORDER BY u.id
+		LIMIT $5 OFFSET $6`
+
+	var standingDTOs []dto.TierStandingDTO
+	err := r.db.SelectContext(ctx, &standingDTOs, query,
+		domain.EntryKindEarn, domain.QualifyingPointType, since, domain.AccountUserAvailable, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tier standings: %w", err)
+	}
+
+	standings := make([]*domain.TierStanding, 0, len(standingDTOs))
+	for _, standingDTO := range standingDTOs {
+		standings = append(standings, standingDTO.ToDomain())
+	}
+	return standings, nil
+}
+
+// ChangeTier moves the user to the change's tier and records it in their history in one transaction.
+// It fails with ErrTierChanged if the user no longer holds the tier the change moves them from.
+func (r *PostgresTierRepository) ChangeTier(ctx context.Context, change *domain.TierChange) error {
+	changeDTO := dto.TierChangeFromDomain(change)
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		updateQuery := `
+			UPDATE users
+			SET tier_id = $3
+			WHERE id = $1 AND tier_id IS NOT DISTINCT FROM $2 AND deleted_at IS NULL`
+
+		result, err := tx.ExecContext(ctx, updateQuery, changeDTO.UserID, changeDTO.FromTierID, changeDTO.ToTierID)
+		if err != nil {
+			return fmt.Errorf("failed to update user tier: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		// Either the user is gone or another recalculation moved them first
+		if rowsAffected == 0 {
+			return domain.ErrTierChanged
+		}
+
+		insertQuery := `
+			INSERT INTO tier_changes (user_id, from_tier_id, from_tier_name, to_tier_id, to_tier_name, qualifying_points, reason, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`
+
+		var generatedID string
+		err = tx.QueryRowContext(ctx, insertQuery,
+			changeDTO.UserID,
+			changeDTO.FromTierID,
+			changeDTO.FromTierName,
+			changeDTO.ToTierID,
+			changeDTO.ToTierName,
+			changeDTO.QualifyingPoints,
+			changeDTO.Reason,
+			changeDTO.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to record tier change: %w", err)
+		}
+
+		change.ID = generatedID
+		return nil
+	})
+}
+
+// ListChanges retrieves a paginated list of a user's tier history, newest first
+func (r *PostgresTierRepository) ListChanges(ctx context.Context, userID string, limit, offset int) ([]*domain.TierChange, error) {
+	query := `
+		SELECT ` + tierChangeColumns + `
+		FROM tier_changes
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var changeDTOs []dto.TierChangeDTO
+	if err := r.db.SelectContext(ctx, &changeDTOs, query, userID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list tier changes: %w", err)
+	}
+
+	changes := make([]*domain.TierChange, 0, len(changeDTOs))
+	for _, changeDTO := range changeDTOs {
+		changes = append(changes, changeDTO.ToDomain())
+	}
+	return changes, nil
+}
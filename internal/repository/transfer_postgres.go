@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// PostgresTransferRepository implements the TransferRepository interface
+type PostgresTransferRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTransferRepository creates a new PostgreSQL transfer repository
+func NewPostgresTransferRepository(db *sqlx.DB) *PostgresTransferRepository {
+	return &PostgresTransferRepository{
+		db: db,
+	}
+}
+
+// Create posts the transfer entry and records the transfer in one transaction. Both users' accounts
+// are locked up front, in ID order, so a sender's concurrent transfers cannot overrun the daily limit.
+func (r *PostgresTransferRepository) Create(ctx context.Context, transfer *domain.Transfer, entry *domain.JournalEntry, dailyLimit int64) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		lockQuery := `
+			SELECT id
+			FROM ledger_accounts
+			WHERE id IN ($1, $2)
+			ORDER BY id
+			FOR UPDATE`
+
+		var locked []string
+		err := tx.SelectContext(ctx, &locked, lockQuery, entry.Postings[0].AccountID, entry.Postings[1].AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to lock ledger accounts: %w", err)
+		}
+
+		if dailyLimit > 0 {
+			sentQuery := `
+				SELECT COALESCE(SUM(amount), 0)
+				FROM transfers
+				WHERE sender_id = $1 AND point_type = $2 AND created_at > $3`
+
+			var sent int64
+			err := tx.GetContext(ctx, &sent, sentQuery, transfer.SenderID, transfer.PointType, transfer.DailyWindowStart())
+			if err != nil {
+				return fmt.Errorf("failed to sum sent transfers: %w", err)
+			}
+
+			if sent+transfer.Amount > dailyLimit {
+				return domain.ErrDailyTransferLimitExceeded
+			}
+		}
+
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		insertQuery := `
+			INSERT INTO transfers (sender_id, recipient_id, point_type, amount, note, entry_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id`
+
+		var generatedID string
+		err = tx.QueryRowContext(ctx, insertQuery,
+			transfer.SenderID,
+			transfer.RecipientID,
+			transfer.PointType,
+			transfer.Amount,
+			transfer.Note,
+			entry.ID,
+			transfer.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to create transfer: %w", err)
+		}
+
+		transfer.ID = generatedID
+		transfer.EntryID = entry.ID
+		return nil
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -12,6 +13,12 @@ import (
 	"github.com/azsharkawy5/SRBCS/internal/repository/dto"
 )
 
+// userColumns lists the users table columns mapped by dto.UserDTO, with the name of the user's tier
+const userColumns = `id, email, name, is_email_verified, is_active, otp_hash, otp_expires_at, otp_attempts,
+		otp_locked_until, otp_last_sent_at, deactivated_at, deactivated_by, deactivation_reason, role,
+		tier_id, (SELECT t.name FROM tiers t WHERE t.id = users.tier_id) AS tier_name, referral_code, referred_by,
+		created_at, updated_at`
+
 // PostgresUserRepository implements the UserRepository interface
 type PostgresUserRepository struct {
 	db *sqlx.DB
@@ -24,44 +31,117 @@ func NewPostgresUserRepository(db *sqlx.DB) *PostgresUserRepository {
 	}
 }
 
-// Create inserts a new user into the database and returns the generated ID
+// Create inserts a new user together with their wallet accounts and returns the generated ID.
+// It fails with ErrReferralCodeTaken if the user's referral code collides with another user's.
 func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.create(ctx, user, 0)
+}
+
+// CreateReferred inserts a referred user like Create, together with their pending referral. The referrer
+// is locked first so concurrent signups cannot exceed maxPerEmailDomain referees from the new user's email domain.
+func (r *PostgresUserRepository) CreateReferred(ctx context.Context, user *domain.User, maxPerEmailDomain int) error {
+	return r.create(ctx, user, maxPerEmailDomain)
+}
+
+// create inserts a user and, when they were referred, their referral, enforcing maxPerEmailDomain if positive
+func (r *PostgresUserRepository) create(ctx context.Context, user *domain.User, maxPerEmailDomain int) error {
 	// Convert domain user to DTO
 	userDTO := dto.FromDomain(user)
 
 	query := `
-		INSERT INTO users (email, name, is_email_verified, is_active, otp, otp_expires_at, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users (email, name, is_email_verified, is_active, otp_hash, otp_expires_at, role, referral_code, referred_by,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id`
 
-	var generatedID string
-	err := r.db.QueryRowContext(ctx, query,
-		userDTO.Email,
-		userDTO.Name,
-		userDTO.IsEmailVerified,
-		userDTO.IsActive,
-		userDTO.OTP,
-		userDTO.OTPExpiresAt,
-		userDTO.Role,
-		userDTO.CreatedAt,
-		userDTO.UpdatedAt,
-	).Scan(&generatedID)
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if user.ReferredBy != nil && maxPerEmailDomain > 0 {
+			var lockedID string
+			lockQuery := `SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+			if err := tx.GetContext(ctx, &lockedID, lockQuery, *user.ReferredBy); err != nil {
+				if err == sql.ErrNoRows {
+					return domain.ErrInvalidReferralCode
+				}
+				return fmt.Errorf("failed to lock referrer: %w", err)
+			}
 
-	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
-	}
+			var count int
+			countQuery := `SELECT COUNT(*) FROM referrals WHERE referrer_id = $1 AND referee_email_domain = $2`
+			if err := tx.GetContext(ctx, &count, countQuery, *user.ReferredBy, domain.EmailDomain(user.Email)); err != nil {
+				return fmt.Errorf("failed to count referrals: %w", err)
+			}
 
-	// Set the generated ID back to the domain user object
-	user.ID = generatedID
-	return nil
+			if count >= maxPerEmailDomain {
+				return domain.ErrReferralLimitReached
+			}
+		}
+
+		var generatedID string
+		err := tx.QueryRowContext(ctx, query,
+			userDTO.Email,
+			userDTO.Name,
+			userDTO.IsEmailVerified,
+			userDTO.IsActive,
+			userDTO.OTPHash,
+			userDTO.OTPExpiresAt,
+			userDTO.Role,
+			userDTO.ReferralCode,
+			userDTO.ReferredBy,
+			userDTO.CreatedAt,
+			userDTO.UpdatedAt,
+		).Scan(&generatedID)
+
+		if err != nil {
+			if isUniqueConstraintViolation(err, "idx_users_referral_code") {
+				return domain.ErrReferralCodeTaken
+			}
+			// Soft-deleted users keep their email until they are purged
+			if isUniqueViolation(err) {
+				return fmt.Errorf("failed to create user: %w", domain.ErrUserAlreadyExists)
+			}
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		// No user may exist without a wallet
+		for _, accountType := range domain.WalletAccountTypes {
+			account, err := domain.NewUserAccount(generatedID, domain.PointTypeCredits, accountType)
+			if err != nil {
+				return fmt.Errorf("failed to create user wallet: %w", err)
+			}
+			if err := openUserAccount(ctx, tx, account); err != nil {
+				return fmt.Errorf("failed to create user wallet: %w", err)
+			}
+		}
+
+		if user.ReferredBy != nil {
+			referralQuery := `
+				INSERT INTO referrals (referrer_id, referee_id, referee_email_domain, status, created_at)
+				VALUES ($1, $2, $3, $4, $5)`
+
+			_, err := tx.ExecContext(ctx, referralQuery,
+				*user.ReferredBy,
+				generatedID,
+				domain.EmailDomain(user.Email),
+				domain.ReferralPending,
+				user.CreatedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create referral: %w", err)
+			}
+		}
+
+		// Set the generated ID back to the domain user object
+		user.ID = generatedID
+		return nil
+	})
 }
 
 // GetByID retrieves a user by ID
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, is_email_verified, is_active, otp, otp_expires_at, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	var userDTO dto.UserDTO
 	err := r.db.GetContext(ctx, &userDTO, query, id)
@@ -84,9 +164,9 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domai
 // GetByEmail retrieves a user by email
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, is_email_verified, is_active, otp, otp_expires_at, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
-		WHERE email = $1`
+		WHERE email = $1 AND deleted_at IS NULL`
 
 	var userDTO dto.UserDTO
 	err := r.db.GetContext(ctx, &userDTO, query, email)
@@ -106,25 +186,47 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 	return user, nil
 }
 
-// Update updates an existing user
+// GetByReferralCode retrieves the user who owns a referral code
+func (r *PostgresUserRepository) GetByReferralCode(ctx context.Context, code string) (*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE referral_code = $1 AND deleted_at IS NULL`
+
+	var userDTO dto.UserDTO
+	err := r.db.GetContext(ctx, &userDTO, query, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by referral code: %w", err)
+	}
+
+	// Convert DTO to domain user
+	user, err := userDTO.ToDomain()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user DTO to domain: %w", err)
+	}
+
+	return user, nil
+}
+
+// Update saves a user's profile: email, name and email verification. Activation, role and OTP state are
+// only changed through their dedicated statements, so saving a stale copy of the user cannot undo them.
 func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User) error {
 	// Convert domain user to DTO
 	userDTO := dto.FromDomain(user)
 
 	query := `
 		UPDATE users
-		SET email = $2, name = $3, is_email_verified = $4, is_active = $5, otp = $6, otp_expires_at = $7, role = $8, updated_at = $9
-		WHERE id = $1`
+		SET email = $2, name = $3, is_email_verified = $4, updated_at = $5
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query,
 		userDTO.ID,
 		userDTO.Email,
 		userDTO.Name,
 		userDTO.IsEmailVerified,
-		userDTO.IsActive,
-		userDTO.OTP,
-		userDTO.OTPExpiresAt,
-		userDTO.Role,
 		userDTO.UpdatedAt,
 	)
 	if err != nil {
@@ -143,13 +245,250 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User)
 	return nil
 }
 
-// Delete removes a user from the database
+// Deactivate records a user's deactivation. Admin rows are locked first so concurrent deactivations
+// cannot remove the last active admin. It fails with ErrUserAlreadyInactive if the user was deactivated meanwhile.
+func (r *PostgresUserRepository) Deactivate(ctx context.Context, user *domain.User) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := ensureNotLastAdmin(ctx, tx, user.ID); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE users
+			SET is_active = FALSE, deactivated_at = $2, deactivated_by = $3, deactivation_reason = $4, updated_at = $5
+			WHERE id = $1 AND is_active = TRUE AND deleted_at IS NULL`
+
+		result, err := tx.ExecContext(ctx, query, user.ID, user.DeactivatedAt, user.DeactivatedBy, user.DeactivationReason, user.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrUserAlreadyInactive
+		}
+
+		return nil
+	})
+}
+
+// Reactivate re-enables a deactivated user and clears the deactivation details.
+// It fails with ErrUserAlreadyActive if the user was reactivated meanwhile.
+func (r *PostgresUserRepository) Reactivate(ctx context.Context, user *domain.User) error {
+	query := `
+		UPDATE users
+		SET is_active = TRUE, deactivated_at = NULL, deactivated_by = NULL, deactivation_reason = NULL, updated_at = $2
+		WHERE id = $1 AND is_active = FALSE AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, user.ID, user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserAlreadyActive
+	}
+
+	return nil
+}
+
+// IncrementOTPAttempts atomically records a verification attempt and returns the new attempt count
+func (r *PostgresUserRepository) IncrementOTPAttempts(ctx context.Context, id string) (int, error) {
+	query := `
+		UPDATE users
+		SET otp_attempts = otp_attempts + 1
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING otp_attempts`
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("failed to increment OTP attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// SetOTP stores a newly sent one-time password and resets the attempt counter. It fails with
+// ErrOTPLocked if verification was locked since the user was loaded.
+func (r *PostgresUserRepository) SetOTP(ctx context.Context, user *domain.User) error {
+	query := `
+		UPDATE users
+		SET otp_hash = $2, otp_expires_at = $3, otp_attempts = 0, otp_last_sent_at = $4, updated_at = $5
+		WHERE id = $1 AND deleted_at IS NULL AND (otp_locked_until IS NULL OR otp_locked_until <= $4)`
+
+	result, err := r.db.ExecContext(ctx, query, user.ID, user.OTPHash, user.OTPExpiresAt, user.OTPLastSentAt, user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set OTP: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrOTPLocked
+	}
+
+	return nil
+}
+
+// LockOTP discards the pending one-time password and blocks verification until the given time
+func (r *PostgresUserRepository) LockOTP(ctx context.Context, id string, until time.Time) error {
+	query := `
+		UPDATE users
+		SET otp_hash = NULL, otp_expires_at = NULL, otp_locked_until = $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, until)
+	if err != nil {
+		return fmt.Errorf("failed to lock OTP: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConsumeOTP clears the one-time password with the given hash and marks the email as verified. Only one
+// request can consume a code: it fails with ErrInvalidOTP if the code was used, replaced or locked meanwhile.
+func (r *PostgresUserRepository) ConsumeOTP(ctx context.Context, id, otpHash string) error {
+	query := `
+		UPDATE users
+		SET otp_hash = NULL, otp_expires_at = NULL, otp_attempts = 0, otp_locked_until = NULL,
+			is_email_verified = TRUE, updated_at = NOW()
+		WHERE id = $1 AND otp_hash = $2 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, otpHash)
+	if err != nil {
+		return fmt.Errorf("failed to consume OTP: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrInvalidOTP
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a user; the row is kept until it is purged.
+// Admin rows are locked first so concurrent deletions cannot remove the last active admin.
 func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM users WHERE id = $1`
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := ensureNotLastAdmin(ctx, tx, id); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE users
+			SET deleted_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL`
+
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return nil
+	})
+}
+
+// ChangeRole updates the user's role and records the change in one transaction.
+// Admin rows are locked first so concurrent demotions cannot remove the last active admin.
+func (r *PostgresUserRepository) ChangeRole(ctx context.Context, user *domain.User, change *domain.RoleChange) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if change.OldRole == domain.RoleAdmin {
+			if err := ensureNotLastAdmin(ctx, tx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		updateQuery := `
+			UPDATE users
+			SET role = $3, updated_at = $4
+			WHERE id = $1 AND role = $2 AND deleted_at IS NULL`
+
+		result, err := tx.ExecContext(ctx, updateQuery, user.ID, change.OldRole, change.NewRole, user.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update user role: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		// Either the user is gone or its role changed since it was loaded
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		insertQuery := `
+			INSERT INTO role_changes (user_id, old_role, new_role, changed_by, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`
+
+		var generatedID string
+		err = tx.QueryRowContext(ctx, insertQuery,
+			change.UserID,
+			change.OldRole,
+			change.NewRole,
+			change.ChangedBy,
+			change.CreatedAt,
+		).Scan(&generatedID)
+		if err != nil {
+			return fmt.Errorf("failed to record role change: %w", err)
+		}
+
+		change.ID = generatedID
+		return nil
+	})
+}
+
+// Restore undoes the soft delete of a user
+func (r *PostgresUserRepository) Restore(ctx context.Context, id string) error {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return fmt.Errorf("failed to restore user: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -164,11 +503,29 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// PurgeDeleted permanently removes users soft-deleted before the given time
+func (r *PostgresUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
 // List retrieves a paginated list of users as DTOs
 func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
 	query := `
-		SELECT id, email, name, is_email_verified, is_active, otp, otp_expires_at, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2`
 
@@ -187,3 +544,23 @@ func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([
 	}
 	return users, nil
 }
+
+// ensureNotLastAdmin locks the active admin rows within tx and fails with ErrLastAdmin if the user is the only
+// active admin, so demoting, deactivating or deleting them would leave no one able to administer the system
+func ensureNotLastAdmin(ctx context.Context, tx *sqlx.Tx, userID string) error {
+	var adminIDs []string
+	lockQuery := `
+		SELECT id FROM users
+		WHERE role = $1 AND is_active = TRUE AND deleted_at IS NULL
+		FOR UPDATE`
+
+	if err := tx.SelectContext(ctx, &adminIDs, lockQuery, domain.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to lock admin users: %w", err)
+	}
+
+	if len(adminIDs) == 1 && adminIDs[0] == userID {
+		return domain.ErrLastAdmin
+	}
+
+	return nil
+}
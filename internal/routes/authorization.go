@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// Policy decides whether the authenticated principal may perform the current request
+type Policy func(c *gin.Context, principal *domain.Principal) bool
+
+// Authorize allows the request when any of the given policies allows it.
+// It must run after Authenticate.
+func Authorize(policies ...Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := domain.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			abortWithError(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+			return
+		}
+
+		for _, policy := range policies {
+			if policy(c, principal) {
+				c.Next()
+				return
+			}
+		}
+
+		abortWithError(c, http.StatusForbidden, "Forbidden", domain.ErrForbidden.Error())
+	}
+}
+
+// Admin allows principals with the admin role
+func Admin() Policy {
+	return func(c *gin.Context, principal *domain.Principal) bool {
+		return principal.IsAdmin()
+	}
+}
+
+// Self allows principals acting on their own user record, identified by the given path parameter
+func Self(param string) Policy {
+	return func(c *gin.Context, principal *domain.Principal) bool {
+		return principal.UserID != "" && principal.UserID == c.Param(param)
+	}
+}
+
+// Scope allows API key principals whose key grants the given scope
+func Scope(scope string) Policy {
+	return func(c *gin.Context, principal *domain.Principal) bool {
+		return principal.HasScope(scope)
+	}
+}
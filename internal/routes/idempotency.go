@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// IdempotencyKeyHeader is the request header clients set to make a mutating request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayHeader marks responses replayed for a retried request
+const IdempotentReplayHeader = "Idempotent-Replayed"
+
+// IdempotencyStore reserves idempotency keys and remembers the responses sent for them
+type IdempotencyStore interface {
+	Begin(ctx context.Context, scope, key, method, path string, body []byte) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error
+	Abandon(ctx context.Context, record *domain.IdempotencyRecord) error
+}
+
+// Idempotency replays the stored response when a mutating request is retried with the same
+// Idempotency-Key and rejects a key reused for a different request. Requests without the header,
+// and reads, pass through. Keys belong to the caller, so it must run after Authenticate on
+// authenticated routes.
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		record, err := store.Begin(ctx, idempotencyScope(c), key, c.Request.Method, c.Request.URL.RequestURI(), body)
+		switch {
+		case errors.Is(err, domain.ErrInvalidIdempotencyKey):
+			abortWithError(c, http.StatusBadRequest, "Invalid idempotency key", err.Error())
+			return
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			abortWithError(c, http.StatusUnprocessableEntity, "Idempotency key reused", err.Error())
+			return
+		case errors.Is(err, domain.ErrIdempotencyKeyInProgress):
+			abortWithError(c, http.StatusConflict, "Request in progress", err.Error())
+			return
+		case err != nil:
+			abortWithError(c, http.StatusInternalServerError, "Failed to check idempotency key", err.Error())
+			return
+		}
+
+		if record.IsCompleted() {
+			c.Header(IdempotentReplayHeader, "true")
+			c.Data(record.StatusCode, record.ContentType, record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		defer func() {
+			// A panic skips the code below, so release the key before the recovery middleware turns
+			// it into a 500; otherwise retries would be rejected until the key's lease runs out
+			if recovered := recover(); recovered != nil {
+				abandonIdempotencyKey(ctx, store, record)
+				panic(recovered)
+			}
+		}()
+		c.Next()
+
+		// Server errors are not replayed so the client can retry them
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			abandonIdempotencyKey(ctx, store, record)
+			return
+		}
+
+		err = store.Complete(context.WithoutCancel(ctx), record, c.Writer.Status(), c.Writer.Header().Get("Content-Type"), recorder.body.Bytes())
+		if err != nil {
+			log.Printf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+// abandonIdempotencyKey releases a key whose request produced no response worth replaying
+func abandonIdempotencyKey(ctx context.Context, store IdempotencyStore, record *domain.IdempotencyRecord) {
+	if err := store.Abandon(context.WithoutCancel(ctx), record); err != nil {
+		log.Printf("Failed to release idempotency key: %v", err)
+	}
+}
+
+// isMutatingMethod reports whether requests with the HTTP method change state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// idempotencyScope identifies whose idempotency keys a request uses. Unauthenticated callers are
+// told apart by their address, so clients that happen to pick the same key rarely collide, while a
+// key reused by one client with a different request is still rejected.
+func idempotencyScope(c *gin.Context) string {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	switch {
+	case !ok:
+		return "anonymous:" + c.ClientIP()
+	case principal.IsAPIKey():
+		return "api_key:" + principal.APIKeyID
+	default:
+		return "user:" + principal.UserID
+	}
+}
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the data to the client and records it
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the client and records it
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
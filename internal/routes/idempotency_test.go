@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/service"
+)
+
+// memoryIdempotencyRepository implements service.IdempotencyRepository in memory for testing
+type memoryIdempotencyRepository struct {
+	records map[string]*domain.IdempotencyRecord
+}
+
+func (m *memoryIdempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if existing, exists := m.records[record.Scope+"/"+record.Key]; exists && !existing.IsExpiredAt(time.Now()) {
+		return domain.ErrIdempotencyKeyExists
+	}
+	stored := *record
+	m.records[record.Scope+"/"+record.Key] = &stored
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	record, exists := m.records[scope+"/"+key]
+	if !exists {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+	stored := *record
+	return &stored, nil
+}
+
+func (m *memoryIdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	stored := *record
+	m.records[record.Scope+"/"+record.Key] = &stored
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Delete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	delete(m.records, record.Scope+"/"+record.Key)
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotency_UnauthenticatedKeyReuse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := service.NewIdempotencyService(&memoryIdempotencyRepository{records: make(map[string]*domain.IdempotencyRecord)}, service.IdempotencyConfig{})
+
+	created := 0
+	engine := gin.New()
+	engine.POST("/users/", Idempotency(store), func(c *gin.Context) {
+		created++
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusCreated, "application/json", body)
+	})
+
+	send := func(clientAddr, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/", strings.NewReader(body))
+		req.RemoteAddr = clientAddr
+		req.Header.Set(IdempotencyKeyHeader, "signup-1")
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	if got := send("203.0.113.1:4000", `{"email":"alice@example.com"}`); got.Code != http.StatusCreated {
+		t.Fatalf("first request status = %d, want %d", got.Code, http.StatusCreated)
+	}
+
+	// A retry replays the first response without creating the user again
+	replay := send("203.0.113.1:4001", `{"email":"alice@example.com"}`)
+	if replay.Code != http.StatusCreated || replay.Header().Get(IdempotentReplayHeader) != "true" || created != 1 {
+		t.Errorf("retry status = %d, replayed = %q, created %d users, want a replay of the first response",
+			replay.Code, replay.Header().Get(IdempotentReplayHeader), created)
+	}
+
+	// The same key with a different payload is rejected, not executed
+	if got := send("203.0.113.1:4002", `{"email":"mallory@example.com"}`); got.Code != http.StatusUnprocessableEntity || created != 1 {
+		t.Errorf("reused key status = %d, created %d users, want %d and no new user", got.Code, created, http.StatusUnprocessableEntity)
+	}
+
+	// Another client picking the same key does not see the first client's response
+	if got := send("198.51.100.7:5000", `{"email":"bob@example.com"}`); got.Code != http.StatusCreated || created != 2 {
+		t.Errorf("other client status = %d, created %d users, want %d and a new user", got.Code, created, http.StatusCreated)
+	}
+}
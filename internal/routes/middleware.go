@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/internal/handler"
+)
+
+// principalKey is the gin context key holding the authenticated principal
+const principalKey = "principal"
+
+// Authenticator verifies bearer tokens and resolves the caller
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// Authenticators groups the verifiers for each kind of bearer credential
+type Authenticators struct {
+	AccessToken Authenticator
+	APIKey      Authenticator
+}
+
+// Authenticate requires a valid bearer credential (an access token or an API key)
+// and stores the caller's principal in both the gin context and the request context
+func Authenticate(authenticators Authenticators) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			abortWithError(c, http.StatusUnauthorized, "Unauthorized", "missing bearer token")
+			return
+		}
+
+		token = strings.TrimSpace(token)
+
+		authenticator := authenticators.AccessToken
+		if strings.HasPrefix(token, domain.APIKeyPrefix) {
+			authenticator = authenticators.APIKey
+		}
+
+		principal, err := authenticator.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			// Only a bad credential means the caller must authenticate again; anything else is our failure
+			if isCredentialError(err) {
+				abortWithError(c, http.StatusUnauthorized, "Unauthorized", err.Error())
+			} else {
+				abortWithError(c, http.StatusInternalServerError, "Failed to authenticate", err.Error())
+			}
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// isCredentialError reports whether an authenticator rejected the credential itself: it is malformed,
+// unknown, expired or revoked, or its owner is gone or deactivated
+func isCredentialError(err error) bool {
+	for _, target := range []error{
+		domain.ErrInvalidToken,
+		domain.ErrTokenExpired,
+		domain.ErrInvalidAPIKey,
+		domain.ErrAPIKeyRevoked,
+		domain.ErrAPIKeyExpired,
+		domain.ErrUserNotFound,
+		domain.ErrUserInactive,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// abortWithError aborts the request with an error response
+func abortWithError(c *gin.Context, statusCode int, errTitle, message string) {
+	c.AbortWithStatusJSON(statusCode, handler.ErrorResponse{
+		Error:   errTitle,
+		Message: message,
+	})
+}
@@ -7,11 +7,29 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/azsharkawy5/SRBCS/internal/domain"
 	"github.com/azsharkawy5/SRBCS/internal/handler"
 )
 
+// Handlers groups the HTTP handlers served by the API
+type Handlers struct {
+	User       *handler.UserHandler
+	Auth       *handler.AuthHandler
+	APIKey     *handler.APIKeyHandler
+	Wallet     *handler.WalletHandler
+	EarnRule   *handler.EarnRuleHandler
+	Reward     *handler.RewardHandler
+	Redemption *handler.RedemptionHandler
+	Transfer   *handler.TransferHandler
+	Tier       *handler.TierHandler
+	Referral   *handler.ReferralHandler
+	Campaign   *handler.CampaignHandler
+	Adjustment *handler.AdjustmentHandler
+	Reversal   *handler.ReversalHandler
+}
+
 // RegisterRoutes registers all HTTP routes
-func RegisterRoutes(engine *gin.Engine, userHandler *handler.UserHandler) {
+func RegisterRoutes(engine *gin.Engine, handlers Handlers, authenticators Authenticators, idempotencyStore IdempotencyStore) {
 	// API version prefix
 	api := engine.Group("/api/v1")
 
@@ -22,24 +40,141 @@ func RegisterRoutes(engine *gin.Engine, userHandler *handler.UserHandler) {
 		c.Writer.Write([]byte(`{"status": "healthy", "timestamp": "` + time.Now().Format(time.RFC3339) + `"}`))
 	})
 
+	authenticate := Authenticate(authenticators)
+	idempotent := Idempotency(idempotencyStore)
+
+	// Auth routes (not idempotent: their responses carry secrets that are only stored hashed)
+	auth := api.Group("/auth")
+	{
+		auth.POST("/otp/request", handlers.Auth.RequestOTP)
+		auth.POST("/otp/verify", handlers.Auth.VerifyOTP)
+		auth.POST("/token/refresh", handlers.Auth.RefreshTokens)
+		auth.POST("/logout", handlers.Auth.Logout)
+	}
+
 	// User routes
 	users := api.Group("/users")
 	{
-		users.POST("/", userHandler.CreateUser)
-		users.GET("/", userHandler.ListUsers)
-		users.GET("/:id", userHandler.GetUser)
-		users.PUT("/:id", userHandler.UpdateUser)
-		users.DELETE("/:id", userHandler.DeleteUser)
+		// Signup (no auth required)
+		users.POST("/", idempotent, handlers.User.CreateUser)
+
+		// Email change revert link sent to the old address: the page only submits the revert (authorized by its token)
+		users.GET("/email/revert", handlers.User.RevertEmailChangePage)
+		users.POST("/email/revert", handlers.User.RevertEmailChange)
+
+		authenticated := users.Group("", authenticate, idempotent)
+		authenticated.GET("/", Authorize(Admin(), Scope(domain.ScopeUsersRead)), handlers.User.ListUsers)
+		authenticated.GET("/me", handlers.User.GetCurrentUser)
+		authenticated.GET("/:id", Authorize(Admin(), Self("id"), Scope(domain.ScopeUsersRead)), handlers.User.GetUser)
+		authenticated.GET("/:id/wallet", Authorize(Admin(), Self("id"), Scope(domain.ScopeCreditsRead)), handlers.Wallet.GetWallet)
+		authenticated.GET("/:id/credits/expiring", Authorize(Admin(), Self("id"), Scope(domain.ScopeCreditsRead)), handlers.Wallet.GetExpiringCredits)
+		authenticated.GET("/:id/transactions", Authorize(Admin(), Self("id"), Scope(domain.ScopeCreditsRead)), handlers.Wallet.ListTransactions)
+		authenticated.GET("/:id/redemptions", Authorize(Admin(), Self("id"), Scope(domain.ScopeCreditsRead)), handlers.Redemption.ListUserRedemptions)
+		authenticated.GET("/:id/tier-history", Authorize(Admin(), Self("id"), Scope(domain.ScopeUsersRead)), handlers.Tier.ListTierHistory)
+		authenticated.GET("/:id/referrals", Authorize(Admin(), Self("id"), Scope(domain.ScopeUsersRead)), handlers.Referral.ListReferrals)
+		authenticated.PUT("/:id", Authorize(Admin(), Self("id"), Scope(domain.ScopeUsersWrite)), handlers.User.UpdateUser)
+		authenticated.POST("/:id/email/confirm", Authorize(Self("id")), handlers.User.ConfirmEmailChange)
+		authenticated.PUT("/:id/role", Authorize(Admin()), handlers.User.ChangeUserRole)
+		authenticated.DELETE("/:id", Authorize(Admin(), Scope(domain.ScopeUsersWrite)), handlers.User.DeleteUser)
+		authenticated.POST("/:id/deactivate", Authorize(Admin()), handlers.User.DeactivateUser)
+		authenticated.POST("/:id/reactivate", Authorize(Admin()), handlers.User.ReactivateUser)
+		authenticated.POST("/:id/restore", Authorize(Admin()), handlers.User.RestoreUser)
+	}
+
+	// API key management routes (admin users only; not idempotent as created keys are only stored hashed)
+	apiKeys := api.Group("/api-keys", authenticate, Authorize(Admin()))
+	{
+		apiKeys.POST("/", handlers.APIKey.CreateAPIKey)
+		apiKeys.GET("/", handlers.APIKey.ListAPIKeys)
+		apiKeys.DELETE("/:id", handlers.APIKey.RevokeAPIKey)
+	}
+
+	// Reward catalog routes (users browse, admins manage)
+	rewards := api.Group("/rewards", authenticate, idempotent)
+	{
+		rewards.GET("/", handlers.Reward.ListRewards)
+		rewards.GET("/:id", handlers.Reward.GetReward)
+		rewards.POST("/", Authorize(Admin()), handlers.Reward.CreateReward)
+		rewards.PUT("/:id", Authorize(Admin()), handlers.Reward.UpdateReward)
+		rewards.DELETE("/:id", Authorize(Admin()), handlers.Reward.DeleteReward)
+	}
+
+	// Loyalty tier routes (users browse, admins manage)
+	tiers := api.Group("/tiers", authenticate, idempotent)
+	{
+		tiers.GET("/", handlers.Tier.ListTiers)
+		tiers.GET("/:id", handlers.Tier.GetTier)
+		tiers.POST("/", Authorize(Admin()), handlers.Tier.CreateTier)
+		tiers.PUT("/:id", Authorize(Admin()), handlers.Tier.UpdateTier)
+		tiers.DELETE("/:id", Authorize(Admin()), handlers.Tier.DeleteTier)
+	}
+
+	// Redemption routes (the service checks the caller owns the redemption)
+	redemptions := api.Group("/redemptions", authenticate, idempotent)
+	{
+		redemptions.POST("/", handlers.Redemption.CreateRedemption)
+		redemptions.GET("/:id", handlers.Redemption.GetRedemption)
+		redemptions.POST("/:id/confirm", handlers.Redemption.ConfirmRedemption)
+		redemptions.POST("/:id/cancel", handlers.Redemption.CancelRedemption)
+	}
+
+	// Transfer routes (users send credits from their own wallet)
+	transfers := api.Group("/transfers", authenticate, idempotent)
+	{
+		transfers.POST("/", handlers.Transfer.CreateTransfer)
+	}
+
+	// Earn rule management routes (admin users only)
+	earnRules := api.Group("/earn-rules", authenticate, Authorize(Admin()), idempotent)
+	{
+		earnRules.POST("/", handlers.EarnRule.CreateRule)
+		earnRules.GET("/", handlers.EarnRule.ListRules)
+		earnRules.GET("/:id", handlers.EarnRule.GetRule)
+		earnRules.PUT("/:id", handlers.EarnRule.UpdateRule)
+		earnRules.DELETE("/:id", handlers.EarnRule.DeleteRule)
+	}
+
+	// Campaign management routes (admin users only)
+	campaigns := api.Group("/campaigns", authenticate, Authorize(Admin()), idempotent)
+	{
+		campaigns.POST("/", handlers.Campaign.CreateCampaign)
+		campaigns.GET("/", handlers.Campaign.ListCampaigns)
+		campaigns.GET("/:id", handlers.Campaign.GetCampaign)
+		campaigns.PUT("/:id", handlers.Campaign.UpdateCampaign)
+	}
+
+	// Manual adjustment routes (admin users only; a second admin approves each request)
+	adjustments := api.Group("/adjustments", authenticate, Authorize(Admin()), idempotent)
+	{
+		adjustments.POST("/", handlers.Adjustment.CreateAdjustment)
+		adjustments.GET("/", handlers.Adjustment.ListAdjustments)
+		adjustments.GET("/:id", handlers.Adjustment.GetAdjustment)
+		adjustments.POST("/:id/approve", handlers.Adjustment.ApproveAdjustment)
+		adjustments.POST("/:id/reject", handlers.Adjustment.RejectAdjustment)
+	}
+
+	// Transaction routes (admins and API keys reporting refunds and failed fulfillments)
+	transactions := api.Group("/transactions", authenticate, Authorize(Admin(), Scope(domain.ScopeCreditsWrite)), idempotent)
+	{
+		transactions.POST("/:id/reverse", handlers.Reversal.ReverseTransaction)
+	}
+
+	// Earn event routes (admins and API keys reporting user activity)
+	earnEvents := api.Group("/earn-events", authenticate, Authorize(Admin(), Scope(domain.ScopeCreditsWrite)), idempotent)
+	{
+		earnEvents.POST("/", handlers.EarnRule.ProcessEvent)
+		earnEvents.POST("/evaluate", handlers.EarnRule.EvaluateEvent)
 	}
 
 	// Debug routes (in development only)
 	if os.Getenv("APP_ENV") == "development" {
 		debug := api.Group("/debug")
 		debug.GET("/routes", func(c *gin.Context) {
-			c.Header("Content-Type", "application/json")
-			c.Status(200)
-			response := `{"routes": ["GET /api/v1/health", "POST /api/v1/users", "GET /api/v1/users", "GET /api/v1/users/:id", "PUT /api/v1/users/:id", "DELETE /api/v1/users/:id"]}`
-			c.Writer.Write([]byte(response))
+			routes := make([]string, 0)
+			for _, route := range engine.Routes() {
+				routes = append(routes, route.Method+" "+route.Path)
+			}
+			c.JSON(http.StatusOK, gin.H{"routes": routes})
 		})
 	}
 }
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// AdjustmentRepository defines what the adjustment service needs from the data layer
+type AdjustmentRepository interface {
+	Create(ctx context.Context, adjustment *domain.Adjustment) error
+	GetByID(ctx context.Context, id string) (*domain.Adjustment, error)
+	List(ctx context.Context, status domain.AdjustmentStatus, limit, offset int) ([]*domain.Adjustment, error)
+	Approve(ctx context.Context, adjustment *domain.Adjustment, entry *domain.JournalEntry) error
+	Reject(ctx context.Context, adjustment *domain.Adjustment) error
+}
+
+// AdjustmentConfig holds manual adjustment settings
+type AdjustmentConfig struct {
+	PointTypes domain.PointTypePolicies // how long granted points of each type stay usable
+}
+
+// AdjustmentService lets admins grant or deduct points by hand under maker-checker control:
+// one admin requests an adjustment and a different admin approves it before it is posted
+type AdjustmentService struct {
+	adjustmentRepo AdjustmentRepository
+	userRepo       UserRepository
+	ledgerRepo     LedgerRepository
+	config         AdjustmentConfig
+}
+
+// NewAdjustmentService creates a new adjustment service
+func NewAdjustmentService(adjustmentRepo AdjustmentRepository, userRepo UserRepository, ledgerRepo LedgerRepository, config AdjustmentConfig) *AdjustmentService {
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &AdjustmentService{
+		adjustmentRepo: adjustmentRepo,
+		userRepo:       userRepo,
+		ledgerRepo:     ledgerRepo,
+		config:         config,
+	}
+}
+
+// RequestAdjustment records a pending grant or deduction of a user's points on behalf of the calling admin
+func (s *AdjustmentService) RequestAdjustment(ctx context.Context, userID string, pointType domain.PointType, direction domain.AdjustmentDirection, amount int64, reason domain.AdjustmentReason, note string) (*domain.Adjustment, error) {
+	requestedBy, err := authorizeAdminUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	adjustment, err := domain.NewAdjustment(userID, pointType, direction, amount, reason, note, requestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create adjustment: %w", err)
+	}
+
+	if err := s.adjustmentRepo.Create(ctx, adjustment); err != nil {
+		return nil, fmt.Errorf("failed to save adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// GetAdjustment retrieves an adjustment by ID
+func (s *AdjustmentService) GetAdjustment(ctx context.Context, id string) (*domain.Adjustment, error) {
+	if _, err := authorizeAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	adjustment, err := s.adjustmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// ListAdjustments retrieves a paginated list of adjustments, oldest first, optionally only those in one status
+func (s *AdjustmentService) ListAdjustments(ctx context.Context, status domain.AdjustmentStatus, limit, offset int) ([]*domain.Adjustment, error) {
+	if _, err := authorizeAdminUser(ctx); err != nil {
+		return nil, err
+	}
+
+	if status != "" && !status.IsValid() {
+		return nil, domain.ErrInvalidAdjustmentStatus
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	adjustments, err := s.adjustmentRepo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// ApproveAdjustment approves another admin's pending adjustment and posts it to the user's wallet.
+// Deductions the user can no longer cover fail and stay pending.
+func (s *AdjustmentService) ApproveAdjustment(ctx context.Context, id, note string) (*domain.Adjustment, error) {
+	adjustment, reviewedBy, err := s.getForReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := adjustment.Approve(reviewedBy, note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.buildEntry(ctx, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.adjustmentRepo.Approve(ctx, adjustment, entry); err != nil {
+		return nil, fmt.Errorf("failed to approve adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// RejectAdjustment rejects another admin's pending adjustment without posting it
+func (s *AdjustmentService) RejectAdjustment(ctx context.Context, id, note string) (*domain.Adjustment, error) {
+	adjustment, reviewedBy, err := s.getForReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := adjustment.Reject(reviewedBy, note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.adjustmentRepo.Reject(ctx, adjustment); err != nil {
+		return nil, fmt.Errorf("failed to reject adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// getForReview retrieves an adjustment together with the ID of the admin reviewing it
+func (s *AdjustmentService) getForReview(ctx context.Context, id string) (*domain.Adjustment, string, error) {
+	reviewedBy, err := authorizeAdminUser(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if id == "" {
+		return nil, "", domain.ErrInvalidInput
+	}
+
+	adjustment, err := s.adjustmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get adjustment: %w", err)
+	}
+
+	return adjustment, reviewedBy, nil
+}
+
+// buildEntry builds the entry moving the adjusted points between the issuance account and the user's wallet
+func (s *AdjustmentService) buildEntry(ctx context.Context, adjustment *domain.Adjustment) (*domain.JournalEntry, error) {
+	policy, err := s.config.PointTypes.Policy(adjustment.PointType)
+	if err != nil {
+		return nil, err
+	}
+
+	issuance, err := s.ledgerRepo.GetSystemAccount(ctx, adjustment.PointType, domain.AccountIssuance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuance account: %w", err)
+	}
+
+	account, err := openWalletAccount(ctx, s.ledgerRepo, adjustment.UserID, adjustment.PointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := issuance.ID, account.ID
+	if adjustment.Direction == domain.AdjustmentDeduct {
+		from, to = account.ID, issuance.ID
+	}
+
+	description := fmt.Sprintf("Manual adjustment (%s)", adjustment.Reason)
+	entry, err := domain.NewTransferEntry(domain.EntryKindAdjustment, adjustment.PointType, adjustment.UserID, from, to, adjustment.Amount, adjustment.EntryReference(), description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build adjustment entry: %w", err)
+	}
+
+	if adjustment.Direction == domain.AdjustmentGrant {
+		entry.ExpireCreditsAfter(policy.Lifetime)
+	}
+
+	return entry, nil
+}
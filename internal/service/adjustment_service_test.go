@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockAdjustmentRepository implements AdjustmentRepository for testing; like the database, it hands out
+// copies so an approval whose entry fails to post leaves the stored adjustment pending
+type MockAdjustmentRepository struct {
+	ledger      *MockLedgerRepository
+	adjustments map[string]*domain.Adjustment
+	nextID      int
+}
+
+func NewMockAdjustmentRepository(ledger *MockLedgerRepository) *MockAdjustmentRepository {
+	return &MockAdjustmentRepository{
+		ledger:      ledger,
+		adjustments: make(map[string]*domain.Adjustment),
+	}
+}
+
+func (m *MockAdjustmentRepository) Create(ctx context.Context, adjustment *domain.Adjustment) error {
+	m.nextID++
+	adjustment.ID = fmt.Sprintf("adjustment-%d", m.nextID)
+	stored := *adjustment
+	m.adjustments[adjustment.ID] = &stored
+	return nil
+}
+
+func (m *MockAdjustmentRepository) GetByID(ctx context.Context, id string) (*domain.Adjustment, error) {
+	adjustment, exists := m.adjustments[id]
+	if !exists {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+	copied := *adjustment
+	return &copied, nil
+}
+
+func (m *MockAdjustmentRepository) List(ctx context.Context, status domain.AdjustmentStatus, limit, offset int) ([]*domain.Adjustment, error) {
+	var adjustments []*domain.Adjustment
+	for _, adjustment := range m.adjustments {
+		if status == "" || adjustment.Status == status {
+			copied := *adjustment
+			adjustments = append(adjustments, &copied)
+		}
+	}
+	return adjustments, nil
+}
+
+func (m *MockAdjustmentRepository) Approve(ctx context.Context, adjustment *domain.Adjustment, entry *domain.JournalEntry) error {
+	if m.adjustments[adjustment.ID].Status != domain.AdjustmentPending {
+		return domain.ErrAdjustmentNotPending
+	}
+	if err := m.ledger.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+	adjustment.EntryID = &entry.ID
+	stored := *adjustment
+	m.adjustments[adjustment.ID] = &stored
+	return nil
+}
+
+func (m *MockAdjustmentRepository) Reject(ctx context.Context, adjustment *domain.Adjustment) error {
+	if m.adjustments[adjustment.ID].Status != domain.AdjustmentPending {
+		return domain.ErrAdjustmentNotPending
+	}
+	stored := *adjustment
+	m.adjustments[adjustment.ID] = &stored
+	return nil
+}
+
+func TestAdjustmentService_MakerChecker(t *testing.T) {
+	ledger := NewMockLedgerRepository()
+	users := NewMockUserRepository()
+	service := NewAdjustmentService(NewMockAdjustmentRepository(ledger), users, ledger, AdjustmentConfig{})
+	ctx := context.Background()
+
+	_ = users.Create(ctx, &domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com", IsActive: true})
+	if _, err := NewLedgerService(ledger, LedgerConfig{}).OpenUserAccount(ctx, "user-1", domain.PointTypeCredits); err != nil {
+		t.Fatalf("OpenUserAccount() unexpected error: %v", err)
+	}
+
+	maker := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	checker := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "admin-2", Role: domain.RoleAdmin})
+
+	// Only admin users may act, so every adjustment has an accountable requester and reviewer
+	for name, caller := range map[string]context.Context{
+		"user":    domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser}),
+		"api key": domain.ContextWithPrincipal(ctx, &domain.Principal{APIKeyID: "key-1", Scopes: []string{domain.ScopeCreditsWrite}}),
+	} {
+		if _, err := service.RequestAdjustment(caller, "user-1", "", domain.AdjustmentGrant, 100, domain.AdjustmentReasonGoodwill, ""); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("RequestAdjustment() as %s expected error %v, got %v", name, domain.ErrForbidden, err)
+		}
+	}
+
+	grant, err := service.RequestAdjustment(maker, "user-1", "", domain.AdjustmentGrant, 100, domain.AdjustmentReasonMissingAward, "Order 42 was not credited")
+	if err != nil {
+		t.Fatalf("RequestAdjustment() unexpected error: %v", err)
+	}
+
+	if _, err := service.ApproveAdjustment(maker, grant.ID, ""); !errors.Is(err, domain.ErrSelfApproval) {
+		t.Errorf("ApproveAdjustment() by requester expected error %v, got %v", domain.ErrSelfApproval, err)
+	}
+
+	approved, err := service.ApproveAdjustment(checker, grant.ID, "Checked the order")
+	if err != nil {
+		t.Fatalf("ApproveAdjustment() unexpected error: %v", err)
+	}
+	if approved.Status != domain.AdjustmentApproved || approved.EntryID == nil || approved.ReviewedBy == nil || *approved.ReviewedBy != "admin-2" {
+		t.Errorf("ApproveAdjustment() = %+v, want approved by admin-2 with an entry", approved)
+	}
+
+	if _, err := service.RejectAdjustment(checker, grant.ID, ""); !errors.Is(err, domain.ErrAdjustmentNotPending) {
+		t.Errorf("RejectAdjustment() after approval expected error %v, got %v", domain.ErrAdjustmentNotPending, err)
+	}
+
+	// A deduction the balance cannot cover fails and waits for another review
+	deduct, err := service.RequestAdjustment(maker, "user-1", domain.PointTypeCredits, domain.AdjustmentDeduct, 150, domain.AdjustmentReasonFraud, "")
+	if err != nil {
+		t.Fatalf("RequestAdjustment() unexpected error: %v", err)
+	}
+	if _, err := service.ApproveAdjustment(checker, deduct.ID, ""); !errors.Is(err, domain.ErrInsufficientCredits) {
+		t.Errorf("ApproveAdjustment() expected error %v, got %v", domain.ErrInsufficientCredits, err)
+	}
+
+	pending, err := service.ListAdjustments(checker, domain.AdjustmentPending, 10, 0)
+	if err != nil {
+		t.Fatalf("ListAdjustments() unexpected error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != deduct.ID {
+		t.Errorf("ListAdjustments() = %+v, want only %s pending", pending, deduct.ID)
+	}
+
+	rejected, err := service.RejectAdjustment(checker, deduct.ID, "Balance already spent")
+	if err != nil {
+		t.Fatalf("RejectAdjustment() unexpected error: %v", err)
+	}
+	if rejected.Status != domain.AdjustmentRejected || rejected.EntryID != nil {
+		t.Errorf("RejectAdjustment() = %+v, want rejected without an entry", rejected)
+	}
+
+	account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if account.Balance != 100 {
+		t.Errorf("balance = %d, want 100", account.Balance)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/token"
+)
+
+// apiKeySecretSize is the number of random bytes in an API key
+const apiKeySecretSize = 32
+
+// apiKeyDisplayLength is the number of leading characters kept to identify a key in listings
+const apiKeyDisplayLength = 12
+
+// APIKeyRepository defines what the API key service needs from the data layer
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id string) (*domain.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// APIKeyService provides business logic for API key management and authentication
+type APIKeyService struct {
+	apiKeyRepo APIKeyRepository
+	userRepo   UserRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo APIKeyRepository, userRepo UserRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// CreateAPIKey creates a new API key and returns it together with its plaintext secret,
+// which is never stored and cannot be retrieved again
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name, ownerUserID string, scopes []string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, "", err
+	}
+
+	// Default the owner to the calling admin
+	if ownerUserID == "" {
+		if principal, ok := domain.PrincipalFromContext(ctx); ok {
+			ownerUserID = principal.UserID
+		}
+	}
+
+	if ownerUserID == "" {
+		return nil, "", domain.ErrInvalidUserID
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, ownerUserID); err != nil {
+		return nil, "", fmt.Errorf("failed to get API key owner: %w", err)
+	}
+
+	secret, err := token.GenerateOpaque(apiKeySecretSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	plaintext := domain.APIKeyPrefix + secret
+
+	key, err := domain.NewAPIKey(name, plaintext[:apiKeyDisplayLength], token.HashOpaque(plaintext), ownerUserID, scopes, expiresAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	return key, plaintext, nil
+}
+
+// ListAPIKeys retrieves a paginated list of API keys
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, limit, offset int) ([]*domain.APIKey, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	keys, err := s.apiKeyRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key so it can no longer authenticate
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return err
+	}
+
+	if id == "" {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate verifies an API key and returns the principal it grants
+func (s *APIKeyService) Authenticate(ctx context.Context, apiKey string) (*domain.Principal, error) {
+	key, err := s.apiKeyRepo.GetByHash(ctx, token.HashOpaque(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate API key: %w", err)
+	}
+
+	if err := key.EnsureUsable(time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Keys stop working while their owner is deactivated
+	owner, err := s.userRepo.GetByID(ctx, key.OwnerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key owner: %w", err)
+	}
+
+	if err := owner.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	return key.Principal(), nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockAPIKeyRepository implements APIKeyRepository for testing
+type MockAPIKeyRepository struct {
+	keys   map[string]*domain.APIKey
+	nextID int
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{
+		keys: make(map[string]*domain.APIKey),
+	}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	m.nextID++
+	key.ID = fmt.Sprintf("key-%d", m.nextID)
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	key, exists := m.keys[id]
+	if !exists {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, domain.ErrInvalidAPIKey
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, limit, offset int) ([]*domain.APIKey, error) {
+	keys := make([]*domain.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	key, exists := m.keys[id]
+	if !exists {
+		return domain.ErrAPIKeyNotFound
+	}
+	key.Revoke(revokedAt)
+	return nil
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	userRepo.users["admin-1"] = &domain.User{ID: "admin-1", Email: "admin@example.com", Name: "Admin", Role: domain.RoleAdmin, IsActive: true}
+
+	service := NewAPIKeyService(NewMockAPIKeyRepository(), userRepo)
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	key, plaintext, err := service.CreateAPIKey(adminCtx, "checkout", "", []string{domain.ScopeUsersRead}, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIKey() unexpected error: %v", err)
+	}
+
+	if key.OwnerUserID != "admin-1" {
+		t.Errorf("CreateAPIKey() OwnerUserID = %v, want admin-1", key.OwnerUserID)
+	}
+
+	if key.KeyHash == plaintext {
+		t.Errorf("CreateAPIKey() should store a hash of the key, not the key itself")
+	}
+
+	principal, err := service.Authenticate(context.Background(), plaintext)
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error: %v", err)
+	}
+
+	if !principal.HasScope(domain.ScopeUsersRead) || principal.HasScope(domain.ScopeUsersWrite) {
+		t.Errorf("Authenticate() scopes = %v, want only %s", principal.Scopes, domain.ScopeUsersRead)
+	}
+
+	if principal.IsAdmin() || !principal.CanAccessUser("user-1", domain.ScopeUsersRead) || principal.CanAccessUser("user-1", domain.ScopeUsersWrite) {
+		t.Errorf("Authenticate() principal should be limited to its scopes")
+	}
+
+	if err := service.RevokeAPIKey(adminCtx, key.ID); err != nil {
+		t.Fatalf("RevokeAPIKey() unexpected error: %v", err)
+	}
+
+	if _, err := service.Authenticate(context.Background(), plaintext); !errors.Is(err, domain.ErrAPIKeyRevoked) {
+		t.Errorf("Authenticate() revoked key expected error %v, got %v", domain.ErrAPIKeyRevoked, err)
+	}
+
+	if _, err := service.Authenticate(context.Background(), domain.APIKeyPrefix+"unknown"); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Authenticate() unknown key expected error %v, got %v", domain.ErrInvalidAPIKey, err)
+	}
+}
+
+func TestAPIKeyService_CreateAPIKeyValidation(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	userRepo.users["admin-1"] = &domain.User{ID: "admin-1", Email: "admin@example.com", Name: "Admin", Role: domain.RoleAdmin, IsActive: true}
+
+	service := NewAPIKeyService(NewMockAPIKeyRepository(), userRepo)
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	keyCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersWrite}})
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		keyName string
+		scopes  []string
+		expires *time.Time
+		errType error
+	}{
+		{"regular user", userCtx, "crm", []string{domain.ScopeUsersRead}, nil, domain.ErrForbidden},
+		{"API key caller", keyCtx, "crm", []string{domain.ScopeUsersRead}, nil, domain.ErrForbidden},
+		{"unknown scope", adminCtx, "crm", []string{"everything"}, nil, domain.ErrInvalidAPIKeyScope},
+		{"no scopes", adminCtx, "crm", nil, nil, domain.ErrInvalidAPIKeyScope},
+		{"empty name", adminCtx, " ", []string{domain.ScopeUsersRead}, nil, domain.ErrInvalidAPIKeyName},
+		{"expiry in the past", adminCtx, "crm", []string{domain.ScopeUsersRead}, &past, domain.ErrInvalidAPIKeyExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.CreateAPIKey(tt.ctx, tt.keyName, "", tt.scopes, tt.expires)
+			if !errors.Is(err, tt.errType) {
+				t.Errorf("CreateAPIKey() expected error %v, got %v", tt.errType, err)
+			}
+		})
+	}
+}
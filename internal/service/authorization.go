@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// Calls without a principal in the context come from trusted internal callers
+// (background jobs, other services) and are not restricted.
+
+// authorizeUserAccess checks that the caller may access the given user's data;
+// API keys must carry scope
+func authorizeUserAccess(ctx context.Context, userID, scope string) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if ok && !principal.CanAccessUser(userID, scope) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeAdmin checks that the caller is an admin or an API key carrying scope;
+// an empty scope reserves the operation for admin users
+func authorizeAdmin(ctx context.Context, scope string) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if ok && !principal.CanAdminister(scope) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeAdminUser checks that the caller is an admin user and returns their user ID;
+// operations that must record which admin acted are closed to API keys and internal callers
+func authorizeAdminUser(ctx context.Context) (string, error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || !principal.IsAdmin() || principal.UserID == "" {
+		return "", domain.ErrForbidden
+	}
+	return principal.UserID, nil
+}
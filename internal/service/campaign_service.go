@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// CampaignRepository defines what the campaign and earn services need from the data layer
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *domain.Campaign) error
+	GetByID(ctx context.Context, id string) (*domain.Campaign, error)
+	Update(ctx context.Context, campaign *domain.Campaign) error
+	List(ctx context.Context, limit, offset int) ([]*domain.Campaign, error)
+	ListActive(ctx context.Context, at time.Time) ([]*domain.Campaign, error)
+}
+
+// CampaignService manages promotional campaigns; the earn service applies them to awards
+type CampaignService struct {
+	campaignRepo CampaignRepository
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(campaignRepo CampaignRepository) *CampaignService {
+	return &CampaignService{
+		campaignRepo: campaignRepo,
+	}
+}
+
+// CreateCampaign creates a new campaign
+func (s *CampaignService) CreateCampaign(ctx context.Context, spec domain.CampaignSpec) (*domain.Campaign, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	campaign, err := domain.NewCampaign(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to save campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+// GetCampaign retrieves a campaign by ID with the credits it has spent so far
+func (s *CampaignService) GetCampaign(ctx context.Context, id string) (*domain.Campaign, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+// UpdateCampaign replaces a campaign's settings; awards already boosted keep their bonus
+func (s *CampaignService) UpdateCampaign(ctx context.Context, id string, spec domain.CampaignSpec) (*domain.Campaign, error) {
+	campaign, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := campaign.Update(spec); err != nil {
+		return nil, fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to save campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+// ListCampaigns retrieves a paginated list of campaigns with the credits each has spent so far
+func (s *CampaignService) ListCampaigns(ctx context.Context, limit, offset int) ([]*domain.Campaign, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	campaigns, err := s.campaignRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	return campaigns, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockCampaignRepository implements CampaignRepository for testing
+type MockCampaignRepository struct {
+	campaigns map[string]*domain.Campaign
+	nextID    int
+}
+
+func NewMockCampaignRepository() *MockCampaignRepository {
+	return &MockCampaignRepository{
+		campaigns: make(map[string]*domain.Campaign),
+	}
+}
+
+func (m *MockCampaignRepository) Create(ctx context.Context, campaign *domain.Campaign) error {
+	m.nextID++
+	campaign.ID = fmt.Sprintf("campaign-%d", m.nextID)
+	m.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (m *MockCampaignRepository) GetByID(ctx context.Context, id string) (*domain.Campaign, error) {
+	campaign, exists := m.campaigns[id]
+	if !exists {
+		return nil, domain.ErrCampaignNotFound
+	}
+	return campaign, nil
+}
+
+func (m *MockCampaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	if _, exists := m.campaigns[campaign.ID]; !exists {
+		return domain.ErrCampaignNotFound
+	}
+	m.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (m *MockCampaignRepository) List(ctx context.Context, limit, offset int) ([]*domain.Campaign, error) {
+	campaigns := make([]*domain.Campaign, 0, len(m.campaigns))
+	for _, campaign := range m.campaigns {
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, nil
+}
+
+func (m *MockCampaignRepository) ListActive(ctx context.Context, at time.Time) ([]*domain.Campaign, error) {
+	var campaigns []*domain.Campaign
+	for _, campaign := range m.campaigns {
+		if campaign.IsActiveAt(at) && campaign.RemainingBudget() > 0 {
+			copied := *campaign
+			campaigns = append(campaigns, &copied)
+		}
+	}
+	return campaigns, nil
+}
+
+func TestCampaignService_BoostsAwards(t *testing.T) {
+	ledger := NewMockLedgerRepository()
+	campaigns := NewMockCampaignRepository()
+	users := NewMockUserRepository()
+	earnService := NewEarnService(NewMockEarnRuleRepository(ledger, campaigns), campaigns, users, ledger, &MockTierRecalculator{}, EarnConfig{})
+	service := NewCampaignService(campaigns)
+	ctx := context.Background()
+	now := time.Now()
+
+	_ = users.Create(ctx, &domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com", IsActive: true, Tier: &domain.TierRef{ID: "silver", Name: "Silver"}})
+	if _, err := NewLedgerService(ledger, LedgerConfig{}).OpenUserAccount(ctx, "user-1", domain.PointTypeCredits); err != nil {
+		t.Fatalf("OpenUserAccount() unexpected error: %v", err)
+	}
+
+	if _, err := earnService.CreateRule(ctx, domain.EarnRuleSpec{Name: "Cashback", EventType: domain.EarnEventPurchase, AwardType: domain.AwardPercentage, Amount: 1000, BaseAttribute: "amount", IsActive: true}); err != nil {
+		t.Fatalf("CreateRule() unexpected error: %v", err)
+	}
+
+	double, err := service.CreateCampaign(ctx, domain.CampaignSpec{
+		Name:       "Double credits weekend",
+		StartsAt:   now.Add(-time.Hour),
+		EndsAt:     now.Add(time.Hour),
+		Audience:   domain.CampaignAudience{EventTypes: []string{domain.EarnEventPurchase}},
+		Multiplier: 20000,
+		Budget:     150,
+		IsActive:   true,
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign() unexpected error: %v", err)
+	}
+	if _, err := service.CreateCampaign(ctx, domain.CampaignSpec{
+		Name:     "Gold members bonus",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+		Audience: domain.CampaignAudience{TierIDs: []string{"gold"}},
+		Bonus:    500,
+		Budget:   5000,
+		IsActive: true,
+	}); err != nil {
+		t.Fatalf("CreateCampaign() unexpected error: %v", err)
+	}
+
+	// The second purchase only gets what is left of the budget and the third none
+	for i, want := range []struct{ amount, bonus int64 }{{100, 100}, {100, 50}, {100, 0}} {
+		purchase, _ := domain.NewEarnEvent(domain.EarnEventPurchase, "user-1", fmt.Sprintf("order-%d", i), map[string]any{"amount": float64(1000)}, time.Time{})
+		awards, err := earnService.ProcessEvent(ctx, purchase)
+		if err != nil {
+			t.Fatalf("ProcessEvent() unexpected error: %v", err)
+		}
+		if len(awards) != 1 || awards[0].Amount != want.amount || awards[0].Bonus != want.bonus {
+			t.Fatalf("ProcessEvent() purchase %d awards = %+v, want amount %d with bonus %d", i, awards, want.amount, want.bonus)
+		}
+		if want.bonus > 0 && (awards[0].CampaignID == nil || *awards[0].CampaignID != double.ID) {
+			t.Errorf("ProcessEvent() purchase %d CampaignID = %v, want %s", i, awards[0].CampaignID, double.ID)
+		}
+	}
+
+	account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if account.Balance != 450 {
+		t.Errorf("balance = %d, want 450", account.Balance)
+	}
+
+	adminCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	spent, err := service.GetCampaign(adminCtx, double.ID)
+	if err != nil {
+		t.Fatalf("GetCampaign() unexpected error: %v", err)
+	}
+	if spent.Spent != 150 || spent.RemainingBudget() != 0 {
+		t.Errorf("GetCampaign() spent %d, remaining %d; want 150 and 0", spent.Spent, spent.RemainingBudget())
+	}
+
+	spec := spent.CampaignSpec
+	spec.Budget = 100
+	if _, err := service.UpdateCampaign(adminCtx, double.ID, spec); !errors.Is(err, domain.ErrInvalidCampaignBudget) {
+		t.Errorf("UpdateCampaign() below spent expected error %v, got %v", domain.ErrInvalidCampaignBudget, err)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	if _, err := service.ListCampaigns(userCtx, 10, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListCampaigns() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+}
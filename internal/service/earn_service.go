@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// EarnRuleRepository defines what the earn service needs from the data layer
+type EarnRuleRepository interface {
+	Create(ctx context.Context, rule *domain.EarnRule) error
+	GetByID(ctx context.Context, id string) (*domain.EarnRule, error)
+	Update(ctx context.Context, rule *domain.EarnRule) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, limit, offset int) ([]*domain.EarnRule, error)
+	ListActiveForEvent(ctx context.Context, eventType string, at time.Time) ([]*domain.EarnRule, error)
+	CountAwards(ctx context.Context, ruleID, userID string) (int, error)
+	RecordAward(ctx context.Context, rule *domain.EarnRule, award *domain.EarnAward, entry *domain.JournalEntry) error
+}
+
+// TierRecalculator re-evaluates a user's loyalty tier once they earned qualifying points
+type TierRecalculator interface {
+	RecalculateTier(ctx context.Context, userID string) (*domain.TierChange, error)
+}
+
+// EarnConfig holds earn settings
+type EarnConfig struct {
+	PointTypes domain.PointTypePolicies // how long awarded points of each type stay usable
+}
+
+// EarnService manages earn rules and awards credits for the events they match, boosted by running campaigns
+type EarnService struct {
+	ruleRepo     EarnRuleRepository
+	campaignRepo CampaignRepository
+	userRepo     UserRepository
+	ledgerRepo   LedgerRepository
+	tiers        TierRecalculator
+	config       EarnConfig
+}
+
+// NewEarnService creates a new earn service
+func NewEarnService(ruleRepo EarnRuleRepository, campaignRepo CampaignRepository, userRepo UserRepository, ledgerRepo LedgerRepository, tiers TierRecalculator, config EarnConfig) *EarnService {
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &EarnService{
+		ruleRepo:     ruleRepo,
+		campaignRepo: campaignRepo,
+		userRepo:     userRepo,
+		ledgerRepo:   ledgerRepo,
+		tiers:        tiers,
+		config:       config,
+	}
+}
+
+// CreateRule creates a new earn rule
+func (s *EarnService) CreateRule(ctx context.Context, spec domain.EarnRuleSpec) (*domain.EarnRule, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	rule, err := domain.NewEarnRule(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create earn rule: %w", err)
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save earn rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// GetRule retrieves an earn rule by ID
+func (s *EarnService) GetRule(ctx context.Context, id string) (*domain.EarnRule, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earn rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateRule replaces an earn rule's settings
+func (s *EarnService) UpdateRule(ctx context.Context, id string, spec domain.EarnRuleSpec) (*domain.EarnRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Update(spec); err != nil {
+		return nil, fmt.Errorf("failed to update earn rule: %w", err)
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save earn rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule deletes an earn rule; credits it already awarded are kept
+func (s *EarnService) DeleteRule(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete earn rule: %w", err)
+	}
+
+	return nil
+}
+
+// ListRules retrieves a paginated list of earn rules
+func (s *EarnService) ListRules(ctx context.Context, limit, offset int) ([]*domain.EarnRule, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rules, err := s.ruleRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list earn rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// Evaluate computes the awards the active rules grant for an event without posting them
+func (s *EarnService) Evaluate(ctx context.Context, event *domain.EarnEvent) ([]*domain.EarnAward, error) {
+	_, awards, err := s.evaluate(ctx, event)
+	return awards, err
+}
+
+// ProcessEvent evaluates an event against the active rules and credits the user's wallet with each award
+// and its campaign bonus. Awards already paid for the same event reference, or beyond a rule's per-user
+// limit, are skipped; awards whose campaign ran out of budget meanwhile are paid without the bonus.
+// Events awarding qualifying points recalculate the user's tier.
+func (s *EarnService) ProcessEvent(ctx context.Context, event *domain.EarnEvent) ([]*domain.EarnAward, error) {
+	rules, awards, err := s.evaluate(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(awards) == 0 {
+		return awards, nil
+	}
+
+	paid := make([]*domain.EarnAward, 0, len(awards))
+	qualifying := false
+	for i, award := range awards {
+		rule := rules[i]
+		if rule.PointType == domain.QualifyingPointType {
+			qualifying = true
+		}
+
+		err := s.recordAward(ctx, event, rule, award)
+		if errors.Is(err, domain.ErrCampaignBudgetExhausted) {
+			award.ClearBoost()
+			err = s.recordAward(ctx, event, rule, award)
+		}
+		if errors.Is(err, domain.ErrDuplicateEntry) || errors.Is(err, domain.ErrEarnRuleCapReached) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record earn award: %w", err)
+		}
+
+		paid = append(paid, award)
+	}
+
+	// Replaying the event after a failed recalculation pays nothing twice but recalculates again
+	if qualifying {
+		if _, err := s.tiers.RecalculateTier(ctx, event.UserID); err != nil {
+			return nil, fmt.Errorf("failed to recalculate tier: %w", err)
+		}
+	}
+
+	return paid, nil
+}
+
+// recordAward pays an award, with its campaign bonus, into the user's wallet
+func (s *EarnService) recordAward(ctx context.Context, event *domain.EarnEvent, rule *domain.EarnRule, award *domain.EarnAward) error {
+	issuance, err := s.ledgerRepo.GetSystemAccount(ctx, rule.PointType, domain.AccountIssuance)
+	if err != nil {
+		return fmt.Errorf("failed to get issuance account: %w", err)
+	}
+
+	account, err := openWalletAccount(ctx, s.ledgerRepo, event.UserID, rule.PointType, domain.AccountUserAvailable)
+	if err != nil {
+		return err
+	}
+
+	policy, err := s.config.PointTypes.Policy(rule.PointType)
+	if err != nil {
+		return err
+	}
+
+	entry, err := domain.NewTransferEntry(domain.EntryKindEarn, rule.PointType, event.UserID, issuance.ID, account.ID, award.Total(), event.EntryReference(rule.ID), rule.Name)
+	if err != nil {
+		return fmt.Errorf("failed to build earn entry: %w", err)
+	}
+	entry.ExpireCreditsAfter(policy.Lifetime)
+
+	return s.ruleRepo.RecordAward(ctx, rule, award, entry)
+}
+
+// evaluate returns the awards for an event together with the rule granting each one
+func (s *EarnService) evaluate(ctx context.Context, event *domain.EarnEvent) ([]*domain.EarnRule, []*domain.EarnAward, error) {
+	if err := authorizeAdmin(ctx, domain.ScopeCreditsWrite); err != nil {
+		return nil, nil, err
+	}
+
+	candidates, err := s.ruleRepo.ListActiveForEvent(ctx, event.Type, event.OccurredAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get earn rules: %w", err)
+	}
+
+	rules := make([]*domain.EarnRule, 0, len(candidates))
+	awards := make([]*domain.EarnAward, 0, len(candidates))
+	for _, rule := range candidates {
+		amount := rule.ComputeAward(event)
+		if amount <= 0 {
+			continue
+		}
+
+		if rule.MaxAwardsPerUser != nil {
+			count, err := s.ruleRepo.CountAwards(ctx, rule.ID, event.UserID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to count earn awards: %w", err)
+			}
+			if count >= *rule.MaxAwardsPerUser {
+				continue
+			}
+		}
+
+		rules = append(rules, rule)
+		awards = append(awards, &domain.EarnAward{
+			RuleID:    rule.ID,
+			UserID:    event.UserID,
+			PointType: rule.PointType,
+			Amount:    amount,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	if err := s.boost(ctx, event, awards); err != nil {
+		return nil, nil, err
+	}
+
+	return rules, awards, nil
+}
+
+// boost adds the bonus of the campaign paying the most to each credit award, drawing down the
+// campaigns' remaining budgets as it goes so awards for one event cannot overspend them together
+func (s *EarnService) boost(ctx context.Context, event *domain.EarnEvent, awards []*domain.EarnAward) error {
+	if !slices.ContainsFunc(awards, func(award *domain.EarnAward) bool { return award.PointType == domain.PointTypeCredits }) {
+		return nil
+	}
+
+	campaigns, err := s.campaignRepo.ListActive(ctx, event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to get campaigns: %w", err)
+	}
+
+	if len(campaigns) == 0 {
+		return nil
+	}
+
+	// The user's tier is only looked up for campaigns targeting tiers
+	var tierID *string
+	if slices.ContainsFunc(campaigns, func(campaign *domain.Campaign) bool { return len(campaign.Audience.TierIDs) > 0 }) {
+		user, err := s.userRepo.GetByID(ctx, event.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Tier != nil {
+			tierID = &user.Tier.ID
+		}
+	}
+
+	for _, award := range awards {
+		if award.PointType != domain.PointTypeCredits {
+			continue
+		}
+
+		campaign, bonus := domain.BestCampaign(campaigns, event, tierID, award.Amount)
+		if campaign == nil {
+			continue
+		}
+
+		award.Boost(campaign, bonus)
+		campaign.Spent += bonus
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockEarnRuleRepository implements EarnRuleRepository for testing
+type MockEarnRuleRepository struct {
+	rules     map[string]*domain.EarnRule
+	awards    []*domain.EarnAward
+	ledger    *MockLedgerRepository
+	campaigns *MockCampaignRepository
+	nextID    int
+}
+
+func NewMockEarnRuleRepository(ledger *MockLedgerRepository, campaigns *MockCampaignRepository) *MockEarnRuleRepository {
+	return &MockEarnRuleRepository{
+		rules:     make(map[string]*domain.EarnRule),
+		ledger:    ledger,
+		campaigns: campaigns,
+	}
+}
+
+func (m *MockEarnRuleRepository) Create(ctx context.Context, rule *domain.EarnRule) error {
+	m.nextID++
+	rule.ID = fmt.Sprintf("rule-%d", m.nextID)
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *MockEarnRuleRepository) GetByID(ctx context.Context, id string) (*domain.EarnRule, error) {
+	rule, exists := m.rules[id]
+	if !exists {
+		return nil, domain.ErrEarnRuleNotFound
+	}
+	return rule, nil
+}
+
+func (m *MockEarnRuleRepository) Update(ctx context.Context, rule *domain.EarnRule) error {
+	if _, exists := m.rules[rule.ID]; !exists {
+		return domain.ErrEarnRuleNotFound
+	}
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *MockEarnRuleRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.rules[id]; !exists {
+		return domain.ErrEarnRuleNotFound
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *MockEarnRuleRepository) List(ctx context.Context, limit, offset int) ([]*domain.EarnRule, error) {
+	rules := make([]*domain.EarnRule, 0, len(m.rules))
+	for _, rule := range m.rules {
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (m *MockEarnRuleRepository) ListActiveForEvent(ctx context.Context, eventType string, at time.Time) ([]*domain.EarnRule, error) {
+	var rules []*domain.EarnRule
+	for _, rule := range m.rules {
+		if rule.EventType == eventType && rule.IsActiveAt(at) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *MockEarnRuleRepository) CountAwards(ctx context.Context, ruleID, userID string) (int, error) {
+	count := 0
+	for _, award := range m.awards {
+		if award.RuleID == ruleID && award.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockEarnRuleRepository) RecordAward(ctx context.Context, rule *domain.EarnRule, award *domain.EarnAward, entry *domain.JournalEntry) error {
+	if rule.MaxAwardsPerUser != nil {
+		if count, _ := m.CountAwards(ctx, rule.ID, award.UserID); count >= *rule.MaxAwardsPerUser {
+			return domain.ErrEarnRuleCapReached
+		}
+	}
+
+	var campaign *domain.Campaign
+	if award.CampaignID != nil {
+		campaign = m.campaigns.campaigns[*award.CampaignID]
+		if campaign == nil || campaign.Spent+award.Bonus > campaign.Budget {
+			return domain.ErrCampaignBudgetExhausted
+		}
+	}
+
+	if err := m.ledger.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	if campaign != nil {
+		campaign.Spent += award.Bonus
+	}
+	award.EntryID = entry.ID
+	m.awards = append(m.awards, award)
+	return nil
+}
+
+// MockTierRecalculator records the users whose tier was recalculated
+type MockTierRecalculator struct {
+	userIDs []string
+}
+
+func (m *MockTierRecalculator) RecalculateTier(ctx context.Context, userID string) (*domain.TierChange, error) {
+	m.userIDs = append(m.userIDs, userID)
+	return nil, nil
+}
+
+func TestEarnService_ProcessEvent(t *testing.T) {
+	ledger := NewMockLedgerRepository()
+	campaigns := NewMockCampaignRepository()
+	repo := NewMockEarnRuleRepository(ledger, campaigns)
+	tiers := &MockTierRecalculator{}
+	service := NewEarnService(repo, campaigns, NewMockUserRepository(), ledger, tiers, EarnConfig{})
+	ctx := context.Background()
+
+	if _, err := NewLedgerService(ledger, LedgerConfig{}).OpenUserAccount(ctx, "user-1", domain.PointTypeCredits); err != nil {
+		t.Fatalf("OpenUserAccount() unexpected error: %v", err)
+	}
+
+	once := 1
+	if _, err := service.CreateRule(ctx, domain.EarnRuleSpec{Name: "Signup bonus", EventType: domain.EarnEventSignup, AwardType: domain.AwardFixed, Amount: 100, MaxAwardsPerUser: &once, IsActive: true}); err != nil {
+		t.Fatalf("CreateRule() unexpected error: %v", err)
+	}
+	if _, err := service.CreateRule(ctx, domain.EarnRuleSpec{Name: "Cashback", EventType: domain.EarnEventPurchase, AwardType: domain.AwardPercentage, Amount: 1000, BaseAttribute: "amount", IsActive: true}); err != nil {
+		t.Fatalf("CreateRule() unexpected error: %v", err)
+	}
+
+	signup, _ := domain.NewEarnEvent(domain.EarnEventSignup, "user-1", "", nil, time.Time{})
+	for i := 0; i < 2; i++ {
+		if _, err := service.ProcessEvent(ctx, signup); err != nil {
+			t.Fatalf("ProcessEvent() unexpected error: %v", err)
+		}
+	}
+
+	purchase, _ := domain.NewEarnEvent(domain.EarnEventPurchase, "user-1", "order-1", map[string]any{"amount": float64(250)}, time.Time{})
+	awards, err := service.ProcessEvent(ctx, purchase)
+	if err != nil {
+		t.Fatalf("ProcessEvent() unexpected error: %v", err)
+	}
+	if len(awards) != 1 || awards[0].Amount != 25 || awards[0].EntryID == "" {
+		t.Errorf("ProcessEvent() awards = %+v, want one posted award of 25", awards)
+	}
+
+	// Retried events with the same reference are not paid twice
+	awards, err = service.ProcessEvent(ctx, purchase)
+	if err != nil {
+		t.Fatalf("ProcessEvent() retry unexpected error: %v", err)
+	}
+	if len(awards) != 0 {
+		t.Errorf("ProcessEvent() retry awards = %+v, want none", awards)
+	}
+
+	account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if account.Balance != 125 {
+		t.Errorf("ProcessEvent() balance = %d, want 125", account.Balance)
+	}
+	if len(tiers.userIDs) != 0 {
+		t.Errorf("ProcessEvent() recalculated tiers %v without qualifying points", tiers.userIDs)
+	}
+
+	if _, err := service.CreateRule(ctx, domain.EarnRuleSpec{Name: "Referral status", EventType: domain.EarnEventReferral, AwardType: domain.AwardFixed, Amount: 50, PointType: domain.PointTypeTierPoints, IsActive: true}); err != nil {
+		t.Fatalf("CreateRule() unexpected error: %v", err)
+	}
+	referral, _ := domain.NewEarnEvent(domain.EarnEventReferral, "user-1", "referral-1", nil, time.Time{})
+	if _, err := service.ProcessEvent(ctx, referral); err != nil {
+		t.Fatalf("ProcessEvent() unexpected error: %v", err)
+	}
+	points, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeTierPoints, domain.AccountUserAvailable)
+	if points == nil || points.Balance != 50 || len(tiers.userIDs) != 1 || tiers.userIDs[0] != "user-1" {
+		t.Errorf("ProcessEvent() tier points %+v, recalculated %v; want 50 and user-1", points, tiers.userIDs)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	if _, err := service.ProcessEvent(userCtx, purchase); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ProcessEvent() as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+	if _, err := service.CreateRule(userCtx, domain.EarnRuleSpec{}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateRule() as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+
+	keyCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{APIKeyID: "key-1", Scopes: []string{domain.ScopeCreditsWrite}})
+	preview, err := service.Evaluate(keyCtx, purchase)
+	if err != nil {
+		t.Fatalf("Evaluate() unexpected error: %v", err)
+	}
+	if len(preview) != 1 || preview[0].EntryID != "" {
+		t.Errorf("Evaluate() = %+v, want one unposted award", preview)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// IdempotencyRepository defines what the idempotency service needs from the data layer
+type IdempotencyRepository interface {
+	Create(ctx context.Context, record *domain.IdempotencyRecord) error
+	Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	Delete(ctx context.Context, record *domain.IdempotencyRecord) error
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// IdempotencyConfig holds idempotency key settings
+type IdempotencyConfig struct {
+	KeyTTL          time.Duration // how long a completed response is replayed
+	InProgressLease time.Duration // how long a key stays reserved by a request that never completes
+}
+
+// IdempotencyService makes retried mutating requests safe by replaying the response to the first attempt
+type IdempotencyService struct {
+	idempotencyRepo IdempotencyRepository
+	config          IdempotencyConfig
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(idempotencyRepo IdempotencyRepository, config IdempotencyConfig) *IdempotencyService {
+	if config.KeyTTL <= 0 {
+		config.KeyTTL = 24 * time.Hour // Default key lifetime
+	}
+	if config.InProgressLease <= 0 {
+		config.InProgressLease = time.Minute // Default lease, longer than any request should take
+	}
+
+	return &IdempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		config:          config,
+	}
+}
+
+// Begin reserves the key for a request. If the key was already used for the same request, the
+// stored record is returned and IsCompleted reports whether its response can be replayed.
+// A key used with a different request is rejected with ErrIdempotencyKeyReused. A key still in
+// progress after its lease, e.g. because the server crashed mid-request, is taken over.
+func (s *IdempotencyService) Begin(ctx context.Context, scope, key, method, path string, body []byte) (*domain.IdempotencyRecord, error) {
+	record, err := domain.NewIdempotencyRecord(scope, key, method, path, body, s.config.InProgressLease)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.idempotencyRepo.Create(ctx, record)
+	if err == nil {
+		return record, nil
+	}
+	if !errors.Is(err, domain.ErrIdempotencyKeyExists) {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	existing, err := s.idempotencyRepo.Get(ctx, scope, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if existing.Fingerprint != record.Fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+
+	if !existing.IsCompleted() {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response sent for a reserved key so retries replay it
+func (s *IdempotencyService) Complete(ctx context.Context, record *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	record.Complete(statusCode, contentType, body, s.config.KeyTTL)
+
+	if err := s.idempotencyRepo.Complete(ctx, record); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Abandon releases a reserved key whose request failed without a response worth replaying
+func (s *IdempotencyService) Abandon(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if err := s.idempotencyRepo.Delete(ctx, record); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredKeys permanently removes expired idempotency keys and returns how many were removed
+func (s *IdempotencyService) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	purged, err := s.idempotencyRepo.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockIdempotencyRepository implements IdempotencyRepository for testing
+type MockIdempotencyRepository struct {
+	records map[string]*domain.IdempotencyRecord
+}
+
+func NewMockIdempotencyRepository() *MockIdempotencyRepository {
+	return &MockIdempotencyRepository{
+		records: make(map[string]*domain.IdempotencyRecord),
+	}
+}
+
+func (m *MockIdempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if existing, exists := m.records[record.Scope+"/"+record.Key]; exists && !existing.IsExpiredAt(time.Now()) {
+		return domain.ErrIdempotencyKeyExists
+	}
+	stored := *record
+	m.records[record.Scope+"/"+record.Key] = &stored
+	return nil
+}
+
+func (m *MockIdempotencyRepository) Get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	record, exists := m.records[scope+"/"+key]
+	if !exists {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+	stored := *record
+	return &stored, nil
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	stored := *record
+	m.records[record.Scope+"/"+record.Key] = &stored
+	return nil
+}
+
+func (m *MockIdempotencyRepository) Delete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if existing, exists := m.records[record.Scope+"/"+record.Key]; exists && !existing.IsCompleted() {
+		delete(m.records, record.Scope+"/"+record.Key)
+	}
+	return nil
+}
+
+func (m *MockIdempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	for id, record := range m.records {
+		if record.IsExpiredAt(before) {
+			delete(m.records, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	repo := NewMockIdempotencyRepository()
+	service := NewIdempotencyService(repo, IdempotencyConfig{})
+	ctx := context.Background()
+	body := []byte(`{"amount":10}`)
+
+	record, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", body)
+	if err != nil {
+		t.Fatalf("Begin() unexpected error: %v", err)
+	}
+	if record.IsCompleted() {
+		t.Fatalf("Begin() = %+v, want a new reservation", record)
+	}
+
+	// A retry while the first request is running must not run it again
+	if _, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", body); !errors.Is(err, domain.ErrIdempotencyKeyInProgress) {
+		t.Errorf("Begin() in progress expected %v, got %v", domain.ErrIdempotencyKeyInProgress, err)
+	}
+
+	if err := service.Complete(ctx, record, 201, "application/json", []byte(`{"id":"transfer-1"}`)); err != nil {
+		t.Fatalf("Complete() unexpected error: %v", err)
+	}
+
+	replay, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", body)
+	if err != nil {
+		t.Fatalf("Begin() retry unexpected error: %v", err)
+	}
+	if !replay.IsCompleted() || replay.StatusCode != 201 || string(replay.ResponseBody) != `{"id":"transfer-1"}` {
+		t.Errorf("Begin() retry = %+v, want the stored response", replay)
+	}
+
+	if _, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", []byte(`{"amount":20}`)); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Errorf("Begin() with different payload expected %v, got %v", domain.ErrIdempotencyKeyReused, err)
+	}
+
+	// Keys of different callers never collide
+	if _, err := service.Begin(ctx, "user:2", "key-1", "POST", "/api/v1/transfers", []byte(`{"amount":20}`)); err != nil {
+		t.Errorf("Begin() for another caller unexpected error: %v", err)
+	}
+
+	// An abandoned key can be retried from scratch
+	failed, _ := service.Begin(ctx, "user:1", "key-2", "POST", "/api/v1/transfers", body)
+	if err := service.Abandon(ctx, failed); err != nil {
+		t.Fatalf("Abandon() unexpected error: %v", err)
+	}
+	if retried, err := service.Begin(ctx, "user:1", "key-2", "POST", "/api/v1/transfers", body); err != nil || retried.IsCompleted() {
+		t.Errorf("Begin() after abandon = %+v, %v, want a new reservation", retried, err)
+	}
+
+	if _, err := service.Begin(ctx, "user:1", "", "POST", "/api/v1/transfers", body); !errors.Is(err, domain.ErrInvalidIdempotencyKey) {
+		t.Errorf("Begin() with empty key expected %v, got %v", domain.ErrInvalidIdempotencyKey, err)
+	}
+}
+
+func TestIdempotencyService_InProgressLease(t *testing.T) {
+	repo := NewMockIdempotencyRepository()
+	service := NewIdempotencyService(repo, IdempotencyConfig{KeyTTL: 24 * time.Hour, InProgressLease: time.Minute})
+	ctx := context.Background()
+	body := []byte(`{"amount":10}`)
+
+	record, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", body)
+	if err != nil {
+		t.Fatalf("Begin() unexpected error: %v", err)
+	}
+	if record.IsExpiredAt(time.Now()) || !record.IsExpiredAt(time.Now().Add(time.Minute)) {
+		t.Errorf("Begin() ExpiresAt = %v, want the in-progress lease", record.ExpiresAt)
+	}
+
+	// The request never finished, e.g. the server crashed, so the lease runs out
+	repo.records["user:1/key-1"].ExpiresAt = time.Now().Add(-time.Second)
+
+	retried, err := service.Begin(ctx, "user:1", "key-1", "POST", "/api/v1/transfers", body)
+	if err != nil {
+		t.Fatalf("Begin() after lease expiry unexpected error: %v", err)
+	}
+	if retried.IsCompleted() {
+		t.Fatalf("Begin() after lease expiry = %+v, want a new reservation", retried)
+	}
+
+	if err := service.Complete(ctx, retried, 201, "application/json", []byte(`{"id":"transfer-1"}`)); err != nil {
+		t.Fatalf("Complete() unexpected error: %v", err)
+	}
+
+	// Completed responses are replayed for the key TTL, not the lease
+	if stored := repo.records["user:1/key-1"]; !stored.IsCompleted() || stored.IsExpiredAt(time.Now().Add(time.Hour)) {
+		t.Errorf("Complete() stored %+v, want a response kept for the key TTL", stored)
+	}
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// LedgerRepository defines what the ledger service needs from the data layer
+type LedgerRepository interface {
+	OpenUserAccount(ctx context.Context, account *domain.LedgerAccount) error
+	GetUserAccount(ctx context.Context, userID string, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error)
+	GetSystemAccount(ctx context.Context, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error)
+	GetWallet(ctx context.Context, userID string) (*domain.Wallet, error)
+	PostEntry(ctx context.Context, entry *domain.JournalEntry) error
+	GetEntry(ctx context.Context, id string) (*domain.JournalEntry, error)
+	ListTransactions(ctx context.Context, userID string, filter domain.TransactionFilter, afterPostingID int64, limit int) ([]*domain.Transaction, error)
+	ListExpiringLots(ctx context.Context, userID string, before time.Time) ([]*domain.CreditLot, error)
+	ListExpiredLots(ctx context.Context, before time.Time, limit int) ([]*domain.CreditLot, error)
+	ExpireLot(ctx context.Context, lot *domain.CreditLot, entry *domain.JournalEntry) error
+}
+
+// expiredLotBatchSize is the number of expired credit lots processed per batch
+const expiredLotBatchSize = 100
+
+// LedgerConfig holds ledger settings
+type LedgerConfig struct {
+	PointTypes domain.PointTypePolicies // how long issued points of each type stay usable
+}
+
+// LedgerService records every credit movement as an immutable, balanced journal entry.
+// Its posting methods are building blocks for other services, which authorize the caller themselves.
+type LedgerService struct {
+	ledgerRepo LedgerRepository
+	config     LedgerConfig
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(ledgerRepo LedgerRepository, config LedgerConfig) *LedgerService {
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &LedgerService{
+		ledgerRepo: ledgerRepo,
+		config:     config,
+	}
+}
+
+// OpenUserAccount returns the user's available account for a point type, creating it if needed
+func (s *LedgerService) OpenUserAccount(ctx context.Context, userID string, pointType domain.PointType) (*domain.LedgerAccount, error) {
+	account, err := domain.NewUserAccount(userID, pointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger account: %w", err)
+	}
+
+	if err := s.ledgerRepo.OpenUserAccount(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to save ledger account: %w", err)
+	}
+
+	return account, nil
+}
+
+// Credit issues new points to a user's available balance; they expire after their point type's lifetime
+func (s *LedgerService) Credit(ctx context.Context, userID string, pointType domain.PointType, amount int64, kind domain.EntryKind, reference, description string) (*domain.JournalEntry, error) {
+	policy, err := s.config.PointTypes.Policy(pointType)
+	if err != nil {
+		return nil, err
+	}
+
+	issuance, err := s.ledgerRepo.GetSystemAccount(ctx, pointType, domain.AccountIssuance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuance account: %w", err)
+	}
+
+	account, err := s.OpenUserAccount(ctx, userID, pointType)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.post(ctx, kind, pointType, userID, issuance.ID, account.ID, amount, reference, description, policy.Lifetime)
+}
+
+// Debit removes points from a user's available balance; it fails if the user cannot cover the amount
+func (s *LedgerService) Debit(ctx context.Context, userID string, pointType domain.PointType, amount int64, kind domain.EntryKind, reference, description string) (*domain.JournalEntry, error) {
+	if !pointType.IsValid() {
+		return nil, domain.ErrInvalidPointType
+	}
+
+	redemption, err := s.ledgerRepo.GetSystemAccount(ctx, pointType, domain.AccountRedemption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption account: %w", err)
+	}
+
+	account, err := s.ledgerRepo.GetUserAccount(ctx, userID, pointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	return s.post(ctx, kind, pointType, userID, account.ID, redemption.ID, amount, reference, description, 0)
+}
+
+// GetWallet retrieves a user's available, pending (held by open redemptions) and lifetime-earned credits
+// and their balance of each point type
+func (s *LedgerService) GetWallet(ctx context.Context, userID string) (*domain.Wallet, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeCreditsRead); err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.ledgerRepo.GetWallet(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// GetExpiringCredits retrieves the user's unspent credits that expire within the given window
+func (s *LedgerService) GetExpiringCredits(ctx context.Context, userID string, within time.Duration) (*domain.ExpiringCredits, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeCreditsRead); err != nil {
+		return nil, err
+	}
+
+	if within <= 0 {
+		within = 30 * 24 * time.Hour // Default window
+	}
+	if within > 365*24*time.Hour {
+		within = 365 * 24 * time.Hour // Maximum window
+	}
+
+	before := time.Now().Add(within)
+	lots, err := s.ledgerRepo.ListExpiringLots(ctx, userID, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring credits: %w", err)
+	}
+
+	expiring := &domain.ExpiringCredits{
+		UserID: userID,
+		Before: before,
+		Lots:   lots,
+	}
+	for _, lot := range lots {
+		expiring.Total += lot.Remaining
+	}
+
+	return expiring, nil
+}
+
+// ListTransactions retrieves a page of a user's statement, newest first, with their available balance after
+// each transaction. Each page continues from the previous page's cursor, so pages stay stable while new
+// transactions are posted.
+func (s *LedgerService) ListTransactions(ctx context.Context, userID string, filter domain.TransactionFilter, cursor string, limit int) (*domain.TransactionPage, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeCreditsRead); err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	afterPostingID, err := domain.DecodeTransactionCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+
+	// Fetch one more than asked to learn whether another page follows
+	transactions, err := s.ledgerRepo.ListTransactions(ctx, userID, filter, afterPostingID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	page := &domain.TransactionPage{Transactions: transactions}
+	if len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		page.NextCursor = domain.EncodeTransactionCursor(page.Transactions[limit-1])
+	}
+
+	return page, nil
+}
+
+// ExpireCredits moves the unspent credits of every expired lot out of their owners' available balances
+// and returns how many lots were expired. Credits of a lot that are held by an open redemption are
+// expired once the hold is released, or consumed if it is confirmed.
+func (s *LedgerService) ExpireCredits(ctx context.Context) (int, error) {
+	expirationAccounts := make(map[domain.PointType]string)
+	expired := 0
+	for {
+		lots, err := s.ledgerRepo.ListExpiredLots(ctx, time.Now(), expiredLotBatchSize)
+		if err != nil {
+			return expired, fmt.Errorf("failed to list expired credit lots: %w", err)
+		}
+
+		progressed := false
+		for _, lot := range lots {
+			expirationAccountID, exists := expirationAccounts[lot.PointType]
+			if !exists {
+				expiration, err := s.ledgerRepo.GetSystemAccount(ctx, lot.PointType, domain.AccountExpiration)
+				if err != nil {
+					return expired, fmt.Errorf("failed to get expiration account: %w", err)
+				}
+				expirationAccountID = expiration.ID
+				expirationAccounts[lot.PointType] = expirationAccountID
+			}
+
+			ok, err := s.expireLot(ctx, lot, expirationAccountID)
+			if err != nil {
+				return expired, err
+			}
+			if ok {
+				expired++
+				progressed = true
+			}
+		}
+
+		// Stop once a batch only holds lots that have to wait for their holds to settle
+		if len(lots) < expiredLotBatchSize || !progressed {
+			return expired, nil
+		}
+	}
+}
+
+// GetEntry retrieves a journal entry with its postings
+func (s *LedgerService) GetEntry(ctx context.Context, id string) (*domain.JournalEntry, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.ledgerRepo.GetEntry(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// expireLot posts the expiry of a lot's credits that are still available, reporting whether any were expired
+func (s *LedgerService) expireLot(ctx context.Context, lot *domain.CreditLot, expirationAccountID string) (bool, error) {
+	account, err := s.ledgerRepo.GetUserAccount(ctx, lot.UserID, lot.PointType, domain.AccountUserAvailable)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	amount := min(lot.Remaining, account.Balance)
+	if amount <= 0 {
+		return false, nil
+	}
+
+	reference := fmt.Sprintf("credit_lot:%s:%d", lot.ID, lot.Remaining)
+	entry, err := domain.NewTransferEntry(domain.EntryKindExpire, lot.PointType, lot.UserID, account.ID, expirationAccountID, amount, reference, "Credits expired")
+	if err != nil {
+		return false, fmt.Errorf("failed to build expiry entry: %w", err)
+	}
+
+	err = s.ledgerRepo.ExpireLot(ctx, lot, entry)
+	// Spent, expired or moved concurrently; the next run picks up whatever remains
+	if errors.Is(err, domain.ErrCreditLotChanged) || errors.Is(err, domain.ErrDuplicateEntry) || errors.Is(err, domain.ErrInsufficientCredits) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to expire credit lot: %w", err)
+	}
+
+	return true, nil
+}
+
+// post builds a transfer entry between two accounts and records it; points it adds to a user's wallet expire after lifetime
+func (s *LedgerService) post(ctx context.Context, kind domain.EntryKind, pointType domain.PointType, userID, fromAccountID, toAccountID string, amount int64, reference, description string, lifetime time.Duration) (*domain.JournalEntry, error) {
+	entry, err := domain.NewTransferEntry(kind, pointType, userID, fromAccountID, toAccountID, amount, reference, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build journal entry: %w", err)
+	}
+	entry.ExpireCreditsAfter(lifetime)
+
+	if err := s.ledgerRepo.PostEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to post journal entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// openWalletAccount returns a user's account of a point type. Credit accounts are opened together
+// with the user; accounts of other point types are opened on first use, for users with a wallet.
+func openWalletAccount(ctx context.Context, ledgerRepo LedgerRepository, userID string, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error) {
+	account, err := ledgerRepo.GetUserAccount(ctx, userID, pointType, accountType)
+	if errors.Is(err, domain.ErrLedgerAccountNotFound) && pointType != domain.PointTypeCredits {
+		if _, err := ledgerRepo.GetUserAccount(ctx, userID, domain.PointTypeCredits, domain.AccountUserAvailable); err != nil {
+			return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+		}
+
+		account, err = domain.NewUserAccount(userID, pointType, accountType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ledger account: %w", err)
+		}
+
+		err = ledgerRepo.OpenUserAccount(ctx, account)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockLedgerRepository implements LedgerRepository for testing
+type MockLedgerRepository struct {
+	accounts map[string]*domain.LedgerAccount
+	entries  map[string]*domain.JournalEntry
+	lots     []*domain.CreditLot
+	nextID   int
+}
+
+func NewMockLedgerRepository() *MockLedgerRepository {
+	m := &MockLedgerRepository{
+		accounts: make(map[string]*domain.LedgerAccount),
+		entries:  make(map[string]*domain.JournalEntry),
+	}
+	m.accounts["issuance"] = &domain.LedgerAccount{ID: "issuance", Type: domain.AccountIssuance, PointType: domain.PointTypeCredits, AllowNegative: true}
+	m.accounts["redemption"] = &domain.LedgerAccount{ID: "redemption", Type: domain.AccountRedemption, PointType: domain.PointTypeCredits}
+	m.accounts["expiration"] = &domain.LedgerAccount{ID: "expiration", Type: domain.AccountExpiration, PointType: domain.PointTypeCredits}
+	m.accounts["tier-issuance"] = &domain.LedgerAccount{ID: "tier-issuance", Type: domain.AccountIssuance, PointType: domain.PointTypeTierPoints, AllowNegative: true}
+	m.accounts["tier-redemption"] = &domain.LedgerAccount{ID: "tier-redemption", Type: domain.AccountRedemption, PointType: domain.PointTypeTierPoints}
+	m.accounts["tier-expiration"] = &domain.LedgerAccount{ID: "tier-expiration", Type: domain.AccountExpiration, PointType: domain.PointTypeTierPoints}
+	return m
+}
+
+func (m *MockLedgerRepository) OpenUserAccount(ctx context.Context, account *domain.LedgerAccount) error {
+	if existing, err := m.GetUserAccount(ctx, *account.UserID, account.PointType, account.Type); err == nil {
+		*account = *existing
+		return nil
+	}
+
+	m.nextID++
+	account.ID = fmt.Sprintf("account-%d", m.nextID)
+	stored := *account
+	m.accounts[account.ID] = &stored
+	return nil
+}
+
+func (m *MockLedgerRepository) GetUserAccount(ctx context.Context, userID string, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error) {
+	for _, account := range m.accounts {
+		if account.UserID != nil && *account.UserID == userID && account.PointType == pointType && account.Type == accountType {
+			return account, nil
+		}
+	}
+	return nil, domain.ErrLedgerAccountNotFound
+}
+
+func (m *MockLedgerRepository) GetSystemAccount(ctx context.Context, pointType domain.PointType, accountType domain.AccountType) (*domain.LedgerAccount, error) {
+	for _, account := range m.accounts {
+		if account.UserID == nil && account.PointType == pointType && account.Type == accountType {
+			return account, nil
+		}
+	}
+	return nil, domain.ErrLedgerAccountNotFound
+}
+
+func (m *MockLedgerRepository) GetWallet(ctx context.Context, userID string) (*domain.Wallet, error) {
+	wallet := &domain.Wallet{UserID: userID, Balances: make(map[domain.PointType]int64)}
+	found := false
+	for _, account := range m.accounts {
+		if account.UserID == nil || *account.UserID != userID {
+			continue
+		}
+		found = true
+		if account.Type == domain.AccountUserAvailable {
+			wallet.Balances[account.PointType] = account.Balance
+		}
+		if account.PointType != domain.PointTypeCredits {
+			continue
+		}
+		switch account.Type {
+		case domain.AccountUserAvailable:
+			wallet.Available = account.Balance
+		case domain.AccountUserHeld:
+			wallet.Held = account.Balance
+		}
+	}
+	if !found {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	for _, entry := range m.entries {
+		if entry.UserID != userID || entry.Kind != domain.EntryKindEarn || entry.PointType != domain.PointTypeCredits {
+			continue
+		}
+		for _, posting := range entry.Postings {
+			if m.accounts[posting.AccountID].Type == domain.AccountIssuance {
+				wallet.LifetimeEarned -= posting.Amount
+			}
+		}
+	}
+	return wallet, nil
+}
+
+func (m *MockLedgerRepository) PostEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	if entry.Reference != nil {
+		for _, existing := range m.entries {
+			if existing.Kind == entry.Kind && existing.Reference != nil && *existing.Reference == *entry.Reference {
+				return domain.ErrDuplicateEntry
+			}
+		}
+	}
+
+	for _, posting := range entry.Postings {
+		account, exists := m.accounts[posting.AccountID]
+		if !exists {
+			return domain.ErrLedgerAccountNotFound
+		}
+		if account.PointType != entry.PointType {
+			return domain.ErrPointTypeMismatch
+		}
+		if !account.AllowNegative && account.Balance+posting.Amount < 0 {
+			return domain.ErrInsufficientCredits
+		}
+	}
+
+	m.nextID++
+	entry.ID = fmt.Sprintf("entry-%d", m.nextID)
+	lotChanges := make(map[string]int64)
+	for i := range entry.Postings {
+		account := m.accounts[entry.Postings[i].AccountID]
+		account.Balance += entry.Postings[i].Amount
+		m.nextID++
+		entry.Postings[i].ID = int64(m.nextID)
+		entry.Postings[i].EntryID = entry.ID
+		entry.Postings[i].BalanceAfter = account.Balance
+		if account.UserID != nil && account.Type.IsLotTracked() {
+			lotChanges[*account.UserID] += entry.Postings[i].Amount
+		}
+	}
+	m.entries[entry.ID] = entry
+
+	var draws []domain.LotDraw
+	for userID, change := range lotChanges {
+		if change < 0 {
+			var userLots []*domain.CreditLot
+			for _, lot := range m.lots {
+				if lot.UserID == userID && lot.PointType == entry.PointType {
+					userLots = append(userLots, lot)
+				}
+			}
+			draws = append(draws, domain.ConsumeLots(userLots, -change)...)
+		}
+	}
+	for userID, change := range lotChanges {
+		if change > 0 {
+			lots, rest, _ := domain.NewCreditLots(userID, entry, change, draws)
+			for _, lot := range lots {
+				m.nextID++
+				lot.ID = fmt.Sprintf("lot-%d", m.nextID)
+				m.lots = append(m.lots, lot)
+			}
+			draws = rest
+		}
+	}
+	return nil
+}
+
+func (m *MockLedgerRepository) GetEntry(ctx context.Context, id string) (*domain.JournalEntry, error) {
+	entry, exists := m.entries[id]
+	if !exists {
+		return nil, domain.ErrJournalEntryNotFound
+	}
+	return entry, nil
+}
+
+func (m *MockLedgerRepository) ListTransactions(ctx context.Context, userID string, filter domain.TransactionFilter, afterPostingID int64, limit int) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	for _, entry := range m.entries {
+		if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, entry.Kind) {
+			continue
+		}
+		if filter.Source != "" && domain.EntrySource(entry.Reference) != filter.Source {
+			continue
+		}
+		if (filter.From != nil && entry.CreatedAt.Before(*filter.From)) || (filter.To != nil && !entry.CreatedAt.Before(*filter.To)) {
+			continue
+		}
+		for _, posting := range entry.Postings {
+			account := m.accounts[posting.AccountID]
+			if account.UserID == nil || *account.UserID != userID || account.PointType != filter.PointType || account.Type != domain.AccountUserAvailable {
+				continue
+			}
+			if afterPostingID > 0 && posting.ID >= afterPostingID {
+				continue
+			}
+			amount := max(posting.Amount, -posting.Amount)
+			if (filter.MinAmount != nil && amount < *filter.MinAmount) || (filter.MaxAmount != nil && amount > *filter.MaxAmount) {
+				continue
+			}
+			transactions = append(transactions, &domain.Transaction{
+				PostingID:    posting.ID,
+				EntryID:      entry.ID,
+				Kind:         entry.Kind,
+				PointType:    entry.PointType,
+				Source:       domain.EntrySource(entry.Reference),
+				Description:  entry.Description,
+				Amount:       posting.Amount,
+				BalanceAfter: posting.BalanceAfter,
+				CreatedAt:    entry.CreatedAt,
+			})
+		}
+	}
+
+	sort.Slice(transactions, func(i, j int) bool { return transactions[i].PostingID > transactions[j].PostingID })
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+	return transactions, nil
+}
+
+func (m *MockLedgerRepository) ListExpiringLots(ctx context.Context, userID string, before time.Time) ([]*domain.CreditLot, error) {
+	var lots []*domain.CreditLot
+	for _, lot := range m.lots {
+		if lot.UserID == userID && lot.Remaining > 0 && lot.IsExpiredAt(before) {
+			lots = append(lots, lot)
+		}
+	}
+	return lots, nil
+}
+
+func (m *MockLedgerRepository) ListExpiredLots(ctx context.Context, before time.Time, limit int) ([]*domain.CreditLot, error) {
+	var lots []*domain.CreditLot
+	for _, lot := range m.lots {
+		if lot.Remaining > 0 && lot.IsExpiredAt(before) && len(lots) < limit {
+			copied := *lot
+			lots = append(lots, &copied)
+		}
+	}
+	return lots, nil
+}
+
+func (m *MockLedgerRepository) ExpireLot(ctx context.Context, lot *domain.CreditLot, entry *domain.JournalEntry) error {
+	for _, stored := range m.lots {
+		if stored.ID == lot.ID && stored.Remaining != lot.Remaining {
+			return domain.ErrCreditLotChanged
+		}
+	}
+	return m.PostEntry(ctx, entry)
+}
+
+func TestLedgerService_CreditAndDebit(t *testing.T) {
+	repo := NewMockLedgerRepository()
+	service := NewLedgerService(repo, LedgerConfig{})
+	ctx := context.Background()
+
+	entry, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "signup:user-1", "Signup bonus")
+	if err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+
+	account, err := repo.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if err != nil {
+		t.Fatalf("GetUserAccount() unexpected error: %v", err)
+	}
+	if account.Balance != 100 {
+		t.Errorf("Credit() balance = %d, want 100", account.Balance)
+	}
+	if issuance := repo.accounts["issuance"]; issuance.Balance != -100 {
+		t.Errorf("Credit() issuance balance = %d, want -100", issuance.Balance)
+	}
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "signup:user-1", "Signup bonus"); !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Errorf("Credit() duplicate reference expected %v, got %v", domain.ErrDuplicateEntry, err)
+	}
+
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeCredits, 150, domain.EntryKindRedeem, "", ""); !errors.Is(err, domain.ErrInsufficientCredits) {
+		t.Errorf("Debit() overdraft expected %v, got %v", domain.ErrInsufficientCredits, err)
+	}
+
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeCredits, 60, domain.EntryKindRedeem, "", ""); err != nil {
+		t.Fatalf("Debit() unexpected error: %v", err)
+	}
+	if account.Balance != 40 {
+		t.Errorf("Debit() balance = %d, want 40", account.Balance)
+	}
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, -5, domain.EntryKindEarn, "", ""); !errors.Is(err, domain.ErrInvalidAmount) {
+		t.Errorf("Credit() negative amount expected %v, got %v", domain.ErrInvalidAmount, err)
+	}
+
+	if _, err := service.Debit(ctx, "user-2", domain.PointTypeCredits, 10, domain.EntryKindRedeem, "", ""); !errors.Is(err, domain.ErrLedgerAccountNotFound) {
+		t.Errorf("Debit() without account expected %v, got %v", domain.ErrLedgerAccountNotFound, err)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	if _, err := service.GetEntry(userCtx, entry.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetEntry() as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+
+	got, err := service.GetEntry(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("GetEntry() unexpected error: %v", err)
+	}
+	if got.UserID != "user-1" || len(got.Postings) != 2 {
+		t.Errorf("GetEntry() = %+v, want entry for user-1 with 2 postings", got)
+	}
+}
+
+func TestLedgerService_GetWallet(t *testing.T) {
+	repo := NewMockLedgerRepository()
+	service := NewLedgerService(repo, LedgerConfig{})
+	ctx := context.Background()
+
+	if _, err := service.GetWallet(ctx, "user-1"); !errors.Is(err, domain.ErrWalletNotFound) {
+		t.Errorf("GetWallet() without accounts expected %v, got %v", domain.ErrWalletNotFound, err)
+	}
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 25, domain.EntryKindAdjustment, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeCredits, 30, domain.EntryKindRedeem, "", ""); err != nil {
+		t.Fatalf("Debit() unexpected error: %v", err)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	wallet, err := service.GetWallet(userCtx, "user-1")
+	if err != nil {
+		t.Fatalf("GetWallet() unexpected error: %v", err)
+	}
+	if wallet.Available != 95 || wallet.Pending() != 0 || wallet.LifetimeEarned != 100 {
+		t.Errorf("GetWallet() = %+v, want available 95, pending 0, lifetime earned 100", wallet)
+	}
+
+	otherCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-2", Role: domain.RoleUser})
+	if _, err := service.GetWallet(otherCtx, "user-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetWallet() for another user expected %v, got %v", domain.ErrForbidden, err)
+	}
+}
+
+func TestLedgerService_PointTypes(t *testing.T) {
+	repo := NewMockLedgerRepository()
+	service := NewLedgerService(repo, LedgerConfig{})
+	ctx := context.Background()
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() credits unexpected error: %v", err)
+	}
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeTierPoints, 40, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() tier points unexpected error: %v", err)
+	}
+
+	// Balances of different point types never cover each other
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeTierPoints, 50, domain.EntryKindAdjustment, "", ""); !errors.Is(err, domain.ErrInsufficientCredits) {
+		t.Errorf("Debit() tier points overdraft expected %v, got %v", domain.ErrInsufficientCredits, err)
+	}
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointType("miles"), 10, domain.EntryKindEarn, "", ""); !errors.Is(err, domain.ErrInvalidPointType) {
+		t.Errorf("Credit() unknown point type expected %v, got %v", domain.ErrInvalidPointType, err)
+	}
+
+	wallet, err := service.GetWallet(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("GetWallet() unexpected error: %v", err)
+	}
+	if wallet.Available != 100 || wallet.LifetimeEarned != 100 || wallet.Balances[domain.PointTypeCredits] != 100 || wallet.Balances[domain.PointTypeTierPoints] != 40 {
+		t.Errorf("GetWallet() = %+v, want 100 credits and 40 tier points", wallet)
+	}
+}
+
+func TestLedgerService_ExpireCredits(t *testing.T) {
+	repo := NewMockLedgerRepository()
+	service := NewLedgerService(repo, LedgerConfig{PointTypes: domain.PointTypePolicies{domain.PointTypeCredits: {Lifetime: time.Hour}}})
+	ctx := context.Background()
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	oldest := repo.lots[0]
+	oldest.ExpiresAt = &past
+
+	if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, 50, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+
+	// Spending draws from the lot that expires first
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeCredits, 30, domain.EntryKindRedeem, "", ""); err != nil {
+		t.Fatalf("Debit() unexpected error: %v", err)
+	}
+	if oldest.Remaining != 70 {
+		t.Errorf("Debit() oldest lot remaining = %d, want 70", oldest.Remaining)
+	}
+
+	expiring, err := service.GetExpiringCredits(ctx, "user-1", 0)
+	if err != nil {
+		t.Fatalf("GetExpiringCredits() unexpected error: %v", err)
+	}
+	if expiring.Total != 120 || len(expiring.Lots) != 2 {
+		t.Errorf("GetExpiringCredits() total = %d over %d lots, want 120 over 2", expiring.Total, len(expiring.Lots))
+	}
+
+	// Credits held by an open redemption wait for the hold to settle
+	available, _ := repo.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	heldAccount, _ := domain.NewUserAccount("user-1", domain.PointTypeCredits, domain.AccountUserHeld)
+	_ = repo.OpenUserAccount(ctx, heldAccount)
+	hold, _ := domain.NewTransferEntry(domain.EntryKindHold, domain.PointTypeCredits, "user-1", available.ID, heldAccount.ID, 100, "", "")
+	if err := repo.PostEntry(ctx, hold); err != nil {
+		t.Fatalf("PostEntry() unexpected error: %v", err)
+	}
+
+	expired, err := service.ExpireCredits(ctx)
+	if err != nil {
+		t.Fatalf("ExpireCredits() unexpected error: %v", err)
+	}
+	if expired != 1 || available.Balance != 0 || oldest.Remaining != 50 {
+		t.Errorf("ExpireCredits() expired %d, available %d, lot remaining %d; want 1, 0, 50", expired, available.Balance, oldest.Remaining)
+	}
+
+	release, _ := domain.NewTransferEntry(domain.EntryKindRelease, domain.PointTypeCredits, "user-1", heldAccount.ID, available.ID, 100, "", "")
+	if err := repo.PostEntry(ctx, release); err != nil {
+		t.Fatalf("PostEntry() unexpected error: %v", err)
+	}
+
+	if _, err := service.ExpireCredits(ctx); err != nil {
+		t.Fatalf("ExpireCredits() unexpected error: %v", err)
+	}
+	if available.Balance != 50 || oldest.Remaining != 0 || repo.accounts["expiration"].Balance != 70 {
+		t.Errorf("ExpireCredits() available %d, lot remaining %d, expired total %d; want 50, 0, 70",
+			available.Balance, oldest.Remaining, repo.accounts["expiration"].Balance)
+	}
+
+	otherCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-2", Role: domain.RoleUser})
+	if _, err := service.GetExpiringCredits(otherCtx, "user-1", 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetExpiringCredits() for another user expected %v, got %v", domain.ErrForbidden, err)
+	}
+}
+
+func TestLedgerService_ListTransactions(t *testing.T) {
+	repo := NewMockLedgerRepository()
+	service := NewLedgerService(repo, LedgerConfig{})
+	ctx := context.Background()
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	for i, amount := range []int64{100, 20, 50} {
+		if _, err := service.Credit(ctx, "user-1", domain.PointTypeCredits, amount, domain.EntryKindEarn, fmt.Sprintf("earn_rule:rule-1:order-%d", i), "Cashback"); err != nil {
+			t.Fatalf("Credit() unexpected error: %v", err)
+		}
+	}
+	if _, err := service.Debit(ctx, "user-1", domain.PointTypeCredits, 30, domain.EntryKindRedeem, "redemption:1", "Gift card"); err != nil {
+		t.Fatalf("Debit() unexpected error: %v", err)
+	}
+
+	// Paging newest first through the whole statement shows the running balance after each transaction
+	var amounts, balances []int64
+	cursor := ""
+	for pages := 0; ; pages++ {
+		page, err := service.ListTransactions(userCtx, "user-1", domain.TransactionFilter{}, cursor, 3)
+		if err != nil {
+			t.Fatalf("ListTransactions() unexpected error: %v", err)
+		}
+		for _, transaction := range page.Transactions {
+			amounts = append(amounts, transaction.Amount)
+			balances = append(balances, transaction.BalanceAfter)
+		}
+		if page.NextCursor == "" {
+			if pages != 1 {
+				t.Errorf("ListTransactions() returned %d pages, want 2", pages+1)
+			}
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if !slices.Equal(amounts, []int64{-30, 50, 20, 100}) || !slices.Equal(balances, []int64{140, 170, 120, 100}) {
+		t.Errorf("ListTransactions() amounts %v with balances %v, want [-30 50 20 100] with [140 170 120 100]", amounts, balances)
+	}
+
+	minAmount := int64(30)
+	page, err := service.ListTransactions(userCtx, "user-1", domain.TransactionFilter{Kinds: []domain.EntryKind{domain.EntryKindEarn}, Source: "earn_rule", MinAmount: &minAmount}, "", 10)
+	if err != nil {
+		t.Fatalf("ListTransactions() filtered unexpected error: %v", err)
+	}
+	if len(page.Transactions) != 2 || page.Transactions[0].Amount != 50 || page.Transactions[1].Amount != 100 {
+		t.Errorf("ListTransactions() filtered = %+v, want the earns of 50 and 100", page.Transactions)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		filter  domain.TransactionFilter
+		cursor  string
+		wantErr error
+	}{
+		{"another user's statement", domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-2", Role: domain.RoleUser}), domain.TransactionFilter{}, "", domain.ErrForbidden},
+		{"unknown type", userCtx, domain.TransactionFilter{Kinds: []domain.EntryKind{"refund"}}, "", domain.ErrInvalidEntryKind},
+		{"empty amount range", userCtx, domain.TransactionFilter{MinAmount: &minAmount, MaxAmount: new(int64)}, "", domain.ErrInvalidTransactionFilter},
+		{"tampered cursor", userCtx, domain.TransactionFilter{}, "not a cursor", domain.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ListTransactions(tt.ctx, "user-1", tt.filter, tt.cursor, 10); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListTransactions() expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// expiredHoldBatchSize is the number of timed-out holds released per batch
+const expiredHoldBatchSize = 100
+
+// RedemptionRepository defines what the redemption service needs from the data layer
+type RedemptionRepository interface {
+	Hold(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error
+	Settle(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error
+	GetByID(ctx context.Context, id string) (*domain.Redemption, error)
+	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Redemption, error)
+	ListExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*domain.Redemption, error)
+}
+
+// RedemptionConfig holds redemption settings
+type RedemptionConfig struct {
+	HoldTTL    time.Duration
+	PointTypes domain.PointTypePolicies // which point types rewards can be redeemed with
+}
+
+// RedemptionService lets users spend credits on rewards through a hold that is later confirmed or released
+type RedemptionService struct {
+	redemptionRepo RedemptionRepository
+	rewardRepo     RewardRepository
+	ledgerRepo     LedgerRepository
+	config         RedemptionConfig
+}
+
+// NewRedemptionService creates a new redemption service
+func NewRedemptionService(redemptionRepo RedemptionRepository, rewardRepo RewardRepository, ledgerRepo LedgerRepository, config RedemptionConfig) *RedemptionService {
+	if config.HoldTTL <= 0 {
+		config.HoldTTL = 15 * time.Minute // Default hold lifetime
+	}
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &RedemptionService{
+		redemptionRepo: redemptionRepo,
+		rewardRepo:     rewardRepo,
+		ledgerRepo:     ledgerRepo,
+		config:         config,
+	}
+}
+
+// CreateRedemption holds the reward's cost from the user's available points and reserves one unit of stock
+func (s *RedemptionService) CreateRedemption(ctx context.Context, userID, rewardID string) (*domain.Redemption, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if rewardID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeCreditsWrite); err != nil {
+		return nil, err
+	}
+
+	reward, err := s.rewardRepo.GetByID(ctx, rewardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward: %w", err)
+	}
+
+	policy, err := s.config.PointTypes.Policy(reward.PointType)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Redeemable {
+		return nil, domain.ErrPointTypeNotRedeemable
+	}
+
+	redemption, err := domain.NewRedemption(userID, reward, s.config.HoldTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redemption: %w", err)
+	}
+
+	available, held, err := s.userAccounts(ctx, userID, redemption.PointType)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewTransferEntry(domain.EntryKindHold, redemption.PointType, userID, available.ID, held.ID, redemption.Cost, "", "Hold for "+reward.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build hold entry: %w", err)
+	}
+
+	if err := s.redemptionRepo.Hold(ctx, redemption, entry); err != nil {
+		return nil, fmt.Errorf("failed to hold credits: %w", err)
+	}
+
+	return redemption, nil
+}
+
+// GetRedemption retrieves a redemption by ID
+func (s *RedemptionService) GetRedemption(ctx context.Context, id string) (*domain.Redemption, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	redemption, err := s.redemptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption: %w", err)
+	}
+
+	if err := authorizeUserAccess(ctx, redemption.UserID, domain.ScopeCreditsRead); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// ListUserRedemptions retrieves a paginated list of a user's redemptions
+func (s *RedemptionService) ListUserRedemptions(ctx context.Context, userID string, limit, offset int) ([]*domain.Redemption, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeCreditsRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	redemptions, err := s.redemptionRepo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+// ConfirmRedemption spends the held credits on the reward
+func (s *RedemptionService) ConfirmRedemption(ctx context.Context, id string) (*domain.Redemption, error) {
+	redemption, err := s.getForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := redemption.Confirm(time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to confirm redemption: %w", err)
+	}
+
+	redemptionAccount, err := s.ledgerRepo.GetSystemAccount(ctx, redemption.PointType, domain.AccountRedemption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption account: %w", err)
+	}
+
+	_, held, err := s.userAccounts(ctx, redemption.UserID, redemption.PointType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.settle(ctx, redemption, domain.EntryKindRedeem, held.ID, redemptionAccount.ID, "Redemption confirmed"); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// CancelRedemption releases the held credits back to the user and returns the reserved stock
+func (s *RedemptionService) CancelRedemption(ctx context.Context, id string) (*domain.Redemption, error) {
+	redemption, err := s.getForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.release(ctx, redemption, false); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// ReleaseExpiredHolds releases every hold that timed out and returns how many were released
+func (s *RedemptionService) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		redemptions, err := s.redemptionRepo.ListExpiredHolds(ctx, time.Now(), expiredHoldBatchSize)
+		if err != nil {
+			return released, fmt.Errorf("failed to list expired holds: %w", err)
+		}
+
+		for _, redemption := range redemptions {
+			err := s.release(ctx, redemption, true)
+			// Confirmed or cancelled while the batch was being processed
+			if errors.Is(err, domain.ErrRedemptionNotHeld) {
+				continue
+			}
+			if err != nil {
+				return released, err
+			}
+			released++
+		}
+
+		if len(redemptions) < expiredHoldBatchSize {
+			return released, nil
+		}
+	}
+}
+
+// getForUpdate loads a redemption the caller is allowed to settle
+func (s *RedemptionService) getForUpdate(ctx context.Context, id string) (*domain.Redemption, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	redemption, err := s.redemptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption: %w", err)
+	}
+
+	if err := authorizeUserAccess(ctx, redemption.UserID, domain.ScopeCreditsWrite); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// release moves the held credits back to the user's available balance
+func (s *RedemptionService) release(ctx context.Context, redemption *domain.Redemption, expired bool) error {
+	if err := redemption.Cancel(time.Now(), expired); err != nil {
+		return fmt.Errorf("failed to cancel redemption: %w", err)
+	}
+
+	available, held, err := s.userAccounts(ctx, redemption.UserID, redemption.PointType)
+	if err != nil {
+		return err
+	}
+
+	description := "Redemption cancelled"
+	if expired {
+		description = "Redemption hold expired"
+	}
+
+	return s.settle(ctx, redemption, domain.EntryKindRelease, held.ID, available.ID, description)
+}
+
+// settle records the redemption's new status with the entry moving its held credits
+func (s *RedemptionService) settle(ctx context.Context, redemption *domain.Redemption, kind domain.EntryKind, fromAccountID, toAccountID, description string) error {
+	entry, err := domain.NewTransferEntry(kind, redemption.PointType, redemption.UserID, fromAccountID, toAccountID, redemption.Cost, "redemption:"+redemption.ID, description)
+	if err != nil {
+		return fmt.Errorf("failed to build settlement entry: %w", err)
+	}
+
+	if err := s.redemptionRepo.Settle(ctx, redemption, entry); err != nil {
+		return fmt.Errorf("failed to settle redemption: %w", err)
+	}
+
+	return nil
+}
+
+// userAccounts retrieves the user's available and held accounts of a point type
+func (s *RedemptionService) userAccounts(ctx context.Context, userID string, pointType domain.PointType) (*domain.LedgerAccount, *domain.LedgerAccount, error) {
+	available, err := s.ledgerRepo.GetUserAccount(ctx, userID, pointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	held, err := openWalletAccount(ctx, s.ledgerRepo, userID, pointType, domain.AccountUserHeld)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return available, held, nil
+}
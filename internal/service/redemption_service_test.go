@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockRedemptionRepository implements RedemptionRepository for testing
+type MockRedemptionRepository struct {
+	redemptions map[string]*domain.Redemption
+	rewards     *MockRewardRepository
+	ledger      *MockLedgerRepository
+	nextID      int
+}
+
+func NewMockRedemptionRepository(rewards *MockRewardRepository, ledger *MockLedgerRepository) *MockRedemptionRepository {
+	return &MockRedemptionRepository{
+		redemptions: make(map[string]*domain.Redemption),
+		rewards:     rewards,
+		ledger:      ledger,
+	}
+}
+
+func (m *MockRedemptionRepository) Hold(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error {
+	reward, exists := m.rewards.rewards[redemption.RewardID]
+	if !exists || reward.Cost != redemption.Cost || !reward.IsAvailableAt(redemption.CreatedAt) {
+		return domain.ErrRewardUnavailable
+	}
+
+	if err := m.ledger.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	reward.Stock--
+	m.nextID++
+	redemption.ID = fmt.Sprintf("redemption-%d", m.nextID)
+	redemption.HoldEntryID = entry.ID
+	stored := *redemption
+	m.redemptions[redemption.ID] = &stored
+	return nil
+}
+
+func (m *MockRedemptionRepository) Settle(ctx context.Context, redemption *domain.Redemption, entry *domain.JournalEntry) error {
+	stored, exists := m.redemptions[redemption.ID]
+	if !exists || !stored.IsHeld() {
+		return domain.ErrRedemptionNotHeld
+	}
+
+	if err := m.ledger.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	if redemption.Status != domain.RedemptionConfirmed {
+		m.rewards.rewards[redemption.RewardID].Stock++
+	}
+	redemption.SettleEntryID = &entry.ID
+	*stored = *redemption
+	return nil
+}
+
+func (m *MockRedemptionRepository) GetByID(ctx context.Context, id string) (*domain.Redemption, error) {
+	redemption, exists := m.redemptions[id]
+	if !exists {
+		return nil, domain.ErrRedemptionNotFound
+	}
+	copied := *redemption
+	return &copied, nil
+}
+
+func (m *MockRedemptionRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Redemption, error) {
+	var redemptions []*domain.Redemption
+	for _, redemption := range m.redemptions {
+		if redemption.UserID == userID {
+			redemptions = append(redemptions, redemption)
+		}
+	}
+	return redemptions, nil
+}
+
+func (m *MockRedemptionRepository) ListExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*domain.Redemption, error) {
+	var redemptions []*domain.Redemption
+	for _, redemption := range m.redemptions {
+		if redemption.IsExpiredAt(before) {
+			copied := *redemption
+			redemptions = append(redemptions, &copied)
+		}
+	}
+	return redemptions, nil
+}
+
+// newRedemptionTestService creates a redemption service over a user wallet holding balance credits
+func newRedemptionTestService(t *testing.T, balance int64) (*RedemptionService, *MockRedemptionRepository, *MockLedgerRepository) {
+	t.Helper()
+
+	ledger := NewMockLedgerRepository()
+	for _, accountType := range domain.WalletAccountTypes {
+		account, _ := domain.NewUserAccount("user-1", domain.PointTypeCredits, accountType)
+		_ = ledger.OpenUserAccount(context.Background(), account)
+	}
+	if _, err := NewLedgerService(ledger, LedgerConfig{}).Credit(context.Background(), "user-1", domain.PointTypeCredits, balance, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+
+	rewards := NewMockRewardRepository()
+	repo := NewMockRedemptionRepository(rewards, ledger)
+	return NewRedemptionService(repo, rewards, ledger, RedemptionConfig{}), repo, ledger
+}
+
+func TestRedemptionService_HoldConfirmCancel(t *testing.T) {
+	service, repo, ledger := newRedemptionTestService(t, 500)
+	ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	reward := &domain.Reward{RewardSpec: domain.RewardSpec{Name: "Mug", Cost: 200, PointType: domain.PointTypeCredits, Stock: 2, Status: domain.RewardActive}}
+	_ = repo.rewards.Create(ctx, reward)
+
+	balance := func(accountType domain.AccountType) int64 {
+		account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, accountType)
+		return account.Balance
+	}
+
+	first, err := service.CreateRedemption(ctx, "user-1", reward.ID)
+	if err != nil {
+		t.Fatalf("CreateRedemption() unexpected error: %v", err)
+	}
+	if balance(domain.AccountUserAvailable) != 300 || balance(domain.AccountUserHeld) != 200 || reward.Stock != 1 {
+		t.Errorf("CreateRedemption() available/held/stock = %d/%d/%d, want 300/200/1",
+			balance(domain.AccountUserAvailable), balance(domain.AccountUserHeld), reward.Stock)
+	}
+
+	// Held credits show as pending in the wallet until the redemption settles
+	if wallet, err := ledger.GetWallet(ctx, "user-1"); err != nil || wallet.Pending() != 200 {
+		t.Errorf("GetWallet() after CreateRedemption() = %+v, %v, want 200 pending", wallet, err)
+	}
+
+	second, err := service.CreateRedemption(ctx, "user-1", reward.ID)
+	if err != nil {
+		t.Fatalf("CreateRedemption() unexpected error: %v", err)
+	}
+
+	if _, err := service.CreateRedemption(ctx, "user-1", reward.ID); !errors.Is(err, domain.ErrRewardUnavailable) {
+		t.Errorf("CreateRedemption() sold out expected %v, got %v", domain.ErrRewardUnavailable, err)
+	}
+
+	if _, err := service.ConfirmRedemption(ctx, first.ID); err != nil {
+		t.Fatalf("ConfirmRedemption() unexpected error: %v", err)
+	}
+	if _, err := service.CancelRedemption(ctx, first.ID); !errors.Is(err, domain.ErrRedemptionNotHeld) {
+		t.Errorf("CancelRedemption() after confirm expected %v, got %v", domain.ErrRedemptionNotHeld, err)
+	}
+
+	cancelled, err := service.CancelRedemption(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("CancelRedemption() unexpected error: %v", err)
+	}
+	if cancelled.Status != domain.RedemptionCancelled {
+		t.Errorf("CancelRedemption() Status = %v, want %v", cancelled.Status, domain.RedemptionCancelled)
+	}
+
+	if balance(domain.AccountUserAvailable) != 300 || balance(domain.AccountUserHeld) != 0 || reward.Stock != 1 {
+		t.Errorf("after settlement available/held/stock = %d/%d/%d, want 300/0/1",
+			balance(domain.AccountUserAvailable), balance(domain.AccountUserHeld), reward.Stock)
+	}
+
+	otherCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-2", Role: domain.RoleUser})
+	if _, err := service.CreateRedemption(otherCtx, "user-1", reward.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateRedemption() for another user expected %v, got %v", domain.ErrForbidden, err)
+	}
+	if _, err := service.GetRedemption(otherCtx, first.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetRedemption() for another user expected %v, got %v", domain.ErrForbidden, err)
+	}
+}
+
+func TestRedemptionService_InsufficientCreditsAndExpiry(t *testing.T) {
+	service, repo, ledger := newRedemptionTestService(t, 100)
+	ctx := context.Background()
+
+	expensive := &domain.Reward{RewardSpec: domain.RewardSpec{Name: "Headphones", Cost: 1000, PointType: domain.PointTypeCredits, Stock: 1, Status: domain.RewardActive}}
+	cheap := &domain.Reward{RewardSpec: domain.RewardSpec{Name: "Sticker", Cost: 50, PointType: domain.PointTypeCredits, Stock: 1, Status: domain.RewardActive}}
+	status := &domain.Reward{RewardSpec: domain.RewardSpec{Name: "Lounge pass", Cost: 10, PointType: domain.PointTypeTierPoints, Stock: 1, Status: domain.RewardActive}}
+	_ = repo.rewards.Create(ctx, expensive)
+	_ = repo.rewards.Create(ctx, cheap)
+	_ = repo.rewards.Create(ctx, status)
+
+	if _, err := service.CreateRedemption(ctx, "user-1", status.ID); !errors.Is(err, domain.ErrPointTypeNotRedeemable) {
+		t.Errorf("CreateRedemption() priced in tier points expected %v, got %v", domain.ErrPointTypeNotRedeemable, err)
+	}
+
+	if _, err := service.CreateRedemption(ctx, "user-1", expensive.ID); !errors.Is(err, domain.ErrInsufficientCredits) {
+		t.Errorf("CreateRedemption() overdraft expected %v, got %v", domain.ErrInsufficientCredits, err)
+	}
+	if expensive.Stock != 1 {
+		t.Errorf("CreateRedemption() overdraft took stock, stock = %d", expensive.Stock)
+	}
+
+	redemption, err := service.CreateRedemption(ctx, "user-1", cheap.ID)
+	if err != nil {
+		t.Fatalf("CreateRedemption() unexpected error: %v", err)
+	}
+	repo.redemptions[redemption.ID].ExpiresAt = time.Now().Add(-time.Second)
+
+	if _, err := service.ConfirmRedemption(ctx, redemption.ID); !errors.Is(err, domain.ErrRedemptionExpired) {
+		t.Errorf("ConfirmRedemption() expired expected %v, got %v", domain.ErrRedemptionExpired, err)
+	}
+
+	released, err := service.ReleaseExpiredHolds(ctx)
+	if err != nil {
+		t.Fatalf("ReleaseExpiredHolds() unexpected error: %v", err)
+	}
+	if released != 1 || repo.redemptions[redemption.ID].Status != domain.RedemptionExpired || cheap.Stock != 1 {
+		t.Errorf("ReleaseExpiredHolds() released %d, status %v, stock %d; want 1, expired, 1",
+			released, repo.redemptions[redemption.ID].Status, cheap.Stock)
+	}
+
+	account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if account.Balance != 100 {
+		t.Errorf("ReleaseExpiredHolds() available = %d, want 100", account.Balance)
+	}
+}
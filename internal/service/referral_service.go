@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReferralRepository defines what the referral service needs from the data layer
+type ReferralRepository interface {
+	GetByReferee(ctx context.Context, refereeID string) (*domain.Referral, error)
+	CountByReferrerAndDomain(ctx context.Context, referrerID, emailDomain string) (int, error)
+	Reward(ctx context.Context, referral *domain.Referral, referrerEntry, refereeEntry *domain.JournalEntry) error
+	ListByReferrer(ctx context.Context, referrerID string, limit, offset int) ([]*domain.Referral, error)
+	ListRewardable(ctx context.Context, limit int) ([]*domain.Referral, error)
+}
+
+// referralRewardBatchSize is how many unpaid referrals are loaded at a time when retrying payouts
+const referralRewardBatchSize = 100
+
+// ReferralConfig holds referral program settings
+type ReferralConfig struct {
+	ReferrerReward    int64                    // credits paid to the user who shared the code
+	RefereeReward     int64                    // credits paid to the user who signed up with it
+	MaxPerEmailDomain int                      // most referees one referrer may bring from a single email domain
+	PointTypes        domain.PointTypePolicies // how long the rewarded credits stay usable
+}
+
+// ReferralService vets referral codes at signup and rewards both users once the referee verified their email
+type ReferralService struct {
+	referralRepo ReferralRepository
+	userRepo     UserRepository
+	ledgerRepo   LedgerRepository
+	config       ReferralConfig
+}
+
+// NewReferralService creates a new referral service
+func NewReferralService(referralRepo ReferralRepository, userRepo UserRepository, ledgerRepo LedgerRepository, config ReferralConfig) *ReferralService {
+	if config.ReferrerReward <= 0 {
+		config.ReferrerReward = 500 // Default referrer reward
+	}
+	if config.RefereeReward <= 0 {
+		config.RefereeReward = 250 // Default referee reward
+	}
+	if config.MaxPerEmailDomain <= 0 {
+		config.MaxPerEmailDomain = 5 // Default referees per email domain
+	}
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &ReferralService{
+		referralRepo: referralRepo,
+		userRepo:     userRepo,
+		ledgerRepo:   ledgerRepo,
+		config:       config,
+	}
+}
+
+// ReferUser links a user who is signing up to the owner of a referral code, rejecting unknown codes,
+// self-referrals and referrers who already brought too many users from the new user's email domain.
+// The limit is checked again when the referred user is saved, where concurrent signups are serialized.
+func (s *ReferralService) ReferUser(ctx context.Context, referee *domain.User, code string) error {
+	code = domain.NormalizeReferralCode(code)
+	if code == "" {
+		return domain.ErrInvalidReferralCode
+	}
+
+	referrer, err := s.userRepo.GetByReferralCode(ctx, code)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrInvalidReferralCode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get referrer: %w", err)
+	}
+
+	if err := referee.ReferBy(referrer); err != nil {
+		return err
+	}
+
+	count, err := s.referralRepo.CountByReferrerAndDomain(ctx, referrer.ID, domain.EmailDomain(referee.Email))
+	if err != nil {
+		return fmt.Errorf("failed to count referrals: %w", err)
+	}
+
+	if count >= s.config.MaxPerEmailDomain {
+		return domain.ErrReferralLimitReached
+	}
+
+	return nil
+}
+
+// MaxPerEmailDomain returns the most referees one referrer may bring from a single email domain
+func (s *ReferralService) MaxPerEmailDomain() int {
+	return s.config.MaxPerEmailDomain
+}
+
+// RewardReferral pays both users of the referral a verified user signed up with, returning nil
+// if the user was not referred or the referral was already rewarded
+func (s *ReferralService) RewardReferral(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	referral, err := s.referralRepo.GetByReferee(ctx, refereeID)
+	if errors.Is(err, domain.ErrReferralNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referral: %w", err)
+	}
+
+	if referral.Status != domain.ReferralPending {
+		return nil, nil
+	}
+
+	referrerEntry, err := s.rewardEntry(ctx, referral, referral.ReferrerID, s.config.ReferrerReward, "referrer")
+	if err != nil {
+		return nil, err
+	}
+
+	refereeEntry, err := s.rewardEntry(ctx, referral, referral.RefereeID, s.config.RefereeReward, "referee")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := referral.MarkRewarded(time.Now()); err != nil {
+		return nil, err
+	}
+
+	err = s.referralRepo.Reward(ctx, referral, referrerEntry, refereeEntry)
+	// Rewarded by a concurrent verification
+	if errors.Is(err, domain.ErrReferralNotPending) || errors.Is(err, domain.ErrDuplicateEntry) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reward referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// RewardVerifiedReferrals pays the referrals of verified users whose payout failed when they verified,
+// returning how many were rewarded
+func (s *ReferralService) RewardVerifiedReferrals(ctx context.Context) (int, error) {
+	rewarded := 0
+	for {
+		referrals, err := s.referralRepo.ListRewardable(ctx, referralRewardBatchSize)
+		if err != nil {
+			return rewarded, fmt.Errorf("failed to list rewardable referrals: %w", err)
+		}
+
+		for _, referral := range referrals {
+			paid, err := s.RewardReferral(ctx, referral.RefereeID)
+			if err != nil {
+				return rewarded, err
+			}
+			if paid != nil {
+				rewarded++
+			}
+		}
+
+		if len(referrals) < referralRewardBatchSize {
+			return rewarded, nil
+		}
+	}
+}
+
+// ListReferrals retrieves a paginated list of the users a referrer referred and whether they were rewarded
+func (s *ReferralService) ListReferrals(ctx context.Context, referrerID string, limit, offset int) ([]*domain.Referral, error) {
+	if referrerID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, referrerID, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	referrals, err := s.referralRepo.ListByReferrer(ctx, referrerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrals: %w", err)
+	}
+
+	return referrals, nil
+}
+
+// rewardEntry builds the entry paying one user of a referral their credits
+func (s *ReferralService) rewardEntry(ctx context.Context, referral *domain.Referral, userID string, amount int64, side string) (*domain.JournalEntry, error) {
+	issuance, err := s.ledgerRepo.GetSystemAccount(ctx, domain.PointTypeCredits, domain.AccountIssuance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuance account: %w", err)
+	}
+
+	account, err := s.ledgerRepo.GetUserAccount(ctx, userID, domain.PointTypeCredits, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+	}
+
+	policy, err := s.config.PointTypes.Policy(domain.PointTypeCredits)
+	if err != nil {
+		return nil, err
+	}
+
+	reference := fmt.Sprintf("referral:%s:%s", referral.ID, side)
+	entry, err := domain.NewTransferEntry(domain.EntryKindEarn, domain.PointTypeCredits, userID, issuance.ID, account.ID, amount, reference, "Referral reward")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build referral entry: %w", err)
+	}
+	entry.ExpireCreditsAfter(policy.Lifetime)
+
+	return entry, nil
+}
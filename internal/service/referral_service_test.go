@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/otp"
+)
+
+// MockReferralRepository implements ReferralRepository for testing; like the signup transaction,
+// it records a pending referral for every user created with a referrer
+type MockReferralRepository struct {
+	users     *MockUserRepository
+	ledger    *MockLedgerRepository
+	referrals map[string]*domain.Referral
+}
+
+func NewMockReferralRepository(users *MockUserRepository, ledger *MockLedgerRepository) *MockReferralRepository {
+	return &MockReferralRepository{
+		users:     users,
+		ledger:    ledger,
+		referrals: make(map[string]*domain.Referral),
+	}
+}
+
+// NewTestReferralService creates a referral service backed by mocks for tests that don't exercise referrals
+func NewTestReferralService(users *MockUserRepository) *ReferralService {
+	ledger := NewMockLedgerRepository()
+	return NewReferralService(NewMockReferralRepository(users, ledger), users, ledger, ReferralConfig{})
+}
+
+func (m *MockReferralRepository) GetByReferee(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	if referral, exists := m.referrals[refereeID]; exists {
+		return referral, nil
+	}
+
+	referee, exists := m.users.users[refereeID]
+	if !exists || referee.ReferredBy == nil {
+		return nil, domain.ErrReferralNotFound
+	}
+
+	referral := &domain.Referral{
+		ID:                 "referral-" + refereeID,
+		ReferrerID:         *referee.ReferredBy,
+		RefereeID:          refereeID,
+		RefereeName:        referee.Name,
+		RefereeEmailDomain: domain.EmailDomain(referee.Email),
+		Status:             domain.ReferralPending,
+		CreatedAt:          referee.CreatedAt,
+	}
+	m.referrals[refereeID] = referral
+	return referral, nil
+}
+
+func (m *MockReferralRepository) CountByReferrerAndDomain(ctx context.Context, referrerID, emailDomain string) (int, error) {
+	count := 0
+	for _, user := range m.users.users {
+		if user.ReferredBy != nil && *user.ReferredBy == referrerID && domain.EmailDomain(user.Email) == emailDomain {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockReferralRepository) Reward(ctx context.Context, referral *domain.Referral, referrerEntry, refereeEntry *domain.JournalEntry) error {
+	if stored, exists := m.referrals[referral.RefereeID]; exists && stored != referral && stored.Status != domain.ReferralPending {
+		return domain.ErrReferralNotPending
+	}
+
+	for _, entry := range []*domain.JournalEntry{referrerEntry, refereeEntry} {
+		if err := m.ledger.PostEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	referral.ReferrerEntryID = &referrerEntry.ID
+	referral.RefereeEntryID = &refereeEntry.ID
+	m.referrals[referral.RefereeID] = referral
+	return nil
+}
+
+func (m *MockReferralRepository) ListByReferrer(ctx context.Context, referrerID string, limit, offset int) ([]*domain.Referral, error) {
+	var referrals []*domain.Referral
+	for id, user := range m.users.users {
+		if user.ReferredBy == nil || *user.ReferredBy != referrerID {
+			continue
+		}
+		referral, err := m.GetByReferee(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		referrals = append(referrals, referral)
+	}
+	return referrals, nil
+}
+
+func (m *MockReferralRepository) ListRewardable(ctx context.Context, limit int) ([]*domain.Referral, error) {
+	var referrals []*domain.Referral
+	for id, user := range m.users.users {
+		if user.ReferredBy == nil || !user.IsEmailVerified || len(referrals) == limit {
+			continue
+		}
+		referral, err := m.GetByReferee(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if referral.Status == domain.ReferralPending {
+			referrals = append(referrals, referral)
+		}
+	}
+	return referrals, nil
+}
+
+func TestReferralService_ReferAndReward(t *testing.T) {
+	users := NewMockUserRepository()
+	ledger := NewMockLedgerRepository()
+	referralService := NewReferralService(NewMockReferralRepository(users, ledger), users, ledger, ReferralConfig{ReferrerReward: 100, RefereeReward: 40, MaxPerEmailDomain: 1})
+	sender := &MockNotifier{}
+	userService := NewUserService(users, NewMockRefreshTokenRepository(), NewMockEmailChangeRepository(users), referralService, sender, otp.NewHasher("test-secret"), OTPConfig{}, EmailChangeConfig{})
+	ctx := context.Background()
+
+	// Signup opens each user's wallet, as the repository does
+	users.createFn = func(ctx context.Context, user *domain.User) error {
+		user.ID = fmt.Sprintf("user-%d", len(users.users)+1)
+		users.users[user.ID] = user
+		users.emails[user.Email] = user
+		account, _ := domain.NewUserAccount(user.ID, domain.PointTypeCredits, domain.AccountUserAvailable)
+		return ledger.OpenUserAccount(ctx, account)
+	}
+
+	referrer, err := userService.CreateUser(ctx, "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatalf("CreateUser() unexpected error: %v", err)
+	}
+	if len(referrer.ReferralCode) != domain.ReferralCodeLength {
+		t.Fatalf("CreateUser() ReferralCode = %q, want %d characters", referrer.ReferralCode, domain.ReferralCodeLength)
+	}
+
+	tests := []struct {
+		name    string
+		email   string
+		code    string
+		wantErr error
+	}{
+		{"unknown code", "dave@example.org", "ZZZZZZZZ", domain.ErrInvalidReferralCode},
+		{"own code through an address alias", "Alice+promo@Example.com", referrer.ReferralCode, domain.ErrSelfReferral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := userService.CreateUser(ctx, tt.email, "Someone", tt.code); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateUser() expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Codes are accepted regardless of case and surrounding spaces
+	referee, err := userService.CreateUser(ctx, "bob@corp.test", "Bob", " "+strings.ToLower(referrer.ReferralCode)+" ")
+	if err != nil {
+		t.Fatalf("CreateUser() with referral code unexpected error: %v", err)
+	}
+	if referee.ReferredBy == nil || *referee.ReferredBy != referrer.ID {
+		t.Errorf("CreateUser() ReferredBy = %v, want %s", referee.ReferredBy, referrer.ID)
+	}
+
+	// The referrer already brought a user from corp.test
+	if _, err := userService.CreateUser(ctx, "carol@corp.test", "Carol", referrer.ReferralCode); !errors.Is(err, domain.ErrReferralLimitReached) {
+		t.Errorf("CreateUser() expected error %v, got %v", domain.ErrReferralLimitReached, err)
+	}
+
+	if err := userService.RequestOTP(ctx, "bob@corp.test"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+	if _, err := userService.VerifyOTP(ctx, "bob@corp.test", sender.sent["bob@corp.test"]); err != nil {
+		t.Fatalf("VerifyOTP() unexpected error: %v", err)
+	}
+
+	// Verifying again must not pay the referral twice
+	referral, err := referralService.RewardReferral(ctx, referee.ID)
+	if err != nil || referral != nil {
+		t.Errorf("RewardReferral() second call = %v, %v, want nil, nil", referral, err)
+	}
+
+	for _, want := range []struct {
+		userID  string
+		balance int64
+	}{{referrer.ID, 100}, {referee.ID, 40}} {
+		account, _ := ledger.GetUserAccount(ctx, want.userID, domain.PointTypeCredits, domain.AccountUserAvailable)
+		if account.Balance != want.balance {
+			t.Errorf("balance of %s = %d, want %d", want.userID, account.Balance, want.balance)
+		}
+	}
+
+	referrerCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: referrer.ID, Role: domain.RoleUser})
+	referrals, err := referralService.ListReferrals(referrerCtx, referrer.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("ListReferrals() unexpected error: %v", err)
+	}
+	if len(referrals) != 1 || referrals[0].Status != domain.ReferralRewarded || referrals[0].RewardedAt == nil {
+		t.Errorf("ListReferrals() = %+v, want one rewarded referral", referrals)
+	}
+
+	refereeCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: referee.ID, Role: domain.RoleUser})
+	if _, err := referralService.ListReferrals(refereeCtx, referrer.ID, 10, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListReferrals() expected error %v, got %v", domain.ErrForbidden, err)
+	}
+}
+
+// failingReferralProgram refers users like the real program but fails every payout
+type failingReferralProgram struct {
+	*ReferralService
+}
+
+func (p failingReferralProgram) RewardReferral(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	return nil, errors.New("ledger unavailable")
+}
+
+func TestReferralService_RewardVerifiedReferrals(t *testing.T) {
+	users := NewMockUserRepository()
+	ledger := NewMockLedgerRepository()
+	referralService := NewReferralService(NewMockReferralRepository(users, ledger), users, ledger, ReferralConfig{ReferrerReward: 100, RefereeReward: 40})
+	sender := &MockNotifier{}
+	userService := NewUserService(users, NewMockRefreshTokenRepository(), NewMockEmailChangeRepository(users), failingReferralProgram{referralService}, sender, otp.NewHasher("test-secret"), OTPConfig{}, EmailChangeConfig{})
+	ctx := context.Background()
+
+	users.createFn = func(ctx context.Context, user *domain.User) error {
+		user.ID = fmt.Sprintf("user-%d", len(users.users)+1)
+		users.users[user.ID] = user
+		users.emails[user.Email] = user
+		account, _ := domain.NewUserAccount(user.ID, domain.PointTypeCredits, domain.AccountUserAvailable)
+		return ledger.OpenUserAccount(ctx, account)
+	}
+
+	referrer, err := userService.CreateUser(ctx, "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatalf("CreateUser() unexpected error: %v", err)
+	}
+	referee, err := userService.CreateUser(ctx, "bob@corp.test", "Bob", referrer.ReferralCode)
+	if err != nil {
+		t.Fatalf("CreateUser() with referral code unexpected error: %v", err)
+	}
+
+	// Nothing to pay before the referee verified their email
+	if rewarded, err := referralService.RewardVerifiedReferrals(ctx); err != nil || rewarded != 0 {
+		t.Errorf("RewardVerifiedReferrals() before verification = %d, %v, want 0, nil", rewarded, err)
+	}
+
+	if err := userService.RequestOTP(ctx, "bob@corp.test"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+
+	// A failed payout must not fail the verification
+	verified, err := userService.VerifyOTP(ctx, "bob@corp.test", sender.sent["bob@corp.test"])
+	if err != nil {
+		t.Fatalf("VerifyOTP() with failing payout unexpected error: %v", err)
+	}
+	if !verified.IsEmailVerified {
+		t.Error("VerifyOTP() with failing payout did not verify the email")
+	}
+
+	rewarded, err := referralService.RewardVerifiedReferrals(ctx)
+	if err != nil || rewarded != 1 {
+		t.Fatalf("RewardVerifiedReferrals() = %d, %v, want 1, nil", rewarded, err)
+	}
+
+	for _, want := range []struct {
+		userID  string
+		balance int64
+	}{{referrer.ID, 100}, {referee.ID, 40}} {
+		account, _ := ledger.GetUserAccount(ctx, want.userID, domain.PointTypeCredits, domain.AccountUserAvailable)
+		if account.Balance != want.balance {
+			t.Errorf("balance of %s = %d, want %d", want.userID, account.Balance, want.balance)
+		}
+	}
+
+	// Retrying again pays nothing twice
+	if rewarded, err := referralService.RewardVerifiedReferrals(ctx); err != nil || rewarded != 0 {
+		t.Errorf("RewardVerifiedReferrals() second run = %d, %v, want 0, nil", rewarded, err)
+	}
+}
+
+// racingReferralProgram refers users without counting earlier referrals, like a check that ran
+// before a concurrent signup from the same email domain was saved
+type racingReferralProgram struct {
+	*ReferralService
+	referrer *domain.User
+}
+
+func (p racingReferralProgram) ReferUser(ctx context.Context, referee *domain.User, code string) error {
+	return referee.ReferBy(p.referrer)
+}
+
+func TestUserService_CreateUserReferralSaving(t *testing.T) {
+	users := NewMockUserRepository()
+	referrer := &domain.User{ID: "user-1", Email: "alice@example.com", Name: "Alice", IsActive: true, ReferralCode: "ALICE123"}
+	users.users[referrer.ID] = referrer
+	users.emails[referrer.Email] = referrer
+
+	program := racingReferralProgram{
+		ReferralService: NewReferralService(NewMockReferralRepository(users, NewMockLedgerRepository()), users, NewMockLedgerRepository(), ReferralConfig{MaxPerEmailDomain: 1}),
+		referrer:        referrer,
+	}
+	userService := NewUserService(users, NewMockRefreshTokenRepository(), NewMockEmailChangeRepository(users), program, &MockNotifier{}, otp.NewHasher("test-secret"), OTPConfig{}, EmailChangeConfig{})
+	ctx := context.Background()
+
+	// The first generated code is taken by another user, so signup retries with a new one
+	var codes []string
+	users.createFn = func(ctx context.Context, user *domain.User) error {
+		codes = append(codes, user.ReferralCode)
+		if len(codes) == 1 {
+			return domain.ErrReferralCodeTaken
+		}
+		user.ID = fmt.Sprintf("user-%d", len(users.users)+1)
+		users.users[user.ID] = user
+		users.emails[user.Email] = user
+		return nil
+	}
+
+	referee, err := userService.CreateUser(ctx, "bob@corp.test", "Bob", referrer.ReferralCode)
+	if err != nil {
+		t.Fatalf("CreateUser() after referral code collision unexpected error: %v", err)
+	}
+	if len(codes) != 2 || referee.ReferralCode != codes[1] || codes[0] == codes[1] {
+		t.Errorf("CreateUser() tried codes %v and kept %q, want a second, different code", codes, referee.ReferralCode)
+	}
+
+	// The limit the referral check missed is still enforced when the user is saved
+	if _, err := userService.CreateUser(ctx, "carol@corp.test", "Carol", referrer.ReferralCode); !errors.Is(err, domain.ErrReferralLimitReached) {
+		t.Errorf("CreateUser() over the domain limit expected error %v, got %v", domain.ErrReferralLimitReached, err)
+	}
+
+	// Collisions that keep happening are reported rather than retried forever
+	users.createFn = func(ctx context.Context, user *domain.User) error {
+		return domain.ErrReferralCodeTaken
+	}
+	if _, err := userService.CreateUser(ctx, "dave@example.org", "Dave", ""); !errors.Is(err, domain.ErrReferralCodeTaken) {
+		t.Errorf("CreateUser() with colliding codes expected error %v, got %v", domain.ErrReferralCodeTaken, err)
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// ReversalRepository defines what the reversal service needs from the data layer
+type ReversalRepository interface {
+	ListByEntry(ctx context.Context, entryID string) ([]*domain.Reversal, error)
+	Reverse(ctx context.Context, reversal *domain.Reversal, reversed int64, entry *domain.JournalEntry) error
+}
+
+// ReversalConfig holds reversal settings
+type ReversalConfig struct {
+	PointTypes domain.PointTypePolicies // how long points returned to users stay usable
+}
+
+// ReversalService undoes journal entries with compensating entries: earned points are clawed back
+// when the order behind them is refunded, and spent points are returned when a redemption fails fulfillment
+type ReversalService struct {
+	reversalRepo ReversalRepository
+	ledgerRepo   LedgerRepository
+	tiers        TierRecalculator
+	config       ReversalConfig
+}
+
+// NewReversalService creates a new reversal service
+func NewReversalService(reversalRepo ReversalRepository, ledgerRepo LedgerRepository, tiers TierRecalculator, config ReversalConfig) *ReversalService {
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &ReversalService{
+		reversalRepo: reversalRepo,
+		ledgerRepo:   ledgerRepo,
+		tiers:        tiers,
+		config:       config,
+	}
+}
+
+// ReverseEntry reverses amount points of an earn or redeem entry, or whatever is left of it if amount is zero.
+// An entry can be reversed in parts until all of it is reversed. Earned points the user already spent are
+// not clawed back but recorded as the reversal's shortfall; reversed qualifying points recalculate the user's tier.
+func (s *ReversalService) ReverseEntry(ctx context.Context, entryID string, amount int64, reason string) (*domain.Reversal, error) {
+	if err := authorizeAdmin(ctx, domain.ScopeCreditsWrite); err != nil {
+		return nil, err
+	}
+
+	if entryID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	entry, err := s.ledgerRepo.GetEntry(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal entry: %w", err)
+	}
+
+	reversals, err := s.reversalRepo.ListByEntry(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reversals: %w", err)
+	}
+	reversed := domain.TotalReversed(reversals)
+
+	reversal, err := domain.NewReversal(entry, reversed, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.reverse(ctx, reversal, reversed)
+	// The user spent some of the points meanwhile; claw back what is left now
+	if errors.Is(err, domain.ErrInsufficientCredits) {
+		err = s.reverse(ctx, reversal, reversed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reverse journal entry: %w", err)
+	}
+
+	if reversal.EntryKind == domain.EntryKindEarn && reversal.PointType == domain.QualifyingPointType {
+		if _, err := s.tiers.RecalculateTier(ctx, reversal.UserID); err != nil {
+			return nil, fmt.Errorf("failed to recalculate tier: %w", err)
+		}
+	}
+
+	return reversal, nil
+}
+
+// reverse builds the reversal's compensating entry from the user's current balance and records both
+func (s *ReversalService) reverse(ctx context.Context, reversal *domain.Reversal, reversed int64) error {
+	entry, err := s.buildEntry(ctx, reversal, reversed)
+	if err != nil {
+		return err
+	}
+
+	return s.reversalRepo.Reverse(ctx, reversal, reversed, entry)
+}
+
+// buildEntry builds the compensating entry and sets how much of the reversal it posts.
+// Earn entries are clawed back from the user's available balance as far as it goes, returning nil
+// when nothing is left; redeem entries are refunded in full as newly issued points.
+func (s *ReversalService) buildEntry(ctx context.Context, reversal *domain.Reversal, reversed int64) (*domain.JournalEntry, error) {
+	var from, to string
+	var lifetime time.Duration
+	switch reversal.EntryKind {
+	case domain.EntryKindEarn:
+		issuance, err := s.ledgerRepo.GetSystemAccount(ctx, reversal.PointType, domain.AccountIssuance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issuance account: %w", err)
+		}
+
+		account, err := s.ledgerRepo.GetUserAccount(ctx, reversal.UserID, reversal.PointType, domain.AccountUserAvailable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user ledger account: %w", err)
+		}
+
+		reversal.Posted = min(reversal.Amount, max(account.Balance, 0))
+		from, to = account.ID, issuance.ID
+
+	case domain.EntryKindRedeem:
+		policy, err := s.config.PointTypes.Policy(reversal.PointType)
+		if err != nil {
+			return nil, err
+		}
+
+		redemption, err := s.ledgerRepo.GetSystemAccount(ctx, reversal.PointType, domain.AccountRedemption)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get redemption account: %w", err)
+		}
+
+		account, err := openWalletAccount(ctx, s.ledgerRepo, reversal.UserID, reversal.PointType, domain.AccountUserAvailable)
+		if err != nil {
+			return nil, err
+		}
+
+		reversal.Posted = reversal.Amount
+		from, to = redemption.ID, account.ID
+		lifetime = policy.Lifetime
+
+	default:
+		return nil, domain.ErrEntryNotReversible
+	}
+
+	if reversal.Posted == 0 {
+		return nil, nil
+	}
+
+	entry, err := domain.NewTransferEntry(domain.EntryKindReversal, reversal.PointType, reversal.UserID, from, to, reversal.Posted, reversal.EntryReference(reversed), reversal.Description())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build reversal entry: %w", err)
+	}
+	entry.ExpireCreditsAfter(lifetime)
+
+	return entry, nil
+}
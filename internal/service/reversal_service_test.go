@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockReversalRepository implements ReversalRepository for testing
+type MockReversalRepository struct {
+	ledger    *MockLedgerRepository
+	reversals []*domain.Reversal
+}
+
+func NewMockReversalRepository(ledger *MockLedgerRepository) *MockReversalRepository {
+	return &MockReversalRepository{
+		ledger: ledger,
+	}
+}
+
+func (m *MockReversalRepository) ListByEntry(ctx context.Context, entryID string) ([]*domain.Reversal, error) {
+	var reversals []*domain.Reversal
+	for _, reversal := range m.reversals {
+		if reversal.EntryID == entryID {
+			reversals = append(reversals, reversal)
+		}
+	}
+	return reversals, nil
+}
+
+func (m *MockReversalRepository) Reverse(ctx context.Context, reversal *domain.Reversal, reversed int64, entry *domain.JournalEntry) error {
+	current, _ := m.ListByEntry(ctx, reversal.EntryID)
+	if domain.TotalReversed(current) != reversed {
+		return domain.ErrReversalConflict
+	}
+
+	if entry != nil {
+		if err := m.ledger.PostEntry(ctx, entry); err != nil {
+			return err
+		}
+		reversal.ReversalEntryID = &entry.ID
+	}
+
+	reversal.ID = fmt.Sprintf("reversal-%d", len(m.reversals)+1)
+	m.reversals = append(m.reversals, reversal)
+	return nil
+}
+
+func TestReversalService_ReverseEntry(t *testing.T) {
+	ledger := NewMockLedgerRepository()
+	ledgerService := NewLedgerService(ledger, LedgerConfig{})
+	tiers := &MockTierRecalculator{}
+	service := NewReversalService(NewMockReversalRepository(ledger), ledger, tiers, ReversalConfig{})
+	ctx := context.Background()
+	adminCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	earned, err := ledgerService.Credit(ctx, "user-1", domain.PointTypeCredits, 100, domain.EntryKindEarn, "order-1", "Cashback")
+	if err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	redeemed, err := ledgerService.Debit(ctx, "user-1", domain.PointTypeCredits, 30, domain.EntryKindRedeem, "order-2", "Gift card")
+	if err != nil {
+		t.Fatalf("Debit() unexpected error: %v", err)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	if _, err := service.ReverseEntry(userCtx, earned.ID, 0, ""); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ReverseEntry() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	partial, err := service.ReverseEntry(adminCtx, earned.ID, 20, "Order partially refunded")
+	if err != nil {
+		t.Fatalf("ReverseEntry() partial unexpected error: %v", err)
+	}
+	if partial.Amount != 20 || partial.Posted != 20 || partial.ReversalEntryID == nil {
+		t.Errorf("ReverseEntry() partial = %+v, want 20 clawed back", partial)
+	}
+
+	if _, err := service.ReverseEntry(adminCtx, earned.ID, 81, ""); !errors.Is(err, domain.ErrReversalExceedsEntry) {
+		t.Errorf("ReverseEntry() beyond the rest expected error %v, got %v", domain.ErrReversalExceedsEntry, err)
+	}
+
+	// Only 50 of the remaining 80 are still available; the 30 spent on the gift card are the shortfall
+	rest, err := service.ReverseEntry(adminCtx, earned.ID, 0, "Order refunded")
+	if err != nil {
+		t.Fatalf("ReverseEntry() rest unexpected error: %v", err)
+	}
+	if rest.Amount != 80 || rest.Posted != 50 || rest.Shortfall() != 30 {
+		t.Errorf("ReverseEntry() rest = %+v, want 80 reversed with 50 clawed back", rest)
+	}
+
+	if _, err := service.ReverseEntry(adminCtx, earned.ID, 0, ""); !errors.Is(err, domain.ErrEntryAlreadyReversed) {
+		t.Errorf("ReverseEntry() twice expected error %v, got %v", domain.ErrEntryAlreadyReversed, err)
+	}
+	if _, err := service.ReverseEntry(adminCtx, *rest.ReversalEntryID, 0, ""); !errors.Is(err, domain.ErrEntryNotReversible) {
+		t.Errorf("ReverseEntry() of a reversal expected error %v, got %v", domain.ErrEntryNotReversible, err)
+	}
+
+	// A failed fulfillment returns the spent credits
+	apiKeyCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{APIKeyID: "key-1", Scopes: []string{domain.ScopeCreditsWrite}})
+	refund, err := service.ReverseEntry(apiKeyCtx, redeemed.ID, 0, "Gift card out of stock")
+	if err != nil {
+		t.Fatalf("ReverseEntry() redeem unexpected error: %v", err)
+	}
+	if refund.Posted != 30 || refund.Shortfall() != 0 {
+		t.Errorf("ReverseEntry() redeem = %+v, want 30 returned", refund)
+	}
+
+	account, _ := ledger.GetUserAccount(ctx, "user-1", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if account.Balance != 30 {
+		t.Errorf("balance = %d, want 30", account.Balance)
+	}
+	if len(tiers.userIDs) != 0 {
+		t.Errorf("RecalculateTier() called for %v, want no calls for credits", tiers.userIDs)
+	}
+
+	// Reversed qualifying points move the user's tier
+	tierPoints, err := ledgerService.Credit(ctx, "user-1", domain.PointTypeTierPoints, 500, domain.EntryKindEarn, "order-3", "Status points")
+	if err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	if _, err := service.ReverseEntry(adminCtx, tierPoints.ID, 0, "Order refunded"); err != nil {
+		t.Fatalf("ReverseEntry() tier points unexpected error: %v", err)
+	}
+	if len(tiers.userIDs) != 1 || tiers.userIDs[0] != "user-1" {
+		t.Errorf("RecalculateTier() called for %v, want [user-1]", tiers.userIDs)
+	}
+}
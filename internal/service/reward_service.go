@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// RewardRepository defines what the reward service needs from the data layer
+type RewardRepository interface {
+	Create(ctx context.Context, reward *domain.Reward) error
+	GetByID(ctx context.Context, id string) (*domain.Reward, error)
+	Update(ctx context.Context, reward *domain.Reward) error
+	List(ctx context.Context, limit, offset int) ([]*domain.Reward, error)
+	ListAvailable(ctx context.Context, at time.Time, limit, offset int) ([]*domain.Reward, error)
+}
+
+// RewardService provides business logic for the reward catalog
+type RewardService struct {
+	rewardRepo RewardRepository
+}
+
+// NewRewardService creates a new reward service
+func NewRewardService(rewardRepo RewardRepository) *RewardService {
+	return &RewardService{
+		rewardRepo: rewardRepo,
+	}
+}
+
+// CreateReward adds a reward to the catalog
+func (s *RewardService) CreateReward(ctx context.Context, spec domain.RewardSpec) (*domain.Reward, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	reward, err := domain.NewReward(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reward: %w", err)
+	}
+
+	if err := s.rewardRepo.Create(ctx, reward); err != nil {
+		return nil, fmt.Errorf("failed to save reward: %w", err)
+	}
+
+	return reward, nil
+}
+
+// GetReward retrieves a reward by ID; only admins can see rewards that are not currently available
+func (s *RewardService) GetReward(ctx context.Context, id string) (*domain.Reward, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	reward, err := s.rewardRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward: %w", err)
+	}
+
+	if !reward.IsAvailableAt(time.Now()) && authorizeAdmin(ctx, "") != nil {
+		return nil, fmt.Errorf("failed to get reward: %w", domain.ErrRewardNotFound)
+	}
+
+	return reward, nil
+}
+
+// UpdateReward replaces a reward's settings
+func (s *RewardService) UpdateReward(ctx context.Context, id string, spec domain.RewardSpec) (*domain.Reward, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	reward, err := s.rewardRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward: %w", err)
+	}
+
+	if err := reward.Update(spec); err != nil {
+		return nil, fmt.Errorf("failed to update reward: %w", err)
+	}
+
+	if err := s.rewardRepo.Update(ctx, reward); err != nil {
+		return nil, fmt.Errorf("failed to save reward: %w", err)
+	}
+
+	return reward, nil
+}
+
+// DeleteReward archives a reward so it leaves the catalog while past redemptions keep referring to it
+func (s *RewardService) DeleteReward(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return err
+	}
+
+	reward, err := s.rewardRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get reward: %w", err)
+	}
+
+	reward.Archive()
+
+	if err := s.rewardRepo.Update(ctx, reward); err != nil {
+		return fmt.Errorf("failed to archive reward: %w", err)
+	}
+
+	return nil
+}
+
+// ListRewards retrieves a page of the catalog. Users browse active, in-stock rewards;
+// admins may include drafts, archived and out-of-stock rewards.
+func (s *RewardService) ListRewards(ctx context.Context, includeUnavailable bool, limit, offset int) ([]*domain.Reward, error) {
+	if includeUnavailable {
+		if err := authorizeAdmin(ctx, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var rewards []*domain.Reward
+	var err error
+	if includeUnavailable {
+		rewards, err = s.rewardRepo.List(ctx, limit, offset)
+	} else {
+		rewards, err = s.rewardRepo.ListAvailable(ctx, time.Now(), limit, offset)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rewards: %w", err)
+	}
+
+	return rewards, nil
+}
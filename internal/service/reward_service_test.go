@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockRewardRepository implements RewardRepository for testing
+type MockRewardRepository struct {
+	rewards map[string]*domain.Reward
+	nextID  int
+}
+
+func NewMockRewardRepository() *MockRewardRepository {
+	return &MockRewardRepository{
+		rewards: make(map[string]*domain.Reward),
+	}
+}
+
+func (m *MockRewardRepository) Create(ctx context.Context, reward *domain.Reward) error {
+	m.nextID++
+	reward.ID = fmt.Sprintf("reward-%d", m.nextID)
+	m.rewards[reward.ID] = reward
+	return nil
+}
+
+func (m *MockRewardRepository) GetByID(ctx context.Context, id string) (*domain.Reward, error) {
+	reward, exists := m.rewards[id]
+	if !exists {
+		return nil, domain.ErrRewardNotFound
+	}
+	return reward, nil
+}
+
+func (m *MockRewardRepository) Update(ctx context.Context, reward *domain.Reward) error {
+	if _, exists := m.rewards[reward.ID]; !exists {
+		return domain.ErrRewardNotFound
+	}
+	m.rewards[reward.ID] = reward
+	return nil
+}
+
+func (m *MockRewardRepository) List(ctx context.Context, limit, offset int) ([]*domain.Reward, error) {
+	rewards := make([]*domain.Reward, 0, len(m.rewards))
+	for _, reward := range m.rewards {
+		rewards = append(rewards, reward)
+	}
+	return rewards, nil
+}
+
+func (m *MockRewardRepository) ListAvailable(ctx context.Context, at time.Time, limit, offset int) ([]*domain.Reward, error) {
+	var rewards []*domain.Reward
+	for _, reward := range m.rewards {
+		if reward.IsAvailableAt(at) {
+			rewards = append(rewards, reward)
+		}
+	}
+	return rewards, nil
+}
+
+func TestRewardService_Catalog(t *testing.T) {
+	service := NewRewardService(NewMockRewardRepository())
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	if _, err := service.CreateReward(userCtx, domain.RewardSpec{Name: "Mug", Cost: 100, Stock: 1}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateReward() as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+
+	active, err := service.CreateReward(adminCtx, domain.RewardSpec{Name: "Mug", Cost: 100, Stock: 5, Status: domain.RewardActive})
+	if err != nil {
+		t.Fatalf("CreateReward() unexpected error: %v", err)
+	}
+	draft, err := service.CreateReward(adminCtx, domain.RewardSpec{Name: "T-shirt", Cost: 300, Stock: 5})
+	if err != nil {
+		t.Fatalf("CreateReward() unexpected error: %v", err)
+	}
+
+	rewards, err := service.ListRewards(userCtx, false, 10, 0)
+	if err != nil {
+		t.Fatalf("ListRewards() unexpected error: %v", err)
+	}
+	if len(rewards) != 1 || rewards[0].ID != active.ID {
+		t.Errorf("ListRewards() as user = %v, want only the active reward", rewards)
+	}
+
+	if _, err := service.ListRewards(userCtx, true, 10, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListRewards(all) as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if rewards, _ := service.ListRewards(adminCtx, true, 10, 0); len(rewards) != 2 {
+		t.Errorf("ListRewards(all) as admin returned %d rewards, want 2", len(rewards))
+	}
+
+	if _, err := service.GetReward(userCtx, draft.ID); !errors.Is(err, domain.ErrRewardNotFound) {
+		t.Errorf("GetReward() draft as user expected %v, got %v", domain.ErrRewardNotFound, err)
+	}
+	if _, err := service.GetReward(adminCtx, draft.ID); err != nil {
+		t.Errorf("GetReward() draft as admin unexpected error: %v", err)
+	}
+
+	updated, err := service.UpdateReward(adminCtx, draft.ID, domain.RewardSpec{Name: "T-shirt", Cost: 250, Stock: 3, Status: domain.RewardActive})
+	if err != nil {
+		t.Fatalf("UpdateReward() unexpected error: %v", err)
+	}
+	if updated.Cost != 250 || updated.Status != domain.RewardActive {
+		t.Errorf("UpdateReward() = %+v, want active reward costing 250", updated)
+	}
+
+	if err := service.DeleteReward(adminCtx, active.ID); err != nil {
+		t.Fatalf("DeleteReward() unexpected error: %v", err)
+	}
+	if _, err := service.GetReward(userCtx, active.ID); !errors.Is(err, domain.ErrRewardNotFound) {
+		t.Errorf("GetReward() archived as user expected %v, got %v", domain.ErrRewardNotFound, err)
+	}
+}
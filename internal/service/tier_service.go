@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// tierStandingBatchSize is the number of users recalculated per batch
+const tierStandingBatchSize = 100
+
+// TierRepository defines what the tier service needs from the data layer
+type TierRepository interface {
+	Create(ctx context.Context, tier *domain.Tier) error
+	GetByID(ctx context.Context, id string) (*domain.Tier, error)
+	Update(ctx context.Context, tier *domain.Tier) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*domain.Tier, error)
+	GetStanding(ctx context.Context, userID string, since time.Time) (*domain.TierStanding, error)
+	ListStandings(ctx context.Context, since time.Time, limit, offset int) ([]*domain.TierStanding, error)
+	ChangeTier(ctx context.Context, change *domain.TierChange) error
+	ListChanges(ctx context.Context, userID string, limit, offset int) ([]*domain.TierChange, error)
+}
+
+// TierConfig holds loyalty tier settings
+type TierConfig struct {
+	QualifyingWindow time.Duration // how far back earned qualifying points count towards a tier
+}
+
+// TierService manages loyalty tiers and keeps each user's tier in line with their qualifying points
+type TierService struct {
+	tierRepo TierRepository
+	config   TierConfig
+}
+
+// NewTierService creates a new tier service
+func NewTierService(tierRepo TierRepository, config TierConfig) *TierService {
+	if config.QualifyingWindow <= 0 {
+		config.QualifyingWindow = 365 * 24 * time.Hour // Default qualifying window
+	}
+
+	return &TierService{
+		tierRepo: tierRepo,
+		config:   config,
+	}
+}
+
+// CreateTier adds a loyalty tier; users reach it at the next recalculation
+func (s *TierService) CreateTier(ctx context.Context, spec domain.TierSpec) (*domain.Tier, error) {
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	tier, err := domain.NewTier(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tier: %w", err)
+	}
+
+	if err := s.tierRepo.Create(ctx, tier); err != nil {
+		return nil, fmt.Errorf("failed to save tier: %w", err)
+	}
+
+	return tier, nil
+}
+
+// GetTier retrieves a tier by ID
+func (s *TierService) GetTier(ctx context.Context, id string) (*domain.Tier, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	tier, err := s.tierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tier: %w", err)
+	}
+
+	return tier, nil
+}
+
+// UpdateTier replaces a tier's settings; members move at the next recalculation
+func (s *TierService) UpdateTier(ctx context.Context, id string, spec domain.TierSpec) (*domain.Tier, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	tier, err := s.tierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tier: %w", err)
+	}
+
+	if err := tier.Update(spec); err != nil {
+		return nil, fmt.Errorf("failed to update tier: %w", err)
+	}
+
+	if err := s.tierRepo.Update(ctx, tier); err != nil {
+		return nil, fmt.Errorf("failed to save tier: %w", err)
+	}
+
+	return tier, nil
+}
+
+// DeleteTier removes a tier; its members are placed in the tier they qualify for at the next recalculation
+func (s *TierService) DeleteTier(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrInvalidInput
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return err
+	}
+
+	if err := s.tierRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete tier: %w", err)
+	}
+
+	return nil
+}
+
+// ListTiers retrieves every tier from the lowest threshold to the highest
+func (s *TierService) ListTiers(ctx context.Context) ([]*domain.Tier, error) {
+	tiers, err := s.tierRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tiers: %w", err)
+	}
+
+	return tiers, nil
+}
+
+// ListTierHistory retrieves a paginated list of a user's tier changes, newest first
+func (s *TierService) ListTierHistory(ctx context.Context, userID string, limit, offset int) ([]*domain.TierChange, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeUserAccess(ctx, userID, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	changes, err := s.tierRepo.ListChanges(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tier changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// RecalculateTier moves a user to the tier their qualifying points reach after they earned some,
+// returning the recorded change or nil if their tier stayed the same
+func (s *TierService) RecalculateTier(ctx context.Context, userID string) (*domain.TierChange, error) {
+	tiers, err := s.tierRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tiers: %w", err)
+	}
+
+	now := time.Now()
+	standing, err := s.tierRepo.GetStanding(ctx, userID, now.Add(-s.config.QualifyingWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tier standing: %w", err)
+	}
+
+	return s.apply(ctx, standing, tiers, domain.TierChangeActivity, now)
+}
+
+// RecalculateAllTiers moves every user to the tier their qualifying points reach, including
+// downgrades as points leave the window, and returns how many users changed tier
+func (s *TierService) RecalculateAllTiers(ctx context.Context) (int, error) {
+	tiers, err := s.tierRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tiers: %w", err)
+	}
+
+	now := time.Now()
+	since := now.Add(-s.config.QualifyingWindow)
+
+	changed := 0
+	for offset := 0; ; offset += tierStandingBatchSize {
+		standings, err := s.tierRepo.ListStandings(ctx, since, tierStandingBatchSize, offset)
+		if err != nil {
+			return changed, fmt.Errorf("failed to list tier standings: %w", err)
+		}
+
+		for _, standing := range standings {
+			change, err := s.apply(ctx, standing, tiers, domain.TierChangeScheduled, now)
+			if err != nil {
+				return changed, err
+			}
+			if change != nil {
+				changed++
+			}
+		}
+
+		if len(standings) < tierStandingBatchSize {
+			return changed, nil
+		}
+	}
+}
+
+// apply records the tier change the standing calls for, if any
+func (s *TierService) apply(ctx context.Context, standing *domain.TierStanding, tiers []*domain.Tier, reason domain.TierChangeReason, now time.Time) (*domain.TierChange, error) {
+	change := domain.NextTierChange(standing, tiers, reason, now)
+	if change == nil {
+		return nil, nil
+	}
+
+	err := s.tierRepo.ChangeTier(ctx, change)
+	// Moved by a concurrent recalculation, which saw the same or newer points
+	if errors.Is(err, domain.ErrTierChanged) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to change tier: %w", err)
+	}
+
+	return change, nil
+}
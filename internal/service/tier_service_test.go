@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockTierRepository implements TierRepository for testing; qualifying points are set per user
+type MockTierRepository struct {
+	tiers     map[string]*domain.Tier
+	standings map[string]*domain.TierStanding
+	changes   []*domain.TierChange
+	nextID    int
+}
+
+func NewMockTierRepository() *MockTierRepository {
+	return &MockTierRepository{
+		tiers:     make(map[string]*domain.Tier),
+		standings: make(map[string]*domain.TierStanding),
+	}
+}
+
+func (m *MockTierRepository) Create(ctx context.Context, tier *domain.Tier) error {
+	for _, existing := range m.tiers {
+		if existing.Name == tier.Name || existing.Threshold == tier.Threshold {
+			return domain.ErrTierAlreadyExists
+		}
+	}
+	m.nextID++
+	tier.ID = fmt.Sprintf("tier-%d", m.nextID)
+	m.tiers[tier.ID] = tier
+	return nil
+}
+
+func (m *MockTierRepository) GetByID(ctx context.Context, id string) (*domain.Tier, error) {
+	tier, exists := m.tiers[id]
+	if !exists {
+		return nil, domain.ErrTierNotFound
+	}
+	return tier, nil
+}
+
+func (m *MockTierRepository) Update(ctx context.Context, tier *domain.Tier) error {
+	if _, exists := m.tiers[tier.ID]; !exists {
+		return domain.ErrTierNotFound
+	}
+	m.tiers[tier.ID] = tier
+	return nil
+}
+
+func (m *MockTierRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.tiers[id]; !exists {
+		return domain.ErrTierNotFound
+	}
+	delete(m.tiers, id)
+	for _, standing := range m.standings {
+		if standing.TierID != nil && *standing.TierID == id {
+			standing.TierID = nil
+		}
+	}
+	return nil
+}
+
+func (m *MockTierRepository) List(ctx context.Context) ([]*domain.Tier, error) {
+	tiers := make([]*domain.Tier, 0, len(m.tiers))
+	for _, tier := range m.tiers {
+		tiers = append(tiers, tier)
+	}
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Threshold < tiers[j].Threshold })
+	return tiers, nil
+}
+
+func (m *MockTierRepository) GetStanding(ctx context.Context, userID string, since time.Time) (*domain.TierStanding, error) {
+	standing, exists := m.standings[userID]
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *standing
+	return &copied, nil
+}
+
+func (m *MockTierRepository) ListStandings(ctx context.Context, since time.Time, limit, offset int) ([]*domain.TierStanding, error) {
+	userIDs := make([]string, 0, len(m.standings))
+	for userID := range m.standings {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	var standings []*domain.TierStanding
+	for i := offset; i < len(userIDs) && len(standings) < limit; i++ {
+		copied := *m.standings[userIDs[i]]
+		standings = append(standings, &copied)
+	}
+	return standings, nil
+}
+
+func (m *MockTierRepository) ChangeTier(ctx context.Context, change *domain.TierChange) error {
+	standing := m.standings[change.UserID]
+
+	var currentID, fromID string
+	if standing.TierID != nil {
+		currentID = *standing.TierID
+	}
+	if change.FromTier != nil {
+		fromID = change.FromTier.ID
+	}
+	if currentID != fromID {
+		return domain.ErrTierChanged
+	}
+
+	standing.TierID = nil
+	if change.ToTier != nil {
+		toID := change.ToTier.ID
+		standing.TierID = &toID
+	}
+
+	m.nextID++
+	change.ID = fmt.Sprintf("change-%d", m.nextID)
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *MockTierRepository) ListChanges(ctx context.Context, userID string, limit, offset int) ([]*domain.TierChange, error) {
+	var changes []*domain.TierChange
+	for i := len(m.changes) - 1; i >= 0; i-- {
+		if m.changes[i].UserID == userID {
+			changes = append(changes, m.changes[i])
+		}
+	}
+	return changes, nil
+}
+
+func TestTierService_Recalculate(t *testing.T) {
+	repo := NewMockTierRepository()
+	service := NewTierService(repo, TierConfig{})
+	ctx := context.Background()
+
+	bronze, err := service.CreateTier(ctx, domain.TierSpec{Name: "Bronze"})
+	if err != nil {
+		t.Fatalf("CreateTier() unexpected error: %v", err)
+	}
+	silver, _ := service.CreateTier(ctx, domain.TierSpec{Name: "Silver", Threshold: 1000})
+	gold, _ := service.CreateTier(ctx, domain.TierSpec{Name: "Gold", Threshold: 5000})
+
+	if _, err := service.CreateTier(ctx, domain.TierSpec{Name: "Platinum", Threshold: 5000}); !errors.Is(err, domain.ErrTierAlreadyExists) {
+		t.Errorf("CreateTier() duplicate threshold expected %v, got %v", domain.ErrTierAlreadyExists, err)
+	}
+
+	repo.standings["user-1"] = &domain.TierStanding{UserID: "user-1", QualifyingPoints: 1200}
+	repo.standings["user-2"] = &domain.TierStanding{UserID: "user-2", TierID: &gold.ID, QualifyingPoints: 300}
+
+	change, err := service.RecalculateTier(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("RecalculateTier() unexpected error: %v", err)
+	}
+	if change == nil || change.FromTier != nil || change.ToTier.ID != silver.ID || change.Reason != domain.TierChangeActivity {
+		t.Errorf("RecalculateTier() = %+v, want a move into %s after activity", change, silver.Name)
+	}
+
+	if change, err := service.RecalculateTier(ctx, "user-1"); err != nil || change != nil {
+		t.Errorf("RecalculateTier() unchanged = %+v, %v; want no change", change, err)
+	}
+
+	changed, err := service.RecalculateAllTiers(ctx)
+	if err != nil {
+		t.Fatalf("RecalculateAllTiers() unexpected error: %v", err)
+	}
+	if changed != 1 || *repo.standings["user-2"].TierID != bronze.ID {
+		t.Errorf("RecalculateAllTiers() changed %d, user-2 tier %s; want 1, %s", changed, *repo.standings["user-2"].TierID, bronze.ID)
+	}
+
+	userCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "user-2", Role: domain.RoleUser})
+	history, err := service.ListTierHistory(userCtx, "user-2", 10, 0)
+	if err != nil {
+		t.Fatalf("ListTierHistory() unexpected error: %v", err)
+	}
+	if len(history) != 1 || history[0].FromTier.Name != gold.Name || history[0].ToTier.Name != bronze.Name || history[0].Reason != domain.TierChangeScheduled {
+		t.Errorf("ListTierHistory() = %+v, want one scheduled downgrade from %s to %s", history, gold.Name, bronze.Name)
+	}
+
+	if _, err := service.ListTierHistory(userCtx, "user-1", 10, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListTierHistory() for another user expected %v, got %v", domain.ErrForbidden, err)
+	}
+	if _, err := service.CreateTier(userCtx, domain.TierSpec{Name: "Diamond", Threshold: 10000}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("CreateTier() as user expected %v, got %v", domain.ErrForbidden, err)
+	}
+}
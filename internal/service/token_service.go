@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/token"
+)
+
+// refreshTokenSize is the number of random bytes in a refresh token
+const refreshTokenSize = 32
+
+// RefreshTokenRepository defines what the token service needs from the data layer
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+	RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error
+}
+
+// AccessTokenSigner signs and verifies access tokens
+type AccessTokenSigner interface {
+	Sign(claims token.Claims) (string, error)
+	Parse(tokenString string, now time.Time) (*token.Claims, error)
+}
+
+// TokenConfig holds token lifetime settings
+type TokenConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// TokenService issues, rotates and verifies authentication tokens
+type TokenService struct {
+	userRepo         UserRepository
+	refreshTokenRepo RefreshTokenRepository
+	signer           AccessTokenSigner
+	config           TokenConfig
+}
+
+// NewTokenService creates a new token service
+func NewTokenService(userRepo UserRepository, refreshTokenRepo RefreshTokenRepository, signer AccessTokenSigner, config TokenConfig) *TokenService {
+	if config.AccessTokenTTL <= 0 {
+		config.AccessTokenTTL = 15 * time.Minute // Default access token lifetime
+	}
+	if config.RefreshTokenTTL <= 0 {
+		config.RefreshTokenTTL = 30 * 24 * time.Hour // Default refresh token lifetime
+	}
+
+	return &TokenService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		signer:           signer,
+		config:           config,
+	}
+}
+
+// IssueTokens issues a new access and refresh token pair for the user
+func (s *TokenService) IssueTokens(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	if err := user.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	accessToken, err := s.signer.Sign(token.Claims{
+		Subject:   user.ID,
+		Role:      string(user.Role),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.config.AccessTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	refreshToken, err := token.GenerateOpaque(refreshTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	stored, err := domain.NewRefreshToken(user.ID, token.HashOpaque(refreshToken), now.Add(s.config.RefreshTokenTTL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, stored); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &domain.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  now.Add(s.config.AccessTokenTTL),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// RefreshTokens exchanges a refresh token for a new token pair and revokes the old one.
+// Presenting a token that was already rotated revokes every session of its owner.
+func (s *TokenService) RefreshTokens(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	now := time.Now()
+
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, token.HashOpaque(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if stored.IsRevoked() {
+		return nil, s.handleReuse(ctx, stored.UserID, now)
+	}
+
+	if stored.IsExpired(now) {
+		return nil, domain.ErrTokenExpired
+	}
+
+	if err := s.refreshTokenRepo.Revoke(ctx, stored.ID, now); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenRevoked) {
+			// Lost a race against a concurrent refresh with the same token
+			return nil, s.handleReuse(ctx, stored.UserID, now)
+		}
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for refresh: %w", err)
+	}
+
+	return s.IssueTokens(ctx, user)
+}
+
+// RevokeRefreshToken revokes a single refresh token (logout)
+func (s *TokenService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return domain.ErrInvalidToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, token.HashOpaque(refreshToken))
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if stored.IsRevoked() {
+		return nil
+	}
+
+	if err := s.refreshTokenRepo.Revoke(ctx, stored.ID, time.Now()); err != nil && !errors.Is(err, domain.ErrRefreshTokenRevoked) {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate verifies an access token and returns the principal it was issued to.
+// The user is reloaded so deactivated accounts are rejected before their token expires.
+func (s *TokenService) Authenticate(ctx context.Context, accessToken string) (*domain.Principal, error) {
+	claims, err := s.signer.Parse(accessToken, time.Now())
+	if err != nil {
+		if errors.Is(err, token.ErrExpiredToken) {
+			return nil, domain.ErrTokenExpired
+		}
+		return nil, domain.ErrInvalidToken
+	}
+
+	if claims.Subject == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get user for access token: %w", err)
+	}
+
+	if err := user.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		UserID: user.ID,
+		Role:   user.Role,
+	}, nil
+}
+
+// handleReuse revokes all sessions of a user whose refresh token was replayed
+func (s *TokenService) handleReuse(ctx context.Context, userID string, now time.Time) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID, now); err != nil {
+		return fmt.Errorf("failed to revoke sessions after refresh token reuse: %w", err)
+	}
+	return domain.ErrRefreshTokenReused
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/token"
+)
+
+// MockRefreshTokenRepository implements RefreshTokenRepository for testing
+type MockRefreshTokenRepository struct {
+	tokens map[string]*domain.RefreshToken
+	nextID int
+}
+
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{
+		tokens: make(map[string]*domain.RefreshToken),
+	}
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, t *domain.RefreshToken) error {
+	m.nextID++
+	t.ID = fmt.Sprintf("token-%d", m.nextID)
+	m.tokens[t.TokenHash] = t
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	t, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, domain.ErrInvalidToken
+	}
+	return t, nil
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	for _, t := range m.tokens {
+		if t.ID == id {
+			if t.RevokedAt != nil {
+				return domain.ErrRefreshTokenRevoked
+			}
+			t.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return domain.ErrInvalidToken
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.RevokedAt == nil {
+			t.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func newTestTokenService(userRepo *MockUserRepository, tokenRepo *MockRefreshTokenRepository) *TokenService {
+	return NewTokenService(userRepo, tokenRepo, token.NewSigner("test-secret", "test"), TokenConfig{})
+}
+
+func TestTokenService_IssueAndAuthenticate(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleAdmin, IsActive: true}
+	userRepo.users[user.ID] = user
+	service := newTestTokenService(userRepo, NewMockRefreshTokenRepository())
+
+	tokens, err := service.IssueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("IssueTokens() unexpected error: %v", err)
+	}
+
+	principal, err := service.Authenticate(context.Background(), tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() unexpected error: %v", err)
+	}
+
+	if principal.UserID != user.ID || principal.Role != domain.RoleAdmin {
+		t.Errorf("Authenticate() principal = %+v, want user %s with role %s", principal, user.ID, domain.RoleAdmin)
+	}
+
+	if _, err := service.Authenticate(context.Background(), tokens.AccessToken+"x"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Authenticate() tampered token expected error %v, got %v", domain.ErrInvalidToken, err)
+	}
+
+	// A refresh token is not an access token
+	if _, err := service.Authenticate(context.Background(), tokens.RefreshToken); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("Authenticate() refresh token expected error %v, got %v", domain.ErrInvalidToken, err)
+	}
+}
+
+func TestTokenService_RefreshTokens(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	userRepo.users[user.ID] = user
+
+	tokenRepo := NewMockRefreshTokenRepository()
+	service := newTestTokenService(userRepo, tokenRepo)
+
+	first, err := service.IssueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("IssueTokens() unexpected error: %v", err)
+	}
+
+	second, err := service.RefreshTokens(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokens() unexpected error: %v", err)
+	}
+
+	if second.RefreshToken == first.RefreshToken {
+		t.Errorf("RefreshTokens() should rotate the refresh token")
+	}
+
+	// Replaying the rotated token is treated as theft and revokes every session
+	if _, err := service.RefreshTokens(context.Background(), first.RefreshToken); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Errorf("RefreshTokens() reuse expected error %v, got %v", domain.ErrRefreshTokenReused, err)
+	}
+
+	if _, err := service.RefreshTokens(context.Background(), second.RefreshToken); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Errorf("RefreshTokens() after reuse expected error %v, got %v", domain.ErrRefreshTokenReused, err)
+	}
+
+	if _, err := service.RefreshTokens(context.Background(), "unknown"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("RefreshTokens() unknown token expected error %v, got %v", domain.ErrInvalidToken, err)
+	}
+}
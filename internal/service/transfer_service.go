@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// TransferRepository defines what the transfer service needs from the data layer
+type TransferRepository interface {
+	Create(ctx context.Context, transfer *domain.Transfer, entry *domain.JournalEntry, dailyLimit int64) error
+}
+
+// TransferConfig holds peer-to-peer transfer settings
+type TransferConfig struct {
+	MaxAmount  int64
+	DailyLimit int64
+	PointTypes domain.PointTypePolicies // which point types users can send
+}
+
+// TransferService moves points between users' wallets
+type TransferService struct {
+	transferRepo TransferRepository
+	userRepo     UserRepository
+	ledgerRepo   LedgerRepository
+	limits       domain.TransferLimits
+	pointTypes   domain.PointTypePolicies
+}
+
+// NewTransferService creates a new transfer service
+func NewTransferService(transferRepo TransferRepository, userRepo UserRepository, ledgerRepo LedgerRepository, config TransferConfig) *TransferService {
+	if config.MaxAmount <= 0 {
+		config.MaxAmount = 10000 // Default per-transfer limit
+	}
+	if config.DailyLimit <= 0 {
+		config.DailyLimit = 50000 // Default daily limit
+	}
+	if config.PointTypes == nil {
+		config.PointTypes = domain.DefaultPointTypePolicies()
+	}
+
+	return &TransferService{
+		transferRepo: transferRepo,
+		userRepo:     userRepo,
+		ledgerRepo:   ledgerRepo,
+		limits: domain.TransferLimits{
+			MaxAmount:  config.MaxAmount,
+			DailyLimit: config.DailyLimit,
+		},
+		pointTypes: config.PointTypes,
+	}
+}
+
+// CreateTransfer moves points of a transferable type from the sender's available balance to the user
+// registered with recipientEmail; the point type defaults to credits. The points keep the expiry they had in the sender's wallet.
+func (s *TransferService) CreateTransfer(ctx context.Context, senderID, recipientEmail string, pointType domain.PointType, amount int64, note string) (*domain.Transfer, error) {
+	if senderID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if recipientEmail == "" {
+		return nil, domain.ErrInvalidUserEmail
+	}
+
+	if err := authorizeUserAccess(ctx, senderID, domain.ScopeCreditsWrite); err != nil {
+		return nil, err
+	}
+
+	if pointType == "" {
+		pointType = domain.PointTypeCredits
+	}
+
+	policy, err := s.pointTypes.Policy(pointType)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Transferable {
+		return nil, domain.ErrPointTypeNotTransferable
+	}
+
+	sender, err := s.userRepo.GetByID(ctx, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender: %w", err)
+	}
+
+	recipient, err := s.userRepo.GetByEmail(ctx, recipientEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	transfer, err := domain.NewTransfer(sender, recipient, pointType, amount, note, s.limits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	from, err := s.ledgerRepo.GetUserAccount(ctx, sender.ID, pointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender ledger account: %w", err)
+	}
+
+	to, err := openWalletAccount(ctx, s.ledgerRepo, recipient.ID, pointType, domain.AccountUserAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient ledger account: %w", err)
+	}
+
+	entry, err := domain.NewTransferEntry(domain.EntryKindTransfer, pointType, sender.ID, from.ID, to.ID, transfer.Amount, "", "Transfer to "+recipient.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transfer entry: %w", err)
+	}
+
+	if err := s.transferRepo.Create(ctx, transfer, entry, s.limits.DailyLimit); err != nil {
+		return nil, fmt.Errorf("failed to transfer credits: %w", err)
+	}
+
+	return transfer, nil
+}
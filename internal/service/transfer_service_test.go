@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/azsharkawy5/SRBCS/internal/domain"
+)
+
+// MockTransferRepository implements TransferRepository for testing
+type MockTransferRepository struct {
+	transfers []*domain.Transfer
+	ledger    *MockLedgerRepository
+}
+
+func NewMockTransferRepository(ledger *MockLedgerRepository) *MockTransferRepository {
+	return &MockTransferRepository{
+		ledger: ledger,
+	}
+}
+
+func (m *MockTransferRepository) Create(ctx context.Context, transfer *domain.Transfer, entry *domain.JournalEntry, dailyLimit int64) error {
+	sent := int64(0)
+	for _, existing := range m.transfers {
+		if existing.SenderID == transfer.SenderID && existing.CreatedAt.After(transfer.DailyWindowStart()) {
+			sent += existing.Amount
+		}
+	}
+	if sent+transfer.Amount > dailyLimit {
+		return domain.ErrDailyTransferLimitExceeded
+	}
+
+	if err := m.ledger.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	transfer.ID = fmt.Sprintf("transfer-%d", len(m.transfers)+1)
+	transfer.EntryID = entry.ID
+	m.transfers = append(m.transfers, transfer)
+	return nil
+}
+
+func TestTransferService_CreateTransfer(t *testing.T) {
+	ledger := NewMockLedgerRepository()
+	users := NewMockUserRepository()
+	service := NewTransferService(NewMockTransferRepository(ledger), users, ledger, TransferConfig{MaxAmount: 100, DailyLimit: 150})
+	ctx := context.Background()
+
+	for _, user := range []*domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", IsActive: true, IsEmailVerified: true},
+		{ID: "bob", Name: "Bob", Email: "bob@example.com", IsActive: true, IsEmailVerified: true},
+		{ID: "carol", Name: "Carol", Email: "carol@example.com", IsActive: true},
+	} {
+		_ = users.Create(ctx, user)
+	}
+
+	if _, err := NewLedgerService(ledger, LedgerConfig{PointTypes: domain.PointTypePolicies{domain.PointTypeCredits: {Lifetime: time.Hour}}}).Credit(ctx, "alice", domain.PointTypeCredits, 300, domain.EntryKindEarn, "", ""); err != nil {
+		t.Fatalf("Credit() unexpected error: %v", err)
+	}
+	for _, userID := range []string{"bob", "carol"} {
+		account, _ := domain.NewUserAccount(userID, domain.PointTypeCredits, domain.AccountUserAvailable)
+		_ = ledger.OpenUserAccount(ctx, account)
+	}
+
+	aliceCtx := domain.ContextWithPrincipal(ctx, &domain.Principal{UserID: "alice", Role: domain.RoleUser})
+
+	transfer, err := service.CreateTransfer(aliceCtx, "alice", "bob@example.com", domain.PointTypeCredits, 80, "Happy birthday")
+	if err != nil {
+		t.Fatalf("CreateTransfer() unexpected error: %v", err)
+	}
+	if transfer.RecipientID != "bob" || transfer.EntryID == "" {
+		t.Errorf("CreateTransfer() = %+v, want posted transfer to bob", transfer)
+	}
+
+	alice, _ := ledger.GetUserAccount(ctx, "alice", domain.PointTypeCredits, domain.AccountUserAvailable)
+	bob, _ := ledger.GetUserAccount(ctx, "bob", domain.PointTypeCredits, domain.AccountUserAvailable)
+	if alice.Balance != 220 || bob.Balance != 80 {
+		t.Errorf("CreateTransfer() balances alice/bob = %d/%d, want 220/80", alice.Balance, bob.Balance)
+	}
+
+	// Received credits keep the expiry they had in the sender's wallet
+	received := ledger.lots[len(ledger.lots)-1]
+	if received.UserID != "bob" || received.ExpiresAt == nil || !received.ExpiresAt.Equal(*ledger.lots[0].ExpiresAt) {
+		t.Errorf("CreateTransfer() recipient lot = %+v, want bob's lot expiring with alice's", received)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		sender  string
+		email   string
+		amount  int64
+		errType error
+	}{
+		{name: "over per-transfer limit", ctx: aliceCtx, sender: "alice", email: "bob@example.com", amount: 101, errType: domain.ErrTransferLimitExceeded},
+		{name: "over daily limit", ctx: aliceCtx, sender: "alice", email: "bob@example.com", amount: 71, errType: domain.ErrDailyTransferLimitExceeded},
+		{name: "to self", ctx: aliceCtx, sender: "alice", email: "alice@example.com", amount: 10, errType: domain.ErrSelfTransfer},
+		{name: "unverified recipient", ctx: aliceCtx, sender: "alice", email: "carol@example.com", amount: 10, errType: domain.ErrTransferRecipientIneligible},
+		{name: "unknown recipient", ctx: aliceCtx, sender: "alice", email: "dave@example.com", amount: 10, errType: domain.ErrUserNotFound},
+		{name: "more than the sender has", ctx: ctx, sender: "bob", email: "alice@example.com", amount: 90, errType: domain.ErrInsufficientCredits},
+		{name: "on behalf of another user", ctx: aliceCtx, sender: "bob", email: "alice@example.com", amount: 10, errType: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CreateTransfer(tt.ctx, tt.sender, tt.email, domain.PointTypeCredits, tt.amount, ""); !errors.Is(err, tt.errType) {
+				t.Errorf("CreateTransfer() expected error %v, got %v", tt.errType, err)
+			}
+		})
+	}
+
+	if _, err := service.CreateTransfer(aliceCtx, "alice", "bob@example.com", domain.PointTypeTierPoints, 10, ""); !errors.Is(err, domain.ErrPointTypeNotTransferable) {
+		t.Errorf("CreateTransfer() of tier points expected %v, got %v", domain.ErrPointTypeNotTransferable, err)
+	}
+
+	// Within the daily limit the sender can keep gifting
+	if _, err := service.CreateTransfer(aliceCtx, "alice", "bob@example.com", domain.PointTypeCredits, 70, ""); err != nil {
+		t.Errorf("CreateTransfer() up to daily limit unexpected error: %v", err)
+	}
+}
@@ -2,36 +2,151 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/otp"
+	"github.com/azsharkawy5/SRBCS/pkg/token"
 )
 
+// emailRevertTokenSize is the number of random bytes in an email change revert token
+const emailRevertTokenSize = 32
+
+// referralCodeAttempts is how many referral codes are tried for a new user before giving up
+const referralCodeAttempts = 3
+
 // UserRepository defines what the user service needs from the data layer
 // Following the rule: "Interfaces belong to the consumer, not the provider"
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
+	CreateReferred(ctx context.Context, user *domain.User, maxPerEmailDomain int) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetByReferralCode(ctx context.Context, code string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	Deactivate(ctx context.Context, user *domain.User) error
+	Reactivate(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id string) error
+	ChangeRole(ctx context.Context, user *domain.User, change *domain.RoleChange) error
+	Restore(ctx context.Context, id string) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 	List(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	IncrementOTPAttempts(ctx context.Context, id string) (int, error)
+	SetOTP(ctx context.Context, user *domain.User) error
+	LockOTP(ctx context.Context, id string, until time.Time) error
+	ConsumeOTP(ctx context.Context, id, otpHash string) error
+}
+
+// SessionRevoker revokes every login session of a user
+type SessionRevoker interface {
+	RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error
+}
+
+// OTPSender delivers one-time passwords to users
+type OTPSender interface {
+	SendOTP(ctx context.Context, email, code string) error
+}
+
+// EmailChangeNotifier delivers email change confirmation codes and notices
+type EmailChangeNotifier interface {
+	SendEmailChangeCode(ctx context.Context, newEmail, code string) error
+	SendEmailChangeNotice(ctx context.Context, oldEmail, newEmail, revertToken string) error
+}
+
+// UserNotifier delivers every email sent by the user service
+type UserNotifier interface {
+	OTPSender
+	EmailChangeNotifier
+}
+
+// EmailChangeRepository defines what the user service needs to persist pending email changes
+type EmailChangeRepository interface {
+	Create(ctx context.Context, req *domain.EmailChangeRequest) error
+	GetPendingByUserID(ctx context.Context, userID string) (*domain.EmailChangeRequest, error)
+	GetByRevertTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChangeRequest, error)
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+	Cancel(ctx context.Context, id string, cancelledAt time.Time) error
+	Complete(ctx context.Context, req *domain.EmailChangeRequest, user *domain.User) error
+}
+
+// ReferralProgram links new users to their referrer and rewards both once the new user verified their email
+type ReferralProgram interface {
+	ReferUser(ctx context.Context, referee *domain.User, code string) error
+	MaxPerEmailDomain() int
+	RewardReferral(ctx context.Context, refereeID string) (*domain.Referral, error)
+}
+
+// OTPHasher hashes one-time passwords for storage and verifies codes against them
+type OTPHasher interface {
+	Hash(code string) (string, error)
+	Compare(hash, code string) bool
+}
+
+// OTPConfig holds one-time password settings
+type OTPConfig struct {
+	TTL             time.Duration
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	ResendCooldown  time.Duration
+}
+
+// EmailChangeConfig holds email change settings
+type EmailChangeConfig struct {
+	CodeTTL   time.Duration
+	RevertTTL time.Duration
 }
 
 // UserService provides business logic for user operations
 type UserService struct {
-	userRepo UserRepository
+	userRepo          UserRepository
+	sessions          SessionRevoker
+	emailChanges      EmailChangeRepository
+	referrals         ReferralProgram
+	notifier          UserNotifier
+	otpHasher         OTPHasher
+	otpConfig         OTPConfig
+	emailChangeConfig EmailChangeConfig
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo UserRepository) *UserService {
+func NewUserService(userRepo UserRepository, sessions SessionRevoker, emailChanges EmailChangeRepository, referrals ReferralProgram, notifier UserNotifier, otpHasher OTPHasher, otpConfig OTPConfig, emailChangeConfig EmailChangeConfig) *UserService {
+	if otpConfig.TTL <= 0 {
+		otpConfig.TTL = 10 * time.Minute // Default OTP lifetime
+	}
+	if otpConfig.MaxAttempts <= 0 {
+		otpConfig.MaxAttempts = 5 // Default failed attempts before lockout
+	}
+	if otpConfig.LockoutDuration <= 0 {
+		otpConfig.LockoutDuration = 15 * time.Minute // Default lockout window
+	}
+	if otpConfig.ResendCooldown < 0 {
+		otpConfig.ResendCooldown = 0
+	}
+	if emailChangeConfig.CodeTTL <= 0 {
+		emailChangeConfig.CodeTTL = 15 * time.Minute // Default confirmation code lifetime
+	}
+	if emailChangeConfig.RevertTTL < emailChangeConfig.CodeTTL {
+		emailChangeConfig.RevertTTL = 7 * 24 * time.Hour // Default revert link lifetime
+	}
+
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		sessions:          sessions,
+		emailChanges:      emailChanges,
+		referrals:         referrals,
+		notifier:          notifier,
+		otpHasher:         otpHasher,
+		otpConfig:         otpConfig,
+		emailChangeConfig: emailChangeConfig,
 	}
 }
 
-// CreateUser creates a new user with business validation
-func (s *UserService) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
+// CreateUser creates a new user with business validation and their own referral code;
+// an optional referral code links them to the user who shared it
+func (s *UserService) CreateUser(ctx context.Context, email, name, referralCode string) (*domain.User, error) {
 	// Check if user already exists by email
 	existingUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err == nil && existingUser != nil {
@@ -44,12 +159,34 @@ func (s *UserService) CreateUser(ctx context.Context, email, name string) (*doma
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Save to repository (repository will set the generated ID)
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to save user: %w", err)
+	if referralCode != "" {
+		if err := s.referrals.ReferUser(ctx, user, referralCode); err != nil {
+			return nil, fmt.Errorf("failed to apply referral code: %w", err)
+		}
 	}
 
-	return user, nil
+	// Random codes rarely collide, so a taken code is simply replaced by a new one
+	for attempt := 1; ; attempt++ {
+		user.ReferralCode, err = token.GenerateCode(domain.ReferralCodeLength)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+
+		// Save to repository (repository will set the generated ID)
+		if user.ReferredBy != nil {
+			err = s.userRepo.CreateReferred(ctx, user, s.referrals.MaxPerEmailDomain())
+		} else {
+			err = s.userRepo.Create(ctx, user)
+		}
+		if errors.Is(err, domain.ErrReferralCodeTaken) && attempt < referralCodeAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save user: %w", err)
+		}
+
+		return user, nil
+	}
 }
 
 // GetUserByID retrieves a user by ID
@@ -58,6 +195,10 @@ func (s *UserService) GetUserByID(ctx context.Context, id string) (*domain.User,
 		return nil, domain.ErrInvalidUserID
 	}
 
+	if err := authorizeUserAccess(ctx, id, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by ID %s: %w", id, err)
@@ -80,8 +221,12 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain
 	return user, nil
 }
 
-// UpdateUser updates an existing user
+// UpdateUser updates an existing user; a new email only takes effect once confirmed with ConfirmEmailChange
 func (s *UserService) UpdateUser(ctx context.Context, id string, email, name string) (*domain.User, error) {
+	if err := authorizeUserAccess(ctx, id, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+
 	// Get existing user
 	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -97,32 +242,205 @@ func (s *UserService) UpdateUser(ctx context.Context, id string, email, name str
 	}
 
 	// Update fields with domain validation
-	if email != "" && email != user.Email {
-		if err := user.UpdateEmail(email); err != nil {
-			return nil, fmt.Errorf("failed to update email: %w", err)
+	nameChanged := name != "" && name != user.Name
+	if nameChanged {
+		if err := user.UpdateName(name); err != nil {
+			return nil, fmt.Errorf("failed to update name: %w", err)
 		}
 	}
 
-	if name != "" && name != user.Name {
-		if err := user.UpdateName(name); err != nil {
-			return nil, fmt.Errorf("failed to update name: %w", err)
+	if email != "" && email != user.Email {
+		if err := s.requestEmailChange(ctx, user, email); err != nil {
+			return nil, err
 		}
 	}
 
 	// Save updated user
-	if err := s.userRepo.Update(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to save updated user: %w", err)
+	if nameChanged {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to save updated user: %w", err)
+		}
 	}
 
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID
+// requestEmailChange records a pending email change and asks the new address to confirm it
+func (s *UserService) requestEmailChange(ctx context.Context, user *domain.User, newEmail string) error {
+	if err := user.EnsureActive(); err != nil {
+		return err
+	}
+
+	code, err := otp.Generate(domain.OTPLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate email change code: %w", err)
+	}
+
+	codeHash, err := s.otpHasher.Hash(code)
+	if err != nil {
+		return fmt.Errorf("failed to hash email change code: %w", err)
+	}
+
+	revertToken, err := token.GenerateOpaque(emailRevertTokenSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate email revert token: %w", err)
+	}
+
+	now := time.Now()
+	req, err := domain.NewEmailChangeRequest(user.ID, user.Email, newEmail, codeHash, token.HashOpaque(revertToken),
+		now.Add(s.emailChangeConfig.CodeTTL), now.Add(s.emailChangeConfig.RevertTTL), now)
+	if err != nil {
+		return fmt.Errorf("failed to request email change: %w", err)
+	}
+
+	if err := s.emailChanges.Create(ctx, req); err != nil {
+		return fmt.Errorf("failed to save email change request: %w", err)
+	}
+
+	if err := s.notifier.SendEmailChangeCode(ctx, req.NewEmail, code); err != nil {
+		return fmt.Errorf("failed to send email change code: %w", err)
+	}
+
+	if err := s.notifier.SendEmailChangeNotice(ctx, req.OldEmail, req.NewEmail, revertToken); err != nil {
+		return fmt.Errorf("failed to send email change notice: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange checks the code sent to the new address and swaps the user's email
+func (s *UserService) ConfirmEmailChange(ctx context.Context, id, code string) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if code == "" {
+		return nil, domain.ErrInvalidEmailChangeCode
+	}
+
+	if err := authorizeUserAccess(ctx, id, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for email change: %w", err)
+	}
+
+	if err := user.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.emailChanges.GetPendingByUserID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	now := time.Now()
+	if err := req.EnsureConfirmable(now); err != nil {
+		return nil, fmt.Errorf("failed to confirm email change: %w", err)
+	}
+
+	// Count the attempt before checking the code so concurrent guesses cannot exceed the limit
+	attempts, err := s.emailChanges.IncrementAttempts(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record email change attempt: %w", err)
+	}
+
+	if attempts > s.otpConfig.MaxAttempts || !s.otpHasher.Compare(req.CodeHash, code) {
+		if attempts >= s.otpConfig.MaxAttempts {
+			if err := s.emailChanges.Cancel(ctx, req.ID, now); err != nil && !errors.Is(err, domain.ErrEmailChangeNotFound) {
+				return nil, fmt.Errorf("failed to cancel email change: %w", err)
+			}
+			return nil, fmt.Errorf("failed to confirm email change: %w", domain.ErrEmailChangeAttemptsExceeded)
+		}
+
+		return nil, fmt.Errorf("failed to confirm email change: %w", domain.ErrInvalidEmailChangeCode)
+	}
+
+	// The address may have been claimed by another account since the change was requested
+	existingUser, err := s.userRepo.GetByEmail(ctx, req.NewEmail)
+	if err == nil && existingUser != nil && existingUser.ID != id {
+		return nil, fmt.Errorf("email %s already in use: %w", req.NewEmail, domain.ErrUserAlreadyExists)
+	}
+
+	if err := user.ApplyEmailChange(req.NewEmail, now); err != nil {
+		return nil, fmt.Errorf("failed to change email: %w", err)
+	}
+	req.Confirm(now)
+
+	if err := s.emailChanges.Complete(ctx, req, user); err != nil {
+		return nil, fmt.Errorf("failed to save email change: %w", err)
+	}
+
+	return user, nil
+}
+
+// RevertEmailChange undoes an email change using the link sent to the old address.
+// A pending change is cancelled; a confirmed one restores the old email and revokes all sessions.
+func (s *UserService) RevertEmailChange(ctx context.Context, revertToken string) (*domain.User, error) {
+	if revertToken == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	req, err := s.emailChanges.GetByRevertTokenHash(ctx, token.HashOpaque(revertToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	now := time.Now()
+	if err := req.EnsureRevertable(now); err != nil {
+		return nil, fmt.Errorf("failed to revert email change: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for email revert: %w", err)
+	}
+
+	if req.IsPending() {
+		if err := s.emailChanges.Cancel(ctx, req.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to cancel email change: %w", err)
+		}
+		return user, nil
+	}
+
+	// Only the latest change can be reverted; a later change has its own revert link
+	if user.Email != req.NewEmail {
+		return nil, fmt.Errorf("failed to revert email change: %w", domain.ErrInvalidToken)
+	}
+
+	// The old address may have been taken by another account since it was released
+	if existingUser, err := s.userRepo.GetByEmail(ctx, req.OldEmail); err == nil && existingUser.ID != user.ID {
+		return nil, fmt.Errorf("email %s already in use: %w", req.OldEmail, domain.ErrUserAlreadyExists)
+	}
+
+	if err := user.ApplyEmailChange(req.OldEmail, now); err != nil {
+		return nil, fmt.Errorf("failed to restore email: %w", err)
+	}
+	req.Revert(now)
+
+	if err := s.emailChanges.Complete(ctx, req, user); err != nil {
+		return nil, fmt.Errorf("failed to save email revert: %w", err)
+	}
+
+	if err := s.sessions.RevokeAllForUser(ctx, user.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions after email revert: %w", err)
+	}
+
+	return user, nil
+}
+
+// DeleteUser soft-deletes a user by ID and revokes its sessions
 func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 	if id == "" {
 		return domain.ErrInvalidUserID
 	}
 
+	if err := authorizeAdmin(ctx, domain.ScopeUsersWrite); err != nil {
+		return err
+	}
+
 	// Check if user exists
 	_, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -134,11 +452,89 @@ func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	if err := s.sessions.RevokeAllForUser(ctx, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke sessions of deleted user: %w", err)
+	}
+
 	return nil
 }
 
+// RestoreUser restores a soft-deleted user that has not been purged yet
+func (s *UserService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restored user: %w", err)
+	}
+
+	return user, nil
+}
+
+// PurgeDeletedUsers permanently removes users that were soft-deleted longer than retention ago
+func (s *UserService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention < 0 {
+		return 0, domain.ErrInvalidInput
+	}
+
+	purged, err := s.userRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	return purged, nil
+}
+
+// ChangeUserRole promotes or demotes a user and records who made the change
+func (s *UserService) ChangeUserRole(ctx context.Context, id string, role domain.Role) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for role change: %w", err)
+	}
+
+	changedBy := ""
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		changedBy = principal.UserID
+	}
+
+	now := time.Now()
+	oldRole := user.Role
+	if err := user.ChangeRole(role, now); err != nil {
+		return nil, fmt.Errorf("failed to change role: %w", err)
+	}
+
+	change := domain.NewRoleChange(user.ID, oldRole, user.Role, changedBy, now)
+	if err := s.userRepo.ChangeRole(ctx, user, change); err != nil {
+		return nil, fmt.Errorf("failed to save role change: %w", err)
+	}
+
+	return user, nil
+}
+
 // ListUsers retrieves a paginated list of users as DTOs
 func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	if err := authorizeAdmin(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+
 	if limit <= 0 {
 		limit = 10 // Default limit
 	}
@@ -156,3 +552,175 @@ func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*doma
 
 	return userDTOs, nil
 }
+
+// RequestOTP issues a short-lived one-time password and sends it to the user's email
+func (s *UserService) RequestOTP(ctx context.Context, email string) error {
+	if email == "" {
+		return domain.ErrInvalidUserEmail
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to get user for OTP: %w", err)
+	}
+
+	if err := user.EnsureActive(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := user.CanRequestOTP(now, s.otpConfig.ResendCooldown); err != nil {
+		return err
+	}
+
+	code, err := otp.Generate(domain.OTPLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate OTP: %w", err)
+	}
+
+	otpHash, err := s.otpHasher.Hash(code)
+	if err != nil {
+		return fmt.Errorf("failed to hash OTP: %w", err)
+	}
+
+	if err := user.SetOTP(otpHash, now.Add(s.otpConfig.TTL), now); err != nil {
+		return fmt.Errorf("failed to set OTP: %w", err)
+	}
+
+	if err := s.userRepo.SetOTP(ctx, user); err != nil {
+		return fmt.Errorf("failed to save OTP: %w", err)
+	}
+
+	if err := s.notifier.SendOTP(ctx, user.Email, code); err != nil {
+		return fmt.Errorf("failed to send OTP: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyOTP checks a one-time password, clears it and marks the user's email as verified
+func (s *UserService) VerifyOTP(ctx context.Context, email, code string) (*domain.User, error) {
+	if email == "" {
+		return nil, domain.ErrInvalidUserEmail
+	}
+
+	if code == "" {
+		return nil, domain.ErrInvalidOTP
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for OTP verification: %w", err)
+	}
+
+	if err := user.EnsureActive(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := user.EnsureOTPUsable(now); err != nil {
+		return nil, fmt.Errorf("failed to verify OTP: %w", err)
+	}
+
+	// Count the attempt before checking the code so concurrent guesses cannot exceed the limit
+	attempts, err := s.userRepo.IncrementOTPAttempts(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record OTP attempt: %w", err)
+	}
+	user.OTPAttempts = attempts
+
+	// Failed attempts only ever write the lock: saving the user loaded before the attempt was
+	// counted could clear a lock set by a concurrent guess
+	if attempts > s.otpConfig.MaxAttempts || !s.otpHasher.Compare(*user.OTPHash, code) {
+		if attempts < s.otpConfig.MaxAttempts {
+			return nil, fmt.Errorf("failed to verify OTP: %w", domain.ErrInvalidOTP)
+		}
+
+		lockedUntil := now.Add(s.otpConfig.LockoutDuration)
+		if err := s.userRepo.LockOTP(ctx, user.ID, lockedUntil); err != nil {
+			return nil, fmt.Errorf("failed to lock OTP: %w", err)
+		}
+		user.LockOTP(lockedUntil)
+
+		return nil, fmt.Errorf("failed to verify OTP: %w", domain.ErrOTPLocked)
+	}
+
+	// Consumed only if the code is still the pending one, so concurrent requests cannot both use it
+	if err := s.userRepo.ConsumeOTP(ctx, user.ID, *user.OTPHash); err != nil {
+		return nil, fmt.Errorf("failed to verify OTP: %w", err)
+	}
+
+	user.ClearOTP()
+	user.MarkEmailVerified()
+
+	// The user is verified either way: a failed payout is retried at the next login and by the referral reward job
+	if user.ReferredBy != nil {
+		if _, err := s.referrals.RewardReferral(ctx, user.ID); err != nil {
+			log.Printf("Failed to reward referral of user %s: %v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// DeactivateUser disables a user account and revokes all of its sessions
+func (s *UserService) DeactivateUser(ctx context.Context, id, reason string) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for deactivation: %w", err)
+	}
+
+	deactivatedBy := ""
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		deactivatedBy = principal.UserID
+	}
+
+	now := time.Now()
+	if err := user.Deactivate(reason, deactivatedBy, now); err != nil {
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	if err := s.userRepo.Deactivate(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save deactivated user: %w", err)
+	}
+
+	if err := s.sessions.RevokeAllForUser(ctx, user.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions of deactivated user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ReactivateUser re-enables a deactivated user account
+func (s *UserService) ReactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if err := authorizeAdmin(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for reactivation: %w", err)
+	}
+
+	if err := user.Reactivate(time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	if err := s.userRepo.Reactivate(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save reactivated user: %w", err)
+	}
+
+	return user, nil
+}
@@ -3,24 +3,31 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/azsharkawy5/SRBCS/internal/domain"
+	"github.com/azsharkawy5/SRBCS/pkg/otp"
 )
 
 // MockUserRepository implements UserRepository for testing
 type MockUserRepository struct {
-	users    map[string]*domain.User
-	emails   map[string]*domain.User
-	createFn func(ctx context.Context, user *domain.User) error
-	getFn    func(ctx context.Context, id string) (*domain.User, error)
+	users        map[string]*domain.User
+	emails       map[string]*domain.User
+	deleted      map[string]time.Time
+	roleChanges  []*domain.RoleChange
+	createFn     func(ctx context.Context, user *domain.User) error
+	getFn        func(ctx context.Context, id string) (*domain.User, error)
+	getByEmailFn func(ctx context.Context, email string) (*domain.User, error)
+	incrementFn  func(ctx context.Context, id string) (int, error)
 }
 
 func NewMockUserRepository() *MockUserRepository {
 	return &MockUserRepository{
-		users:  make(map[string]*domain.User),
-		emails: make(map[string]*domain.User),
+		users:   make(map[string]*domain.User),
+		emails:  make(map[string]*domain.User),
+		deleted: make(map[string]time.Time),
 	}
 }
 
@@ -39,6 +46,20 @@ func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) CreateReferred(ctx context.Context, user *domain.User, maxPerEmailDomain int) error {
+	count := 0
+	for _, other := range m.users {
+		if other.ReferredBy != nil && *other.ReferredBy == *user.ReferredBy && domain.EmailDomain(other.Email) == domain.EmailDomain(user.Email) {
+			count++
+		}
+	}
+	if count >= maxPerEmailDomain {
+		return domain.ErrReferralLimitReached
+	}
+
+	return m.Create(ctx, user)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	if m.getFn != nil {
 		return m.getFn(ctx, id)
@@ -48,41 +69,198 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.Us
 	if !exists {
 		return nil, domain.ErrUserNotFound
 	}
+	if _, deleted := m.deleted[id]; deleted {
+		return nil, domain.ErrUserNotFound
+	}
 	return user, nil
 }
 
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if m.getByEmailFn != nil {
+		return m.getByEmailFn(ctx, email)
+	}
+
 	user, exists := m.emails[email]
 	if !exists {
 		return nil, domain.ErrUserNotFound
 	}
+	if _, deleted := m.deleted[user.ID]; deleted {
+		return nil, domain.ErrUserNotFound
+	}
 	return user, nil
 }
 
+func (m *MockUserRepository) GetByReferralCode(ctx context.Context, code string) (*domain.User, error) {
+	for id, user := range m.users {
+		if _, deleted := m.deleted[id]; !deleted && user.ReferralCode == code {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	if _, exists := m.users[user.ID]; !exists {
 		return domain.ErrUserNotFound
 	}
 
 	// Update email mapping if email changed
-	oldUser := m.users[user.ID]
-	if oldUser.Email != user.Email {
-		delete(m.emails, oldUser.Email)
-		m.emails[user.Email] = user
+	stored := m.users[user.ID]
+	if stored.Email != user.Email {
+		delete(m.emails, stored.Email)
+		m.emails[user.Email] = stored
 	}
 
-	m.users[user.ID] = user
+	// Like the repository, only the profile is saved
+	stored.Email = user.Email
+	stored.Name = user.Name
+	stored.IsEmailVerified = user.IsEmailVerified
+	stored.UpdatedAt = user.UpdatedAt
 	return nil
 }
 
 func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.users[id]; !exists {
+		return domain.ErrUserNotFound
+	}
+	if _, deleted := m.deleted[id]; deleted {
+		return domain.ErrUserNotFound
+	}
+
+	if user := m.users[id]; user.Role == domain.RoleAdmin && user.IsActive && m.otherActiveAdmins(id) == 0 {
+		return domain.ErrLastAdmin
+	}
+
+	m.deleted[id] = time.Now()
+	return nil
+}
+
+func (m *MockUserRepository) Deactivate(ctx context.Context, user *domain.User) error {
+	if user.Role == domain.RoleAdmin && m.otherActiveAdmins(user.ID) == 0 {
+		// Stored users are shared with callers, so undo the in-memory change like a rolled back transaction
+		user.IsActive = true
+		user.DeactivatedAt = nil
+		user.DeactivatedBy = nil
+		user.DeactivationReason = nil
+		return domain.ErrLastAdmin
+	}
+
+	return m.saveActivation(user)
+}
+
+func (m *MockUserRepository) Reactivate(ctx context.Context, user *domain.User) error {
+	return m.saveActivation(user)
+}
+
+// saveActivation stores the activation state of a user, like the repository's dedicated statements
+func (m *MockUserRepository) saveActivation(user *domain.User) error {
+	stored, exists := m.users[user.ID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	stored.IsActive = user.IsActive
+	stored.DeactivatedAt = user.DeactivatedAt
+	stored.DeactivatedBy = user.DeactivatedBy
+	stored.DeactivationReason = user.DeactivationReason
+	stored.UpdatedAt = user.UpdatedAt
+	return nil
+}
+
+// otherActiveAdmins counts the active, non-deleted admins other than the given user
+func (m *MockUserRepository) otherActiveAdmins(excludeID string) int {
+	count := 0
+	for id, u := range m.users {
+		if _, deleted := m.deleted[id]; !deleted && id != excludeID && u.Role == domain.RoleAdmin && u.IsActive {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *MockUserRepository) ChangeRole(ctx context.Context, user *domain.User, change *domain.RoleChange) error {
+	if change.OldRole == domain.RoleAdmin {
+		if m.otherActiveAdmins(user.ID) == 0 {
+			// Stored users are shared with callers, so undo the in-memory change like a rolled back transaction
+			user.Role = change.OldRole
+			return domain.ErrLastAdmin
+		}
+	}
+
+	m.users[user.ID] = user
+	m.roleChanges = append(m.roleChanges, change)
+	return nil
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id string) error {
+	if _, deleted := m.deleted[id]; !deleted {
+		return domain.ErrUserNotFound
+	}
+
+	delete(m.deleted, id)
+	return nil
+}
+
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	for id, deletedAt := range m.deleted {
+		if deletedAt.Before(deletedBefore) {
+			delete(m.emails, m.users[id].Email)
+			delete(m.users, id)
+			delete(m.deleted, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *MockUserRepository) IncrementOTPAttempts(ctx context.Context, id string) (int, error) {
+	if m.incrementFn != nil {
+		return m.incrementFn(ctx, id)
+	}
+
 	user, exists := m.users[id]
+	if !exists {
+		return 0, domain.ErrUserNotFound
+	}
+	user.OTPAttempts++
+	return user.OTPAttempts, nil
+}
+
+func (m *MockUserRepository) SetOTP(ctx context.Context, user *domain.User) error {
+	stored, exists := m.users[user.ID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	if stored.IsOTPLocked(*user.OTPLastSentAt) {
+		return domain.ErrOTPLocked
+	}
+
+	stored.OTPHash = user.OTPHash
+	stored.OTPExpiresAt = user.OTPExpiresAt
+	stored.OTPAttempts = 0
+	stored.OTPLastSentAt = user.OTPLastSentAt
+	return nil
+}
+
+func (m *MockUserRepository) LockOTP(ctx context.Context, id string, until time.Time) error {
+	stored, exists := m.users[id]
 	if !exists {
 		return domain.ErrUserNotFound
 	}
 
-	delete(m.users, id)
-	delete(m.emails, user.Email)
+	stored.LockOTP(until)
+	return nil
+}
+
+func (m *MockUserRepository) ConsumeOTP(ctx context.Context, id, otpHash string) error {
+	stored, exists := m.users[id]
+	if !exists || stored.OTPHash == nil || *stored.OTPHash != otpHash {
+		return domain.ErrInvalidOTP
+	}
+
+	stored.ClearOTP()
+	stored.MarkEmailVerified()
 	return nil
 }
 
@@ -106,6 +284,100 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*do
 	return users[start:end], nil
 }
 
+// MockNotifier records the codes and revert tokens sent by the service
+type MockNotifier struct {
+	sent    map[string]string
+	notices map[string]string
+}
+
+func (m *MockNotifier) SendOTP(ctx context.Context, email, code string) error {
+	if m.sent == nil {
+		m.sent = make(map[string]string)
+	}
+	m.sent[email] = code
+	return nil
+}
+
+func (m *MockNotifier) SendEmailChangeCode(ctx context.Context, newEmail, code string) error {
+	return m.SendOTP(ctx, newEmail, code)
+}
+
+func (m *MockNotifier) SendEmailChangeNotice(ctx context.Context, oldEmail, newEmail, revertToken string) error {
+	if m.notices == nil {
+		m.notices = make(map[string]string)
+	}
+	m.notices[oldEmail] = revertToken
+	return nil
+}
+
+// MockEmailChangeRepository implements EmailChangeRepository for testing
+type MockEmailChangeRepository struct {
+	users    *MockUserRepository
+	requests []*domain.EmailChangeRequest
+}
+
+func NewMockEmailChangeRepository(users *MockUserRepository) *MockEmailChangeRepository {
+	return &MockEmailChangeRepository{users: users}
+}
+
+func (m *MockEmailChangeRepository) Create(ctx context.Context, req *domain.EmailChangeRequest) error {
+	for _, existing := range m.requests {
+		if existing.UserID == req.UserID && existing.IsPending() {
+			existing.Cancel(req.CreatedAt)
+		}
+	}
+
+	req.ID = fmt.Sprintf("email-change-%d", len(m.requests)+1)
+	m.requests = append(m.requests, req)
+	return nil
+}
+
+func (m *MockEmailChangeRepository) GetPendingByUserID(ctx context.Context, userID string) (*domain.EmailChangeRequest, error) {
+	for _, req := range m.requests {
+		if req.UserID == userID && req.IsPending() {
+			return req, nil
+		}
+	}
+	return nil, domain.ErrEmailChangeNotFound
+}
+
+func (m *MockEmailChangeRepository) GetByRevertTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChangeRequest, error) {
+	for _, req := range m.requests {
+		if req.RevertTokenHash == tokenHash {
+			return req, nil
+		}
+	}
+	return nil, domain.ErrInvalidToken
+}
+
+func (m *MockEmailChangeRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	for _, req := range m.requests {
+		if req.ID == id {
+			req.Attempts++
+			return req.Attempts, nil
+		}
+	}
+	return 0, domain.ErrEmailChangeNotFound
+}
+
+func (m *MockEmailChangeRepository) Cancel(ctx context.Context, id string, cancelledAt time.Time) error {
+	for _, req := range m.requests {
+		if req.ID == id && req.IsPending() {
+			req.Cancel(cancelledAt)
+			return nil
+		}
+	}
+	return domain.ErrEmailChangeNotFound
+}
+
+func (m *MockEmailChangeRepository) Complete(ctx context.Context, req *domain.EmailChangeRequest, user *domain.User) error {
+	return m.users.Update(ctx, user)
+}
+
+func newTestUserService(repo *MockUserRepository, sender *MockNotifier, otpConfig OTPConfig) *UserService {
+	return NewUserService(repo, NewMockRefreshTokenRepository(), NewMockEmailChangeRepository(repo), NewTestReferralService(repo), sender, otp.NewHasher("test-secret"), otpConfig, EmailChangeConfig{})
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -166,9 +438,9 @@ func TestUserService_CreateUser(t *testing.T) {
 			mockRepo := NewMockUserRepository()
 			tt.mockFn(mockRepo)
 
-			service := NewUserService(mockRepo)
+			service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
 
-			user, err := service.CreateUser(context.Background(), tt.email, tt.userName)
+			user, err := service.CreateUser(context.Background(), tt.email, tt.userName, "")
 
 			if tt.wantErr {
 				if err == nil {
@@ -250,7 +522,7 @@ func TestUserService_GetUserByID(t *testing.T) {
 			mockRepo := NewMockUserRepository()
 			tt.mockFn(mockRepo)
 
-			service := NewUserService(mockRepo)
+			service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
 
 			user, err := service.GetUserByID(context.Background(), tt.userID)
 
@@ -284,25 +556,28 @@ func TestUserService_GetUserByID(t *testing.T) {
 
 func TestUserService_UpdateUser(t *testing.T) {
 	tests := []struct {
-		name     string
-		userID   string
-		newEmail string
-		newName  string
-		mockFn   func(*MockUserRepository) time.Time // Return original UpdatedAt
-		wantErr  bool
-		errType  error
+		name      string
+		userID    string
+		newEmail  string
+		newName   string
+		wantEmail string                              // email is only swapped once the change is confirmed
+		mockFn    func(*MockUserRepository) time.Time // Return original UpdatedAt
+		wantErr   bool
+		errType   error
 	}{
 		{
-			name:     "successful update email and name",
-			userID:   "user-1",
-			newEmail: "new@example.com",
-			newName:  "New Name",
+			name:      "successful update email and name",
+			userID:    "user-1",
+			newEmail:  "new@example.com",
+			newName:   "New Name",
+			wantEmail: "old@example.com",
 			mockFn: func(m *MockUserRepository) time.Time {
 				originalTime := time.Now().Add(-time.Hour)
 				existingUser := &domain.User{
 					ID:        "user-1",
 					Email:     "old@example.com",
 					Name:      "Old Name",
+					IsActive:  true,
 					CreatedAt: originalTime,
 					UpdatedAt: originalTime,
 				}
@@ -313,16 +588,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:     "update only name",
-			userID:   "user-1",
-			newEmail: "",
-			newName:  "Updated Name",
+			name:      "update only name",
+			userID:    "user-1",
+			newEmail:  "",
+			newName:   "Updated Name",
+			wantEmail: "old@example.com",
 			mockFn: func(m *MockUserRepository) time.Time {
 				originalTime := time.Now().Add(-time.Hour)
 				existingUser := &domain.User{
 					ID:        "user-1",
 					Email:     "old@example.com",
 					Name:      "Old Name",
+					IsActive:  true,
 					CreatedAt: originalTime,
 					UpdatedAt: originalTime,
 				}
@@ -348,7 +625,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 			mockRepo := NewMockUserRepository()
 			originalUpdatedAt := tt.mockFn(mockRepo)
 
-			service := NewUserService(mockRepo)
+			service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
 
 			user, err := service.UpdateUser(context.Background(), tt.userID, tt.newEmail, tt.newName)
 
@@ -374,8 +651,8 @@ func TestUserService_UpdateUser(t *testing.T) {
 			}
 
 			// Check updated fields
-			if tt.newEmail != "" && user.Email != tt.newEmail {
-				t.Errorf("UpdateUser() Email = %v, want %v", user.Email, tt.newEmail)
+			if user.Email != tt.wantEmail {
+				t.Errorf("UpdateUser() Email = %v, want %v", user.Email, tt.wantEmail)
 			}
 
 			if tt.newName != "" && user.Name != tt.newName {
@@ -389,3 +666,594 @@ func TestUserService_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_RequestAndVerifyOTP(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	existingUser := &domain.User{
+		ID:       "user-1",
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Role:     domain.RoleUser,
+		IsActive: true,
+	}
+	mockRepo.users["user-1"] = existingUser
+	mockRepo.emails["test@example.com"] = existingUser
+
+	sender := &MockNotifier{}
+	service := newTestUserService(mockRepo, sender, OTPConfig{TTL: time.Minute})
+
+	if err := service.RequestOTP(context.Background(), "missing@example.com"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("RequestOTP() expected error %v, got %v", domain.ErrUserNotFound, err)
+	}
+
+	if err := service.RequestOTP(context.Background(), "test@example.com"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+
+	code, ok := sender.sent["test@example.com"]
+	if !ok || len(code) != domain.OTPLength {
+		t.Fatalf("RequestOTP() sent code = %q, want %d digits", code, domain.OTPLength)
+	}
+
+	if existingUser.OTPHash == nil || *existingUser.OTPHash == code {
+		t.Fatalf("RequestOTP() should store a hash of the code, not the code itself")
+	}
+
+	if _, err := service.VerifyOTP(context.Background(), "test@example.com", "xxxxxx"); !errors.Is(err, domain.ErrInvalidOTP) {
+		t.Errorf("VerifyOTP() expected error %v, got %v", domain.ErrInvalidOTP, err)
+	}
+
+	user, err := service.VerifyOTP(context.Background(), "test@example.com", code)
+	if err != nil {
+		t.Fatalf("VerifyOTP() unexpected error: %v", err)
+	}
+
+	if !user.IsEmailVerified {
+		t.Errorf("VerifyOTP() IsEmailVerified = false, want true")
+	}
+
+	if user.OTPHash != nil || user.OTPExpiresAt != nil {
+		t.Errorf("VerifyOTP() OTP should be cleared after use")
+	}
+
+	// A used code cannot be replayed
+	if _, err := service.VerifyOTP(context.Background(), "test@example.com", code); !errors.Is(err, domain.ErrInvalidOTP) {
+		t.Errorf("VerifyOTP() replay expected error %v, got %v", domain.ErrInvalidOTP, err)
+	}
+}
+
+func TestUserService_Authorization(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	for _, id := range []string{"user-1", "user-2"} {
+		user := &domain.User{ID: id, Email: id + "@example.com", Name: "User", Role: domain.RoleUser}
+		mockRepo.users[id] = user
+		mockRepo.emails[user.Email] = user
+	}
+
+	service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
+
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	if _, err := service.GetUserByID(userCtx, "user-1"); err != nil {
+		t.Errorf("GetUserByID() own record unexpected error: %v", err)
+	}
+
+	if _, err := service.GetUserByID(userCtx, "user-2"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("GetUserByID() other record expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.UpdateUser(userCtx, "user-2", "", "Hacked"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("UpdateUser() other record expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.ListUsers(userCtx, 10, 0); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListUsers() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if err := service.DeleteUser(userCtx, "user-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("DeleteUser() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.UpdateUser(adminCtx, "user-2", "", "Renamed"); err != nil {
+		t.Errorf("UpdateUser() as admin unexpected error: %v", err)
+	}
+
+	if err := service.DeleteUser(adminCtx, "user-2"); err != nil {
+		t.Errorf("DeleteUser() as admin unexpected error: %v", err)
+	}
+}
+
+func TestUserService_VerifyOTPLockout(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	existingUser := &domain.User{
+		ID:       "user-1",
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Role:     domain.RoleUser,
+		IsActive: true,
+	}
+	mockRepo.users["user-1"] = existingUser
+	mockRepo.emails["test@example.com"] = existingUser
+
+	sender := &MockNotifier{}
+	service := newTestUserService(mockRepo, sender, OTPConfig{MaxAttempts: 3, ResendCooldown: time.Hour})
+
+	if err := service.RequestOTP(context.Background(), "test@example.com"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+	code := sender.sent["test@example.com"]
+
+	if err := service.RequestOTP(context.Background(), "test@example.com"); !errors.Is(err, domain.ErrOTPResendCooldown) {
+		t.Errorf("RequestOTP() within cooldown expected error %v, got %v", domain.ErrOTPResendCooldown, err)
+	}
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 1; i < 3; i++ {
+		if _, err := service.VerifyOTP(context.Background(), "test@example.com", wrong); !errors.Is(err, domain.ErrInvalidOTP) {
+			t.Errorf("VerifyOTP() attempt %d expected error %v, got %v", i, domain.ErrInvalidOTP, err)
+		}
+	}
+
+	if _, err := service.VerifyOTP(context.Background(), "test@example.com", wrong); !errors.Is(err, domain.ErrOTPLocked) {
+		t.Errorf("VerifyOTP() last attempt expected error %v, got %v", domain.ErrOTPLocked, err)
+	}
+
+	// The correct code no longer works once locked out
+	if _, err := service.VerifyOTP(context.Background(), "test@example.com", code); !errors.Is(err, domain.ErrOTPLocked) {
+		t.Errorf("VerifyOTP() while locked expected error %v, got %v", domain.ErrOTPLocked, err)
+	}
+}
+
+func TestUserService_VerifyOTPConcurrentAttempts(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	existingUser := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test User", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users["user-1"] = existingUser
+	mockRepo.emails["test@example.com"] = existingUser
+
+	sender := &MockNotifier{}
+	service := newTestUserService(mockRepo, sender, OTPConfig{MaxAttempts: 3})
+	ctx := context.Background()
+
+	if err := service.RequestOTP(ctx, "test@example.com"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+	code := sender.sent["test@example.com"]
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	// A guess that loaded the user and had its attempt counted before the others locked the code out
+	stale := *existingUser
+	for i := 0; i < 3; i++ {
+		_, _ = service.VerifyOTP(ctx, "test@example.com", wrong)
+	}
+	if !existingUser.IsOTPLocked(time.Now()) {
+		t.Fatal("VerifyOTP() did not lock after the maximum attempts")
+	}
+
+	mockRepo.getByEmailFn = func(ctx context.Context, email string) (*domain.User, error) {
+		copied := stale
+		return &copied, nil
+	}
+	mockRepo.incrementFn = func(ctx context.Context, id string) (int, error) {
+		return 1, nil
+	}
+
+	if _, err := service.VerifyOTP(ctx, "test@example.com", wrong); !errors.Is(err, domain.ErrInvalidOTP) {
+		t.Errorf("VerifyOTP() stale attempt expected error %v, got %v", domain.ErrInvalidOTP, err)
+	}
+
+	if stored := mockRepo.users["user-1"]; !stored.IsOTPLocked(time.Now()) || stored.OTPHash != nil || stored.OTPAttempts != 3 {
+		t.Errorf("VerifyOTP() stale attempt left locked = %v, hash = %v, attempts = %d, want the lockout kept",
+			stored.IsOTPLocked(time.Now()), stored.OTPHash, stored.OTPAttempts)
+	}
+
+	// Two requests with the right code: only the first one may use it
+	other := &domain.User{ID: "user-2", Email: "other@example.com", Name: "Other", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[other.ID] = other
+	mockRepo.emails[other.Email] = other
+	mockRepo.getByEmailFn = nil
+	mockRepo.incrementFn = nil
+
+	if err := service.RequestOTP(ctx, "other@example.com"); err != nil {
+		t.Fatalf("RequestOTP() unexpected error: %v", err)
+	}
+	stale = *other
+
+	if _, err := service.VerifyOTP(ctx, "other@example.com", sender.sent["other@example.com"]); err != nil {
+		t.Fatalf("VerifyOTP() unexpected error: %v", err)
+	}
+
+	mockRepo.getByEmailFn = func(ctx context.Context, email string) (*domain.User, error) {
+		copied := stale
+		return &copied, nil
+	}
+	if _, err := service.VerifyOTP(ctx, "other@example.com", sender.sent["other@example.com"]); !errors.Is(err, domain.ErrInvalidOTP) {
+		t.Errorf("VerifyOTP() reusing a consumed code expected error %v, got %v", domain.ErrInvalidOTP, err)
+	}
+}
+
+func TestUserService_DeactivateAndReactivate(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[user.ID] = user
+	mockRepo.emails[user.Email] = user
+
+	tokenRepo := NewMockRefreshTokenRepository()
+	tokenService := newTestTokenService(mockRepo, tokenRepo)
+	service := NewUserService(mockRepo, tokenRepo, NewMockEmailChangeRepository(mockRepo), NewTestReferralService(mockRepo), &MockNotifier{}, otp.NewHasher("test-secret"), OTPConfig{}, EmailChangeConfig{})
+
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	tokens, err := tokenService.IssueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("IssueTokens() unexpected error: %v", err)
+	}
+
+	if _, err := service.DeactivateUser(userCtx, "user-1", "self"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("DeactivateUser() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.DeactivateUser(adminCtx, "user-1", ""); !errors.Is(err, domain.ErrInvalidDeactivationReason) {
+		t.Errorf("DeactivateUser() without reason expected error %v, got %v", domain.ErrInvalidDeactivationReason, err)
+	}
+
+	deactivated, err := service.DeactivateUser(adminCtx, "user-1", "chargeback fraud")
+	if err != nil {
+		t.Fatalf("DeactivateUser() unexpected error: %v", err)
+	}
+
+	if deactivated.IsActive || deactivated.DeactivationReason == nil || *deactivated.DeactivationReason != "chargeback fraud" {
+		t.Errorf("DeactivateUser() should record the reason and disable the account")
+	}
+
+	if deactivated.DeactivatedBy == nil || *deactivated.DeactivatedBy != "admin-1" {
+		t.Errorf("DeactivateUser() should record the admin who deactivated the account")
+	}
+
+	if _, err := service.DeactivateUser(adminCtx, "user-1", "again"); !errors.Is(err, domain.ErrUserAlreadyInactive) {
+		t.Errorf("DeactivateUser() twice expected error %v, got %v", domain.ErrUserAlreadyInactive, err)
+	}
+
+	// Every authenticated flow rejects the deactivated account
+	if _, err := tokenService.Authenticate(context.Background(), tokens.AccessToken); !errors.Is(err, domain.ErrUserInactive) {
+		t.Errorf("Authenticate() expected error %v, got %v", domain.ErrUserInactive, err)
+	}
+
+	if _, err := tokenService.RefreshTokens(context.Background(), tokens.RefreshToken); err == nil {
+		t.Errorf("RefreshTokens() expected revoked session to be rejected")
+	}
+
+	if err := service.RequestOTP(context.Background(), "test@example.com"); !errors.Is(err, domain.ErrUserInactive) {
+		t.Errorf("RequestOTP() expected error %v, got %v", domain.ErrUserInactive, err)
+	}
+
+	reactivated, err := service.ReactivateUser(adminCtx, "user-1")
+	if err != nil {
+		t.Fatalf("ReactivateUser() unexpected error: %v", err)
+	}
+
+	if !reactivated.IsActive || reactivated.DeactivationReason != nil {
+		t.Errorf("ReactivateUser() should enable the account and clear deactivation details")
+	}
+
+	if _, err := tokenService.Authenticate(context.Background(), tokens.AccessToken); err != nil {
+		t.Errorf("Authenticate() after reactivation unexpected error: %v", err)
+	}
+}
+
+func TestUserService_DeleteRestoreAndPurge(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[user.ID] = user
+	mockRepo.emails[user.Email] = user
+
+	tokenRepo := NewMockRefreshTokenRepository()
+	tokenService := newTestTokenService(mockRepo, tokenRepo)
+	service := NewUserService(mockRepo, tokenRepo, NewMockEmailChangeRepository(mockRepo), NewTestReferralService(mockRepo), &MockNotifier{}, otp.NewHasher("test-secret"), OTPConfig{}, EmailChangeConfig{})
+
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	tokens, err := tokenService.IssueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("IssueTokens() unexpected error: %v", err)
+	}
+
+	if err := service.DeleteUser(adminCtx, "user-1"); err != nil {
+		t.Fatalf("DeleteUser() unexpected error: %v", err)
+	}
+
+	if _, err := service.GetUserByID(adminCtx, "user-1"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetUserByID() after delete expected error %v, got %v", domain.ErrUserNotFound, err)
+	}
+
+	if _, err := tokenService.RefreshTokens(context.Background(), tokens.RefreshToken); err == nil {
+		t.Errorf("RefreshTokens() expected sessions of deleted user to be revoked")
+	}
+
+	if _, err := service.RestoreUser(userCtx, "user-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("RestoreUser() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	restored, err := service.RestoreUser(adminCtx, "user-1")
+	if err != nil {
+		t.Fatalf("RestoreUser() unexpected error: %v", err)
+	}
+
+	if restored.ID != "user-1" {
+		t.Errorf("RestoreUser() returned user %s, want user-1", restored.ID)
+	}
+
+	if _, err := service.RestoreUser(adminCtx, "user-1"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("RestoreUser() of active user expected error %v, got %v", domain.ErrUserNotFound, err)
+	}
+
+	// Only users deleted longer than the retention period are purged
+	if err := service.DeleteUser(adminCtx, "user-1"); err != nil {
+		t.Fatalf("DeleteUser() unexpected error: %v", err)
+	}
+
+	purged, err := service.PurgeDeletedUsers(context.Background(), time.Hour)
+	if err != nil || purged != 0 {
+		t.Errorf("PurgeDeletedUsers() within retention = %d, %v; want 0, nil", purged, err)
+	}
+
+	mockRepo.deleted["user-1"] = time.Now().Add(-2 * time.Hour)
+
+	purged, err = service.PurgeDeletedUsers(context.Background(), time.Hour)
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeDeletedUsers() past retention = %d, %v; want 1, nil", purged, err)
+	}
+
+	if _, err := service.RestoreUser(adminCtx, "user-1"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("RestoreUser() of purged user expected error %v, got %v", domain.ErrUserNotFound, err)
+	}
+}
+
+func TestUserService_EmailChange(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "old@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	other := &domain.User{ID: "user-2", Email: "taken@example.com", Name: "Other", Role: domain.RoleUser, IsActive: true}
+	for _, u := range []*domain.User{user, other} {
+		mockRepo.users[u.ID] = u
+		mockRepo.emails[u.Email] = u
+	}
+
+	notifier := &MockNotifier{}
+	tokenRepo := NewMockRefreshTokenRepository()
+	tokenService := newTestTokenService(mockRepo, tokenRepo)
+	service := NewUserService(mockRepo, tokenRepo, NewMockEmailChangeRepository(mockRepo), NewTestReferralService(mockRepo), notifier, otp.NewHasher("test-secret"), OTPConfig{MaxAttempts: 3}, EmailChangeConfig{})
+
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	if _, err := service.UpdateUser(userCtx, "user-1", "taken@example.com", ""); !errors.Is(err, domain.ErrUserAlreadyExists) {
+		t.Errorf("UpdateUser() to taken email expected error %v, got %v", domain.ErrUserAlreadyExists, err)
+	}
+
+	if _, err := service.UpdateUser(userCtx, "user-1", "new@example.com", ""); err != nil {
+		t.Fatalf("UpdateUser() unexpected error: %v", err)
+	}
+
+	code := notifier.sent["new@example.com"]
+	if code == "" || notifier.notices["old@example.com"] == "" {
+		t.Fatalf("UpdateUser() should send a code to the new address and a notice to the old one")
+	}
+
+	if user.Email != "old@example.com" {
+		t.Errorf("UpdateUser() swapped email before confirmation")
+	}
+
+	if _, err := service.ConfirmEmailChange(userCtx, "user-1", "xxxxxx"); !errors.Is(err, domain.ErrInvalidEmailChangeCode) {
+		t.Errorf("ConfirmEmailChange() expected error %v, got %v", domain.ErrInvalidEmailChangeCode, err)
+	}
+
+	confirmed, err := service.ConfirmEmailChange(userCtx, "user-1", code)
+	if err != nil {
+		t.Fatalf("ConfirmEmailChange() unexpected error: %v", err)
+	}
+
+	if confirmed.Email != "new@example.com" || !confirmed.IsEmailVerified {
+		t.Errorf("ConfirmEmailChange() should swap the email and keep it verified")
+	}
+
+	if _, err := service.ConfirmEmailChange(userCtx, "user-1", code); !errors.Is(err, domain.ErrEmailChangeNotFound) {
+		t.Errorf("ConfirmEmailChange() replay expected error %v, got %v", domain.ErrEmailChangeNotFound, err)
+	}
+
+	// The old address can undo the change and sign out whoever made it
+	tokens, err := tokenService.IssueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("IssueTokens() unexpected error: %v", err)
+	}
+
+	// The released old address may be taken by another account before the revert
+	squatter := &domain.User{ID: "user-3", Email: "old@example.com", Name: "Squatter", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[squatter.ID] = squatter
+	mockRepo.emails[squatter.Email] = squatter
+	if _, err := service.RevertEmailChange(context.Background(), notifier.notices["old@example.com"]); !errors.Is(err, domain.ErrUserAlreadyExists) {
+		t.Errorf("RevertEmailChange() to a taken address expected error %v, got %v", domain.ErrUserAlreadyExists, err)
+	}
+	delete(mockRepo.users, squatter.ID)
+	delete(mockRepo.emails, squatter.Email)
+
+	reverted, err := service.RevertEmailChange(context.Background(), notifier.notices["old@example.com"])
+	if err != nil {
+		t.Fatalf("RevertEmailChange() unexpected error: %v", err)
+	}
+
+	if reverted.Email != "old@example.com" {
+		t.Errorf("RevertEmailChange() Email = %v, want old@example.com", reverted.Email)
+	}
+
+	if _, err := tokenService.RefreshTokens(context.Background(), tokens.RefreshToken); err == nil {
+		t.Errorf("RefreshTokens() expected sessions to be revoked after revert")
+	}
+
+	if _, err := service.RevertEmailChange(context.Background(), notifier.notices["old@example.com"]); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("RevertEmailChange() replay expected error %v, got %v", domain.ErrInvalidToken, err)
+	}
+}
+
+func TestUserService_EmailChangeAttemptLimit(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "old@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[user.ID] = user
+	mockRepo.emails[user.Email] = user
+
+	notifier := &MockNotifier{}
+	service := newTestUserService(mockRepo, notifier, OTPConfig{MaxAttempts: 2})
+
+	if _, err := service.UpdateUser(context.Background(), "user-1", "new@example.com", ""); err != nil {
+		t.Fatalf("UpdateUser() unexpected error: %v", err)
+	}
+
+	if _, err := service.ConfirmEmailChange(context.Background(), "user-1", "xxxxxx"); !errors.Is(err, domain.ErrInvalidEmailChangeCode) {
+		t.Errorf("ConfirmEmailChange() expected error %v, got %v", domain.ErrInvalidEmailChangeCode, err)
+	}
+
+	if _, err := service.ConfirmEmailChange(context.Background(), "user-1", "xxxxxx"); !errors.Is(err, domain.ErrEmailChangeAttemptsExceeded) {
+		t.Errorf("ConfirmEmailChange() expected error %v, got %v", domain.ErrEmailChangeAttemptsExceeded, err)
+	}
+
+	// The correct code no longer works once the request has been cancelled
+	if _, err := service.ConfirmEmailChange(context.Background(), "user-1", notifier.sent["new@example.com"]); !errors.Is(err, domain.ErrEmailChangeNotFound) {
+		t.Errorf("ConfirmEmailChange() after lockout expected error %v, got %v", domain.ErrEmailChangeNotFound, err)
+	}
+}
+
+func TestUserService_ChangeUserRole(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	admin := &domain.User{ID: "admin-1", Email: "admin@example.com", Name: "Admin", Role: domain.RoleAdmin, IsActive: true}
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	for _, u := range []*domain.User{admin, user} {
+		mockRepo.users[u.ID] = u
+		mockRepo.emails[u.Email] = u
+	}
+
+	service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
+
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+	keyCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersWrite}})
+
+	if _, err := service.ChangeUserRole(userCtx, "user-1", domain.RoleAdmin); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ChangeUserRole() as user expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.ChangeUserRole(keyCtx, "user-1", domain.RoleAdmin); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ChangeUserRole() with API key expected error %v, got %v", domain.ErrForbidden, err)
+	}
+
+	if _, err := service.ChangeUserRole(adminCtx, "admin-1", domain.RoleUser); !errors.Is(err, domain.ErrLastAdmin) {
+		t.Errorf("ChangeUserRole() of last admin expected error %v, got %v", domain.ErrLastAdmin, err)
+	}
+
+	promoted, err := service.ChangeUserRole(adminCtx, "user-1", domain.RoleAdmin)
+	if err != nil {
+		t.Fatalf("ChangeUserRole() unexpected error: %v", err)
+	}
+
+	if promoted.Role != domain.RoleAdmin {
+		t.Errorf("ChangeUserRole() Role = %v, want %v", promoted.Role, domain.RoleAdmin)
+	}
+
+	if len(mockRepo.roleChanges) != 1 {
+		t.Fatalf("ChangeUserRole() recorded %d changes, want 1", len(mockRepo.roleChanges))
+	}
+
+	change := mockRepo.roleChanges[0]
+	if change.OldRole != domain.RoleUser || change.NewRole != domain.RoleAdmin || change.ChangedBy == nil || *change.ChangedBy != "admin-1" {
+		t.Errorf("ChangeUserRole() recorded %+v, want user -> admin by admin-1", change)
+	}
+
+	// With a second admin the first one can now be demoted
+	if _, err := service.ChangeUserRole(adminCtx, "admin-1", domain.RoleUser); err != nil {
+		t.Errorf("ChangeUserRole() demotion unexpected error: %v", err)
+	}
+}
+
+func TestUserService_StaleUpdateKeepsDeactivation(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	user := &domain.User{ID: "user-1", Email: "test@example.com", Name: "Test", Role: domain.RoleUser, IsActive: true}
+	mockRepo.users[user.ID] = user
+	mockRepo.emails[user.Email] = user
+
+	service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	userCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "user-1", Role: domain.RoleUser})
+
+	// The user loaded their profile for an update just before an admin deactivated them
+	stale := *user
+	if _, err := service.DeactivateUser(adminCtx, "user-1", "abuse"); err != nil {
+		t.Fatalf("DeactivateUser() unexpected error: %v", err)
+	}
+
+	mockRepo.getFn = func(ctx context.Context, id string) (*domain.User, error) {
+		copied := stale
+		return &copied, nil
+	}
+	if _, err := service.UpdateUser(userCtx, "user-1", "", "Renamed"); err != nil {
+		t.Fatalf("UpdateUser() unexpected error: %v", err)
+	}
+
+	if stored := mockRepo.users["user-1"]; stored.IsActive || stored.DeactivatedAt == nil || stored.Name != "Renamed" {
+		t.Errorf("UpdateUser() with stale user stored IsActive = %v, DeactivatedAt = %v, Name = %q, want the deactivation kept",
+			stored.IsActive, stored.DeactivatedAt, stored.Name)
+	}
+}
+
+func TestUserService_LastAdminCannotBeDeletedOrDeactivated(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	admin := &domain.User{ID: "admin-1", Email: "admin@example.com", Name: "Admin", Role: domain.RoleAdmin, IsActive: true}
+	other := &domain.User{ID: "admin-2", Email: "other@example.com", Name: "Other", Role: domain.RoleAdmin, IsActive: false}
+	for _, u := range []*domain.User{admin, other} {
+		mockRepo.users[u.ID] = u
+		mockRepo.emails[u.Email] = u
+	}
+
+	service := newTestUserService(mockRepo, &MockNotifier{}, OTPConfig{})
+	adminCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+
+	// An inactive admin does not count towards the remaining admins
+	if _, err := service.DeactivateUser(adminCtx, "admin-1", "leaving"); !errors.Is(err, domain.ErrLastAdmin) {
+		t.Errorf("DeactivateUser() of last admin expected error %v, got %v", domain.ErrLastAdmin, err)
+	}
+
+	if !admin.IsActive || admin.DeactivatedAt != nil {
+		t.Errorf("DeactivateUser() of last admin left IsActive = %v, DeactivatedAt = %v", admin.IsActive, admin.DeactivatedAt)
+	}
+
+	if err := service.DeleteUser(adminCtx, "admin-1"); !errors.Is(err, domain.ErrLastAdmin) {
+		t.Errorf("DeleteUser() of last admin expected error %v, got %v", domain.ErrLastAdmin, err)
+	}
+
+	if _, deleted := mockRepo.deleted["admin-1"]; deleted {
+		t.Error("DeleteUser() of last admin soft-deleted the user")
+	}
+
+	// Once another admin is active the first one can be deactivated, after which the other is the last admin
+	if _, err := service.ReactivateUser(adminCtx, "admin-2"); err != nil {
+		t.Fatalf("ReactivateUser() unexpected error: %v", err)
+	}
+
+	if _, err := service.DeactivateUser(adminCtx, "admin-1", "leaving"); err != nil {
+		t.Errorf("DeactivateUser() with another admin unexpected error: %v", err)
+	}
+
+	if err := service.DeleteUser(adminCtx, "admin-2"); !errors.Is(err, domain.ErrLastAdmin) {
+		t.Errorf("DeleteUser() of remaining admin expected error %v, got %v", domain.ErrLastAdmin, err)
+	}
+
+	// A deactivated admin is no longer counted, so deleting them is allowed
+	if err := service.DeleteUser(adminCtx, "admin-1"); err != nil {
+		t.Errorf("DeleteUser() of deactivated admin unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+-- Remove attempt limiting and restore the plaintext OTP column
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_users_otp_attempts;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS otp_last_sent_at,
+DROP COLUMN IF EXISTS otp_locked_until,
+DROP COLUMN IF EXISTS otp_attempts,
+DROP COLUMN IF EXISTS otp_hash,
+ADD COLUMN IF NOT EXISTS otp VARCHAR(6);
+
+UPDATE users SET otp_expires_at = NULL;
+
+ALTER TABLE users ADD CONSTRAINT check_users_otp_length
+CHECK (otp IS NULL OR LENGTH(otp) = 6);
@@ -0,0 +1,16 @@
+-- Replace plaintext OTPs with salted hashes and add attempt limiting
+-- Outstanding plaintext codes are discarded; users have to request a new one
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_users_otp_length;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS otp,
+ADD COLUMN IF NOT EXISTS otp_hash VARCHAR(255),
+ADD COLUMN IF NOT EXISTS otp_attempts INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS otp_locked_until TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS otp_last_sent_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET otp_expires_at = NULL;
+
+-- Add check constraint for attempt counter
+ALTER TABLE users ADD CONSTRAINT check_users_otp_attempts
+CHECK (otp_attempts >= 0);
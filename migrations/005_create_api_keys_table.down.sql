@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_api_keys_created_at;
+DROP INDEX IF EXISTS idx_api_keys_owner_user_id;
+
+-- Drop api_keys table
+DROP TABLE IF EXISTS api_keys;
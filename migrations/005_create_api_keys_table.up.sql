@@ -0,0 +1,22 @@
+-- Create api_keys table (only key hashes are stored)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL,
+    owner_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add check constraint requiring at least one scope
+ALTER TABLE api_keys ADD CONSTRAINT check_api_keys_scopes
+CHECK (cardinality(scopes) > 0);
+
+-- Create index on owner_user_id for listing keys by owner
+CREATE INDEX IF NOT EXISTS idx_api_keys_owner_user_id ON api_keys(owner_user_id);
+
+-- Create index on created_at for sorting
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_at ON api_keys(created_at);
@@ -0,0 +1,5 @@
+-- Remove deactivation details from users table
+ALTER TABLE users
+DROP COLUMN IF EXISTS deactivation_reason,
+DROP COLUMN IF EXISTS deactivated_by,
+DROP COLUMN IF EXISTS deactivated_at;
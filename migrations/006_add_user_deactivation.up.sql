@@ -0,0 +1,5 @@
+-- Record why and by whom an account was deactivated
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS deactivated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS deactivation_reason TEXT;
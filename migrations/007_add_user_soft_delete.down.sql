@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+-- Remove soft delete support from users table
+ALTER TABLE users
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Add soft delete support to users table
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index on deleted_at for purging soft-deleted users
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
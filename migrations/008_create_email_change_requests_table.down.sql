@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_email_change_requests_user_id;
+DROP INDEX IF EXISTS idx_email_change_requests_pending_user_id;
+
+-- Drop email_change_requests table
+DROP TABLE IF EXISTS email_change_requests;
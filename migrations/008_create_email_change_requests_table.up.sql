@@ -0,0 +1,23 @@
+-- Create email_change_requests table (only code and revert token hashes are stored)
+CREATE TABLE IF NOT EXISTS email_change_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_email VARCHAR(255) NOT NULL,
+    new_email VARCHAR(255) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    revert_token_hash VARCHAR(64) UNIQUE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revert_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    reverted_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Allow at most one pending email change per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_change_requests_pending_user_id ON email_change_requests(user_id)
+WHERE confirmed_at IS NULL AND reverted_at IS NULL AND cancelled_at IS NULL;
+
+-- Create index on user_id for looking up a user's email changes
+CREATE INDEX IF NOT EXISTS idx_email_change_requests_user_id ON email_change_requests(user_id);
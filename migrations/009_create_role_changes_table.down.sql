@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_role_changes_user_id;
+
+-- Drop role_changes table
+DROP TABLE IF EXISTS role_changes;
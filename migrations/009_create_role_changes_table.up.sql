@@ -0,0 +1,12 @@
+-- Create role_changes table to audit who changed which user's role and when
+CREATE TABLE IF NOT EXISTS role_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_role VARCHAR(20) NOT NULL,
+    new_role VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index on user_id for listing a user's role history
+CREATE INDEX IF NOT EXISTS idx_role_changes_user_id ON role_changes(user_id);
@@ -0,0 +1,14 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS trg_ledger_postings_immutable ON ledger_postings;
+DROP TRIGGER IF EXISTS trg_journal_entries_immutable ON journal_entries;
+DROP TRIGGER IF EXISTS trg_ledger_postings_balanced ON ledger_postings;
+DROP TRIGGER IF EXISTS trg_journal_entries_balanced ON journal_entries;
+
+-- Drop trigger functions
+DROP FUNCTION IF EXISTS prevent_ledger_mutation();
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+
+-- Drop ledger tables
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
@@ -0,0 +1,103 @@
+-- Create ledger_accounts table (balances are maintained together with the postings that change them)
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    type VARCHAR(32) NOT NULL,
+    user_id UUID, -- no foreign key: credit history outlives purged users
+    balance BIGINT NOT NULL DEFAULT 0,
+    allow_negative BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Only system accounts may be overdrawn
+ALTER TABLE ledger_accounts ADD CONSTRAINT check_ledger_accounts_balance
+CHECK (allow_negative OR balance >= 0);
+
+-- One account per type for each user, and one per type for the system
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_user_type ON ledger_accounts(user_id, type) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_system_type ON ledger_accounts(type) WHERE user_id IS NULL;
+
+-- Create journal_entries table
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(32) NOT NULL,
+    user_id UUID NOT NULL,
+    reference VARCHAR(255),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A reference identifies at most one entry of each kind, making postings idempotent
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_kind_reference ON journal_entries(kind, reference) WHERE reference IS NOT NULL;
+
+-- Create index on user_id for listing a user's credit history
+CREATE INDEX IF NOT EXISTS idx_journal_entries_user_id ON journal_entries(user_id, created_at);
+
+-- Create ledger_postings table
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    account_id UUID NOT NULL REFERENCES ledger_accounts(id),
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    balance_after BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for loading entries and account statements
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_entry_id ON ledger_postings(entry_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_account_id ON ledger_postings(account_id, id);
+
+-- Every journal entry must have at least two postings that sum to zero, checked at commit
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+DECLARE
+    v_entry_id UUID;
+    v_count INTEGER;
+    v_sum BIGINT;
+BEGIN
+    IF TG_TABLE_NAME = 'journal_entries' THEN
+        v_entry_id := NEW.id;
+    ELSE
+        v_entry_id := NEW.entry_id;
+    END IF;
+
+    SELECT COUNT(*), COALESCE(SUM(amount), 0) INTO v_count, v_sum
+    FROM ledger_postings
+    WHERE entry_id = v_entry_id;
+
+    IF v_count < 2 OR v_sum <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', v_entry_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_journal_entries_balanced
+AFTER INSERT ON journal_entries
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+CREATE CONSTRAINT TRIGGER trg_ledger_postings_balanced
+AFTER INSERT ON ledger_postings
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Journal entries and postings are append-only
+CREATE OR REPLACE FUNCTION prevent_ledger_mutation() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '% rows are immutable', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_journal_entries_immutable
+BEFORE UPDATE OR DELETE ON journal_entries
+FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+CREATE TRIGGER trg_ledger_postings_immutable
+BEFORE UPDATE OR DELETE ON ledger_postings
+FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+-- Seed the system accounts credits are issued from and redeemed into
+INSERT INTO ledger_accounts (type, allow_negative) VALUES
+    ('issuance', TRUE),
+    ('redemption', FALSE)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Remove pending accounts that never received a posting (available accounts may predate wallets)
+DELETE FROM ledger_accounts a
+WHERE a.type = 'user_pending'
+  AND NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.account_id = a.id);
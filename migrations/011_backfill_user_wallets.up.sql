@@ -0,0 +1,6 @@
+-- Open wallet accounts for users created before wallets were opened together with the user row
+INSERT INTO ledger_accounts (type, user_id)
+SELECT t.type, u.id
+FROM users u
+CROSS JOIN (VALUES ('user_available'), ('user_pending')) AS t(type)
+ON CONFLICT DO NOTHING;
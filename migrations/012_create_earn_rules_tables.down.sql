@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_earn_rule_awards_rule_user;
+DROP INDEX IF EXISTS idx_earn_rules_event_type;
+
+-- Drop earn rule tables
+DROP TABLE IF EXISTS earn_rule_awards;
+DROP TABLE IF EXISTS earn_rules;
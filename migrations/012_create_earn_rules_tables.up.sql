@@ -0,0 +1,36 @@
+-- Create earn_rules table holding admin-managed rules for awarding credits on user events
+CREATE TABLE IF NOT EXISTS earn_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    conditions JSONB NOT NULL DEFAULT '[]',
+    award_type VARCHAR(20) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    base_attribute VARCHAR(64) NOT NULL DEFAULT '',
+    max_award BIGINT CHECK (max_award > 0),
+    max_awards_per_user INTEGER CHECK (max_awards_per_user > 0),
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE earn_rules ADD CONSTRAINT check_earn_rules_award_type
+CHECK (award_type IN ('fixed', 'percentage'));
+
+-- Create index on event_type for evaluating reported events
+CREATE INDEX IF NOT EXISTS idx_earn_rules_event_type ON earn_rules(event_type) WHERE is_active;
+
+-- Create earn_rule_awards table linking each award to the journal entry that paid it
+CREATE TABLE IF NOT EXISTS earn_rule_awards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_id UUID NOT NULL REFERENCES earn_rules(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for enforcing per-user award limits
+CREATE INDEX IF NOT EXISTS idx_earn_rule_awards_rule_user ON earn_rule_awards(rule_id, user_id);
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_rewards_status_created_at;
+
+-- Drop rewards table
+DROP TABLE IF EXISTS rewards;
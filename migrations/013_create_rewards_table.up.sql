@@ -0,0 +1,23 @@
+-- Create rewards table holding the catalog users spend credits on
+CREATE TABLE IF NOT EXISTS rewards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    cost BIGINT NOT NULL CHECK (cost > 0),
+    stock INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Stock can never be oversold
+ALTER TABLE rewards ADD CONSTRAINT check_rewards_stock
+CHECK (stock >= 0);
+
+ALTER TABLE rewards ADD CONSTRAINT check_rewards_status
+CHECK (status IN ('draft', 'active', 'archived'));
+
+-- Create index for browsing the active catalog
+CREATE INDEX IF NOT EXISTS idx_rewards_status_created_at ON rewards(status, created_at);
@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_redemptions_held_expires_at;
+DROP INDEX IF EXISTS idx_redemptions_user_id;
+
+-- Drop redemptions table
+DROP TABLE IF EXISTS redemptions;
+
+-- Remove held-credit accounts that never received a posting
+DELETE FROM ledger_accounts a
+WHERE a.type = 'user_held'
+  AND NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.account_id = a.id);
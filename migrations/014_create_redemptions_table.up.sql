@@ -0,0 +1,27 @@
+-- Open held-credit accounts for existing users (new users get one with their wallet)
+INSERT INTO ledger_accounts (type, user_id)
+SELECT 'user_held', id FROM users
+ON CONFLICT DO NOTHING;
+
+-- Create redemptions table tracking credit holds on rewards until they are settled
+CREATE TABLE IF NOT EXISTS redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    reward_id UUID NOT NULL REFERENCES rewards(id),
+    cost BIGINT NOT NULL CHECK (cost > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    hold_entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    settle_entry_id UUID REFERENCES journal_entries(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE redemptions ADD CONSTRAINT check_redemptions_status
+CHECK (status IN ('held', 'confirmed', 'cancelled', 'expired'));
+
+-- Create index on user_id for listing a user's redemptions
+CREATE INDEX IF NOT EXISTS idx_redemptions_user_id ON redemptions(user_id, created_at);
+
+-- Create index for releasing timed-out holds
+CREATE INDEX IF NOT EXISTS idx_redemptions_held_expires_at ON redemptions(expires_at) WHERE status = 'held';
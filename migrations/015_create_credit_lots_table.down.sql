@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_credit_lots_unspent_expires_at;
+DROP INDEX IF EXISTS idx_credit_lots_user_unspent;
+
+-- Drop credit_lots table
+DROP TABLE IF EXISTS credit_lots;
+
+-- Remove the expiration account unless credits already expired into it
+DELETE FROM ledger_accounts a
+WHERE a.type = 'expiration' AND a.user_id IS NULL
+  AND NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.account_id = a.id);
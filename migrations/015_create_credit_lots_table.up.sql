@@ -0,0 +1,32 @@
+-- Create credit_lots table tracking the credits each entry added to a user's wallet until they are spent or expire
+CREATE TABLE IF NOT EXISTS credit_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    entry_id UUID REFERENCES journal_entries(id), -- NULL for balances that predate lot tracking
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    remaining BIGINT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE, -- NULL for credits that never expire
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE credit_lots ADD CONSTRAINT check_credit_lots_remaining
+CHECK (remaining >= 0 AND remaining <= amount);
+
+-- Create index for consuming a user's unspent lots oldest first
+CREATE INDEX IF NOT EXISTS idx_credit_lots_user_unspent ON credit_lots(user_id, expires_at, created_at) WHERE remaining > 0;
+
+-- Create index for expiring unspent lots
+CREATE INDEX IF NOT EXISTS idx_credit_lots_unspent_expires_at ON credit_lots(expires_at) WHERE remaining > 0;
+
+-- Existing available and held balances become one non-expiring lot per user
+INSERT INTO credit_lots (user_id, amount, remaining)
+SELECT user_id, SUM(balance), SUM(balance)
+FROM ledger_accounts
+WHERE type IN ('user_available', 'user_held')
+GROUP BY user_id
+HAVING SUM(balance) > 0;
+
+-- Seed the system account expired credits are moved into
+INSERT INTO ledger_accounts (type, allow_negative) VALUES
+    ('expiration', FALSE)
+ON CONFLICT DO NOTHING;
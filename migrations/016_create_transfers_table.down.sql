@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_transfers_recipient_id;
+DROP INDEX IF EXISTS idx_transfers_sender_id;
+
+-- Drop transfers table
+DROP TABLE IF EXISTS transfers;
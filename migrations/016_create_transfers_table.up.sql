@@ -0,0 +1,19 @@
+-- Create transfers table recording credits users gift to each other
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sender_id UUID NOT NULL,
+    recipient_id UUID NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    note TEXT NOT NULL DEFAULT '',
+    entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE transfers ADD CONSTRAINT check_transfers_distinct_users
+CHECK (sender_id <> recipient_id);
+
+-- Create index for summing what a user sent towards the daily limit
+CREATE INDEX IF NOT EXISTS idx_transfers_sender_id ON transfers(sender_id, created_at);
+
+-- Create index on recipient_id for listing credits a user received
+CREATE INDEX IF NOT EXISTS idx_transfers_recipient_id ON transfers(recipient_id, created_at);
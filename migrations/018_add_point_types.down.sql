@@ -0,0 +1,26 @@
+-- Remove the tier point system accounts unless points already moved through them
+DELETE FROM ledger_accounts a
+WHERE a.point_type <> 'credits' AND a.user_id IS NULL
+  AND NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.account_id = a.id);
+
+-- Restore indexes without the point type
+DROP INDEX IF EXISTS idx_transfers_sender_id;
+CREATE INDEX IF NOT EXISTS idx_transfers_sender_id ON transfers(sender_id, created_at);
+
+DROP INDEX IF EXISTS idx_credit_lots_user_unspent;
+CREATE INDEX IF NOT EXISTS idx_credit_lots_user_unspent ON credit_lots(user_id, expires_at, created_at) WHERE remaining > 0;
+
+DROP INDEX IF EXISTS idx_ledger_accounts_system_type;
+DROP INDEX IF EXISTS idx_ledger_accounts_user_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_user_type ON ledger_accounts(user_id, type) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_system_type ON ledger_accounts(type) WHERE user_id IS NULL;
+
+-- Drop point type columns
+ALTER TABLE ledger_accounts DROP CONSTRAINT IF EXISTS check_ledger_accounts_point_type;
+ALTER TABLE transfers DROP COLUMN IF EXISTS point_type;
+ALTER TABLE earn_rules DROP COLUMN IF EXISTS point_type;
+ALTER TABLE redemptions DROP COLUMN IF EXISTS point_type;
+ALTER TABLE rewards DROP COLUMN IF EXISTS point_type;
+ALTER TABLE credit_lots DROP COLUMN IF EXISTS point_type;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS point_type;
+ALTER TABLE ledger_accounts DROP COLUMN IF EXISTS point_type;
@@ -0,0 +1,32 @@
+-- Every balance and movement is in one point type; existing ones are credits
+ALTER TABLE ledger_accounts ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE credit_lots ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE rewards ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE redemptions ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE earn_rules ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS point_type VARCHAR(32) NOT NULL DEFAULT 'credits';
+
+ALTER TABLE ledger_accounts ADD CONSTRAINT check_ledger_accounts_point_type
+CHECK (point_type IN ('credits', 'tier_points'));
+
+-- One account per type and point type for each user, and one per type and point type for the system
+DROP INDEX IF EXISTS idx_ledger_accounts_user_type;
+DROP INDEX IF EXISTS idx_ledger_accounts_system_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_user_type ON ledger_accounts(user_id, point_type, type) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_system_type ON ledger_accounts(point_type, type) WHERE user_id IS NULL;
+
+-- Lots are consumed per point type
+DROP INDEX IF EXISTS idx_credit_lots_user_unspent;
+CREATE INDEX IF NOT EXISTS idx_credit_lots_user_unspent ON credit_lots(user_id, point_type, expires_at, created_at) WHERE remaining > 0;
+
+-- Daily transfer limits are counted per point type
+DROP INDEX IF EXISTS idx_transfers_sender_id;
+CREATE INDEX IF NOT EXISTS idx_transfers_sender_id ON transfers(sender_id, point_type, created_at);
+
+-- Seed the system accounts tier points are issued from, redeemed into and expired into
+INSERT INTO ledger_accounts (type, point_type, allow_negative) VALUES
+    ('issuance', 'tier_points', TRUE),
+    ('redemption', 'tier_points', FALSE),
+    ('expiration', 'tier_points', FALSE)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_tier_changes_user_id;
+
+-- Drop tier_changes table
+DROP TABLE IF EXISTS tier_changes;
+
+-- Drop users tier column
+ALTER TABLE users DROP COLUMN IF EXISTS tier_id;
+
+-- Drop tiers table
+DROP INDEX IF EXISTS idx_tiers_threshold;
+DROP INDEX IF EXISTS idx_tiers_name;
+DROP TABLE IF EXISTS tiers;
@@ -0,0 +1,35 @@
+-- Create tiers table holding the loyalty levels admins define
+CREATE TABLE IF NOT EXISTS tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    threshold BIGINT NOT NULL CHECK (threshold >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Tiers are told apart by name and ranked by threshold
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tiers_name ON tiers(LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tiers_threshold ON tiers(threshold);
+
+-- Store each user's current tier (members of a deleted tier are reassigned by the next recalculation)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tier_id UUID REFERENCES tiers(id) ON DELETE SET NULL;
+
+-- Create tier_changes table recording every move of a user between tiers
+CREATE TABLE IF NOT EXISTS tier_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_tier_id UUID REFERENCES tiers(id) ON DELETE SET NULL,
+    from_tier_name VARCHAR(100),
+    to_tier_id UUID REFERENCES tiers(id) ON DELETE SET NULL,
+    to_tier_name VARCHAR(100),
+    qualifying_points BIGINT NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE tier_changes ADD CONSTRAINT check_tier_changes_reason
+CHECK (reason IN ('activity', 'scheduled'));
+
+-- Create index on user_id for listing a user's tier history
+CREATE INDEX IF NOT EXISTS idx_tier_changes_user_id ON tier_changes(user_id, created_at);
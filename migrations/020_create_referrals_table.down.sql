@@ -0,0 +1,10 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_referrals_referrer_id;
+
+-- Drop referrals table
+DROP TABLE IF EXISTS referrals;
+
+-- Drop users referral columns
+ALTER TABLE users DROP COLUMN IF EXISTS referred_by;
+DROP INDEX IF EXISTS idx_users_referral_code;
+ALTER TABLE users DROP COLUMN IF EXISTS referral_code;
@@ -0,0 +1,30 @@
+-- Give every user a unique referral code, backfilling existing users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS referral_code VARCHAR(16);
+UPDATE users SET referral_code = UPPER(SUBSTR(MD5(id::text || RANDOM()::text), 1, 8)) WHERE referral_code IS NULL;
+ALTER TABLE users ALTER COLUMN referral_code SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_referral_code ON users(referral_code);
+
+-- Store who referred each user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS referred_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create referrals table tracking the reward owed to both users of a referral
+CREATE TABLE IF NOT EXISTS referrals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    referrer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    referee_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    referee_email_domain VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    referrer_entry_id UUID REFERENCES journal_entries(id),
+    referee_entry_id UUID REFERENCES journal_entries(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    rewarded_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE referrals ADD CONSTRAINT check_referrals_status
+CHECK (status IN ('pending', 'rewarded'));
+
+ALTER TABLE referrals ADD CONSTRAINT check_referrals_distinct_users
+CHECK (referrer_id <> referee_id);
+
+-- Create index for listing a user's referrals and counting them per email domain
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_id ON referrals(referrer_id, referee_email_domain);
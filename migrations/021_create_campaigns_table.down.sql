@@ -0,0 +1,8 @@
+-- Drop earn_rule_awards campaign columns
+DROP INDEX IF EXISTS idx_earn_rule_awards_campaign_id;
+ALTER TABLE earn_rule_awards DROP COLUMN IF EXISTS bonus;
+ALTER TABLE earn_rule_awards DROP COLUMN IF EXISTS campaign_id;
+
+-- Drop campaigns table
+DROP INDEX IF EXISTS idx_campaigns_window;
+DROP TABLE IF EXISTS campaigns;
@@ -0,0 +1,33 @@
+-- Create campaigns table holding time-boxed promotions that boost earned credits
+CREATE TABLE IF NOT EXISTS campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    audience JSONB NOT NULL DEFAULT '{}',
+    multiplier BIGINT NOT NULL DEFAULT 0,
+    bonus BIGINT NOT NULL DEFAULT 0 CHECK (bonus >= 0),
+    budget BIGINT NOT NULL CHECK (budget > 0),
+    spent BIGINT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- The budget can never be overspent
+ALTER TABLE campaigns ADD CONSTRAINT check_campaigns_spent
+CHECK (spent >= 0 AND spent <= budget);
+
+ALTER TABLE campaigns ADD CONSTRAINT check_campaigns_window
+CHECK (ends_at > starts_at);
+
+-- Create index for finding the campaigns running when an event occurred
+CREATE INDEX IF NOT EXISTS idx_campaigns_window ON campaigns(starts_at, ends_at) WHERE is_active;
+
+-- Record the campaign that boosted each award and the credits it added
+ALTER TABLE earn_rule_awards ADD COLUMN IF NOT EXISTS campaign_id UUID REFERENCES campaigns(id);
+ALTER TABLE earn_rule_awards ADD COLUMN IF NOT EXISTS bonus BIGINT NOT NULL DEFAULT 0;
+
+-- Create index for listing a campaign's awards
+CREATE INDEX IF NOT EXISTS idx_earn_rule_awards_campaign_id ON earn_rule_awards(campaign_id) WHERE campaign_id IS NOT NULL;
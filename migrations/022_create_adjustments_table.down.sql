@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_adjustments_user_id;
+DROP INDEX IF EXISTS idx_adjustments_status_created_at;
+
+-- Drop adjustments table
+DROP TABLE IF EXISTS adjustments;
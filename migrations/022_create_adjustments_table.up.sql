@@ -0,0 +1,36 @@
+-- Create adjustments table holding manual grants and deductions awaiting a second admin's review
+CREATE TABLE IF NOT EXISTS adjustments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    point_type VARCHAR(32) NOT NULL DEFAULT 'credits',
+    direction VARCHAR(10) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    reason VARCHAR(32) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by UUID NOT NULL REFERENCES users(id),
+    reviewed_by UUID REFERENCES users(id),
+    review_note TEXT NOT NULL DEFAULT '',
+    entry_id UUID REFERENCES journal_entries(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE adjustments ADD CONSTRAINT check_adjustments_direction
+CHECK (direction IN ('grant', 'deduct'));
+
+ALTER TABLE adjustments ADD CONSTRAINT check_adjustments_reason
+CHECK (reason IN ('goodwill', 'error_correction', 'missing_award', 'fraud', 'other'));
+
+ALTER TABLE adjustments ADD CONSTRAINT check_adjustments_status
+CHECK (status IN ('pending', 'approved', 'rejected'));
+
+-- The requester can never review their own adjustment
+ALTER TABLE adjustments ADD CONSTRAINT check_adjustments_distinct_reviewer
+CHECK (reviewed_by <> requested_by);
+
+-- Create index for listing adjustments awaiting review
+CREATE INDEX IF NOT EXISTS idx_adjustments_status_created_at ON adjustments(status, created_at);
+
+-- Create index on user_id for listing a user's adjustments
+CREATE INDEX IF NOT EXISTS idx_adjustments_user_id ON adjustments(user_id, created_at);
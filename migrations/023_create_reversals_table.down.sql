@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_reversals_entry_id;
+
+-- Drop reversals table
+DROP TABLE IF EXISTS reversals;
@@ -0,0 +1,20 @@
+-- Create reversals table linking each compensating entry to the journal entry it reverses
+CREATE TABLE IF NOT EXISTS reversals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    entry_kind VARCHAR(32) NOT NULL,
+    user_id UUID NOT NULL, -- no foreign key: credit history outlives purged users
+    point_type VARCHAR(32) NOT NULL DEFAULT 'credits',
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    posted BIGINT NOT NULL,
+    reversal_entry_id UUID REFERENCES journal_entries(id), -- NULL when nothing could be clawed back
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Points that could not be clawed back are the shortfall; a reversal never moves more than it undoes
+ALTER TABLE reversals ADD CONSTRAINT check_reversals_posted
+CHECK (posted >= 0 AND posted <= amount);
+
+-- Create index for summing what was already reversed of an entry
+CREATE INDEX IF NOT EXISTS idx_reversals_entry_id ON reversals(entry_id);
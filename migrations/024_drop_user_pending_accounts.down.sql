@@ -0,0 +1,4 @@
+-- Reopen pending credit accounts for every user
+INSERT INTO ledger_accounts (type, user_id)
+SELECT 'user_pending', id FROM users
+ON CONFLICT DO NOTHING;
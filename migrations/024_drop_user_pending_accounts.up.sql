@@ -0,0 +1,4 @@
+-- Remove the pending wallet accounts: nothing ever posted to them, redemption holds go to user_held
+DELETE FROM ledger_accounts a
+WHERE a.type = 'user_pending'
+  AND NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.account_id = a.id);
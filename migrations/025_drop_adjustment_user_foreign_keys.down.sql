@@ -0,0 +1,4 @@
+-- Restore the foreign keys without validating adjustments of users purged in the meantime
+ALTER TABLE adjustments ADD CONSTRAINT adjustments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) NOT VALID;
+ALTER TABLE adjustments ADD CONSTRAINT adjustments_requested_by_fkey FOREIGN KEY (requested_by) REFERENCES users(id) NOT VALID;
+ALTER TABLE adjustments ADD CONSTRAINT adjustments_reviewed_by_fkey FOREIGN KEY (reviewed_by) REFERENCES users(id) NOT VALID;
@@ -0,0 +1,5 @@
+-- Like the ledger, adjustments outlive purged users and the audit trail outlives purged admins,
+-- so they must not keep soft-deleted users from being purged
+ALTER TABLE adjustments DROP CONSTRAINT IF EXISTS adjustments_user_id_fkey;
+ALTER TABLE adjustments DROP CONSTRAINT IF EXISTS adjustments_requested_by_fkey;
+ALTER TABLE adjustments DROP CONSTRAINT IF EXISTS adjustments_reviewed_by_fkey;
@@ -0,0 +1,135 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DispatcherConfig holds asynchronous delivery configuration
+type DispatcherConfig struct {
+	Workers      int
+	QueueSize    int
+	MaxRetries   int
+	RetryBackoff time.Duration
+	SendTimeout  time.Duration
+}
+
+// Dispatcher delivers messages in the background with retries so callers never block on delivery
+type Dispatcher struct {
+	mailer Mailer
+	config DispatcherConfig
+	queue  chan *Message
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewDispatcher creates a dispatcher and starts its workers
+func NewDispatcher(mailer Mailer, config DispatcherConfig) *Dispatcher {
+	if config.Workers <= 0 {
+		config.Workers = 2
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 100
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = time.Second
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = 30 * time.Second
+	}
+
+	d := &Dispatcher{
+		mailer: mailer,
+		config: config,
+		queue:  make(chan *Message, config.QueueSize),
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+
+	return d
+}
+
+// Enqueue schedules a message for delivery without waiting for it to be sent
+func (d *Dispatcher) Enqueue(msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+
+	select {
+	case d.queue <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting messages and waits for queued messages to be delivered or ctx to expire
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// worker delivers queued messages until the queue is closed
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+
+	for msg := range d.queue {
+		d.deliver(msg)
+	}
+}
+
+// deliver sends a message, retrying with exponential backoff on failure
+func (d *Dispatcher) deliver(msg *Message) {
+	backoff := d.config.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), d.config.SendTimeout)
+		err := d.mailer.Send(ctx, msg)
+		cancel()
+
+		if err == nil {
+			return
+		}
+
+		if attempt >= d.config.MaxRetries {
+			log.Printf("Failed to deliver email %q to %v after %d attempts: %v", msg.Subject, msg.To, attempt+1, err)
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// flakyMailer fails a fixed number of times before succeeding
+type flakyMailer struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	sent     []*Message
+}
+
+func (m *flakyMailer) Send(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attempts++
+	if m.attempts <= m.failures {
+		return errors.New("temporary failure")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func testMessage() *Message {
+	return &Message{
+		From:     "from@example.com",
+		To:       []string{"to@example.com"},
+		Subject:  "Hello",
+		TextBody: "Body",
+	}
+}
+
+func TestDispatcher_RetriesUntilDelivered(t *testing.T) {
+	m := &flakyMailer{failures: 2}
+	d := NewDispatcher(m, DispatcherConfig{Workers: 1, MaxRetries: 3, RetryBackoff: time.Millisecond})
+
+	if err := d.Enqueue(testMessage()); err != nil {
+		t.Fatalf("Enqueue() unexpected error: %v", err)
+	}
+
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	if m.attempts != 3 || len(m.sent) != 1 {
+		t.Errorf("attempts = %d, sent = %d, want 3 attempts and 1 delivery", m.attempts, len(m.sent))
+	}
+
+	if err := d.Enqueue(testMessage()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Enqueue() after Close expected error %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxRetries(t *testing.T) {
+	m := &flakyMailer{failures: 10}
+	d := NewDispatcher(m, DispatcherConfig{Workers: 1, MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	if err := d.Enqueue(testMessage()); err != nil {
+		t.Fatalf("Enqueue() unexpected error: %v", err)
+	}
+
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	if m.attempts != 3 || len(m.sent) != 0 {
+		t.Errorf("attempts = %d, sent = %d, want 3 attempts and no delivery", m.attempts, len(m.sent))
+	}
+}
+
+func TestDispatcher_RejectsInvalidMessage(t *testing.T) {
+	d := NewDispatcher(&flakyMailer{}, DispatcherConfig{})
+	defer d.Close(context.Background())
+
+	if err := d.Enqueue(&Message{}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Enqueue() expected error %v, got %v", ErrInvalidMessage, err)
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"welcome.subject.tmpl": {Data: []byte("Welcome {{.Name}}\n")},
+		"welcome.txt.tmpl":     {Data: []byte("Hi {{.Name}}")},
+		"welcome.html.tmpl":    {Data: []byte("<p>Hi {{.Name}}</p>")},
+	}
+
+	renderer, err := NewRenderer(fsys, "from@example.com")
+	if err != nil {
+		t.Fatalf("NewRenderer() unexpected error: %v", err)
+	}
+
+	msg, err := renderer.Render("welcome", []string{"to@example.com"}, map[string]string{"Name": "<Ann>"})
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+
+	if msg.Subject != "Welcome <Ann>" || msg.TextBody != "Hi <Ann>" {
+		t.Errorf("Render() subject = %q, text = %q", msg.Subject, msg.TextBody)
+	}
+
+	if msg.HTMLBody != "<p>Hi &lt;Ann&gt;</p>" {
+		t.Errorf("Render() HTML body should be escaped, got %q", msg.HTMLBody)
+	}
+
+	if _, err := renderer.Render("missing", []string{"to@example.com"}, nil); err == nil {
+		t.Errorf("Render() expected error for missing template")
+	}
+}
@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMessage = errors.New("invalid email message")
+	ErrQueueFull      = errors.New("email queue is full")
+	ErrClosed         = errors.New("email dispatcher is closed")
+)
+
+// Message represents an outbound email
+type Message struct {
+	From     string
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Mailer delivers email messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Validate checks that the message can be delivered
+func (m *Message) Validate() error {
+	if m.From == "" || len(m.To) == 0 || m.Subject == "" {
+		return ErrInvalidMessage
+	}
+
+	if m.TextBody == "" && m.HTMLBody == "" {
+		return ErrInvalidMessage
+	}
+
+	return nil
+}
+
+// Bytes encodes the message as an RFC 5322 document with a multipart/alternative body
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", m.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@srbcs>\r\n", messageID())
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", m.TextBody},
+		{"text/html; charset=utf-8", m.HTMLBody},
+	}
+
+	for _, part := range parts {
+		if part.body == "" {
+			continue
+		}
+
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create message part: %w", err)
+		}
+
+		if _, err := w.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to write message part: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close message: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// messageID returns a random identifier for the Message-ID header
+func messageID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SpoolMailer writes messages to a local directory or stream instead of delivering them.
+// It is intended for development and tests.
+type SpoolMailer struct {
+	dir string
+	out io.Writer
+	mu  sync.Mutex
+}
+
+// NewFileSpoolMailer creates a mailer that writes each message as an .eml file in dir
+func NewFileSpoolMailer(dir string) (*SpoolMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail spool directory: %w", err)
+	}
+
+	return &SpoolMailer{
+		dir: dir,
+	}, nil
+}
+
+// NewStreamSpoolMailer creates a mailer that writes messages to out (e.g. os.Stdout)
+func NewStreamSpoolMailer(out io.Writer) *SpoolMailer {
+	return &SpoolMailer{
+		out: out,
+	}
+}
+
+// Send writes the message to the spool
+func (m *SpoolMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		if _, err := fmt.Fprintf(m.out, "----- email -----\n%s\n----- end email -----\n", body); err != nil {
+			return fmt.Errorf("failed to write email: %w", err)
+		}
+		return nil
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), messageID()[:8])
+	if err := os.WriteFile(filepath.Join(m.dir, name), body, 0o644); err != nil {
+		return fmt.Errorf("failed to write email to spool: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	texttemplate "text/template"
+)
+
+// Renderer builds messages from named templates.
+// Each template name has a "<name>.subject.tmpl" and "<name>.txt.tmpl" file, and optionally "<name>.html.tmpl".
+type Renderer struct {
+	from string
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// NewRenderer parses all templates in fsys
+func NewRenderer(fsys fs.FS, from string) (*Renderer, error) {
+	text, err := texttemplate.ParseFS(fsys, "*.subject.tmpl", "*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text email templates: %w", err)
+	}
+
+	html, err := htmltemplate.ParseFS(fsys, "*.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML email templates: %w", err)
+	}
+
+	return &Renderer{
+		from: from,
+		text: text,
+		html: html,
+	}, nil
+}
+
+// Render builds a message addressed to the given recipients from the named template
+func (r *Renderer) Render(name string, to []string, data any) (*Message, error) {
+	subject, err := r.executeText(name+".subject.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	textBody, err := r.executeText(name+".txt.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		From:     r.from,
+		To:       to,
+		Subject:  string(bytes.TrimSpace([]byte(subject))),
+		TextBody: textBody,
+	}
+
+	if tmpl := r.html.Lookup(name + ".html.tmpl"); tmpl != nil {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render email template %s: %w", name, err)
+		}
+		msg.HTMLBody = buf.String()
+	}
+
+	return msg, nil
+}
+
+// executeText renders a text template by file name
+func (r *Renderer) executeText(name string, data any) (string, error) {
+	tmpl := r.text.Lookup(name)
+	if tmpl == nil {
+		return "", fmt.Errorf("email template %s not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
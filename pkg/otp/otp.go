@@ -0,0 +1,25 @@
+package otp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// Generate returns a random numeric code with the given number of digits
+func Generate(digits int) (string, error) {
+	if digits <= 0 {
+		return "", fmt.Errorf("invalid OTP length: %d", digits)
+	}
+
+	code := make([]byte, digits)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate OTP: %w", err)
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+
+	return string(code), nil
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// codeAlphabet leaves out letters easily mistaken for digits (I, L, O, U)
+const codeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// GenerateCode returns a random human-friendly code with the given number of characters
+func GenerateCode(length int) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("invalid code length: %d", length)
+	}
+
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codeAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}